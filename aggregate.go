@@ -0,0 +1,509 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AggregateFunc is a function computing value of bucket from its points
+type AggregateFunc string
+
+// Functions supported by aggregations
+const (
+	AggregateMean  AggregateFunc = "mean"
+	AggregateMin   AggregateFunc = "min"
+	AggregateMax   AggregateFunc = "max"
+	AggregateSum   AggregateFunc = "sum"
+	AggregateFirst AggregateFunc = "first"
+	AggregateLast  AggregateFunc = "last"
+)
+
+// FillPolicy says how buckets without points are returned
+type FillPolicy string
+
+const (
+	// Empty buckets are omitted
+	FillNone FillPolicy = "none"
+	// Empty buckets are returned without values
+	FillNull FillPolicy = "null"
+	// Values of empty buckets are zero
+	FillZero FillPolicy = "zero"
+	// Values of empty buckets are copied from the previous non-empty one
+	FillPrevious FillPolicy = "previous"
+)
+
+// AggregateMode says where aggregation is computed
+type AggregateMode int
+
+const (
+	// Aggregation is requested from CloudThing and computed locally if the server doesn't support it
+	AggregateAuto AggregateMode = iota
+	// Aggregation is only requested from CloudThing
+	AggregateServer
+	// Aggregation is always computed locally from raw points
+	AggregateLocal
+)
+
+// Aggregation describes aggregation of data series into buckets of fixed interval.
+// Buckets are aligned to multiples of Interval since Unix epoch, in UTC.
+//
+//	buckets, err := client.Resources.AggregateDataByDeviceID(id, &api.Aggregation{
+//		Interval:   time.Hour,
+//		Functions:  []api.AggregateFunc{api.AggregateMean, api.AggregateMax},
+//		GroupByKey: true,
+//	}, api.TimeRange(start, end))
+type Aggregation struct {
+	Interval time.Duration
+	// Functions computed for every bucket, mean if empty. Count is always computed.
+	Functions []AggregateFunc
+	// FillNone if empty
+	Fill FillPolicy
+	// Whether points of every key are aggregated separately, otherwise all points
+	// are aggregated together and returned under empty key
+	GroupByKey bool
+	// Keys which are aggregated, all if empty
+	Keys []string
+	Mode AggregateMode
+}
+
+// Bucket holds aggregated values of points within [Start, Start+Interval).
+// Values of functions which weren't requested are nil, as are values of empty
+// buckets unless they are filled. Only numeric values are aggregated, other
+// points are counted.
+type Bucket struct {
+	Start time.Time `json:"start"`
+	Count int64     `json:"count"`
+	Mean  *float64  `json:"mean,omitempty"`
+	Min   *float64  `json:"min,omitempty"`
+	Max   *float64  `json:"max,omitempty"`
+	Sum   *float64  `json:"sum,omitempty"`
+	First *float64  `json:"first,omitempty"`
+	Last  *float64  `json:"last,omitempty"`
+}
+
+// Value returns value of function f, nil if it wasn't computed
+func (b *Bucket) Value(f AggregateFunc) *float64 {
+	switch f {
+	case AggregateMean:
+		return b.Mean
+	case AggregateMin:
+		return b.Min
+	case AggregateMax:
+		return b.Max
+	case AggregateSum:
+		return b.Sum
+	case AggregateFirst:
+		return b.First
+	case AggregateLast:
+		return b.Last
+	}
+	return nil
+}
+
+// AggregatesResponse is a response from CloudThing when calling for aggregates resource
+type AggregatesResponse struct {
+	Items []struct {
+		Key     string   `json:"key"`
+		Buckets []Bucket `json:"buckets"`
+	} `json:"items"`
+}
+
+// Validate checks whether aggregation may be computed
+func (a *Aggregation) Validate() error {
+	if a.Interval <= 0 {
+		return fmt.Errorf("aggregation interval has to be positive")
+	}
+	for _, f := range a.Functions {
+		switch f {
+		case AggregateMean, AggregateMin, AggregateMax, AggregateSum, AggregateFirst, AggregateLast:
+		default:
+			return fmt.Errorf("unsupported aggregate function %q", f)
+		}
+	}
+	switch a.Fill {
+	case "", FillNone, FillNull, FillZero, FillPrevious:
+	default:
+		return fmt.Errorf("unsupported fill policy %q", a.Fill)
+	}
+	return nil
+}
+
+func (a *Aggregation) functions() []AggregateFunc {
+	if len(a.Functions) == 0 {
+		return []AggregateFunc{AggregateMean}
+	}
+	return a.Functions
+}
+
+func (a *Aggregation) fill() FillPolicy {
+	if a.Fill == "" {
+		return FillNone
+	}
+	return a.Fill
+}
+
+func (a *Aggregation) String() string {
+	funcs := make([]string, 0, len(a.functions()))
+	for _, f := range a.functions() {
+		funcs = append(funcs, string(f))
+	}
+	params := []string{
+		"interval=" + formatInterval(a.Interval),
+		"functions=" + url.QueryEscape(strings.Join(funcs, ",")),
+		"fill=" + string(a.fill()),
+	}
+	if a.GroupByKey {
+		params = append(params, "groupBy=key")
+	}
+	if len(a.Keys) > 0 {
+		params = append(params, "keys="+url.QueryEscape(strings.Join(a.Keys, ",")))
+	}
+	return strings.Join(params, "&")
+}
+
+// formatInterval formats d with the largest unit it is a multiple of, e.g. 2h or 90s
+func formatInterval(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	case d%time.Second == 0:
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+	return fmt.Sprintf("%dms", d/time.Millisecond)
+}
+
+// AggregateDataByDeviceID requests from CloudThing aggregated data of device, grouped by key
+func (s *ResourcesServiceOp) AggregateDataByDeviceID(deviceID string, agg *Aggregation, filters ...interface{}) (map[string][]Bucket, error) {
+	return s.AggregateDataByDeviceIDContext(context.Background(), deviceID, agg, filters...)
+}
+
+// AggregateDataByDeviceIDContext is like AggregateDataByDeviceID but uses ctx for the underlying requests.
+func (s *ResourcesServiceOp) AggregateDataByDeviceIDContext(ctx context.Context, deviceID string, agg *Aggregation, filters ...interface{}) (map[string][]Bucket, error) {
+	ctx = withOperation(ctx, "Resources", "AggregateDataByDeviceID")
+	endpoint := fmt.Sprintf("devices/%s/resources", deviceID)
+
+	return s.AggregateDataByLinkContext(ctx, endpoint, agg, filters...)
+}
+
+// AggregateDataByClusterID requests from CloudThing aggregated data of cluster, grouped by key
+func (s *ResourcesServiceOp) AggregateDataByClusterID(clusterID string, agg *Aggregation, filters ...interface{}) (map[string][]Bucket, error) {
+	return s.AggregateDataByClusterIDContext(context.Background(), clusterID, agg, filters...)
+}
+
+// AggregateDataByClusterIDContext is like AggregateDataByClusterID but uses ctx for the underlying requests.
+func (s *ResourcesServiceOp) AggregateDataByClusterIDContext(ctx context.Context, clusterID string, agg *Aggregation, filters ...interface{}) (map[string][]Bucket, error) {
+	ctx = withOperation(ctx, "Resources", "AggregateDataByClusterID")
+	endpoint := fmt.Sprintf("clusters/%s/resources", clusterID)
+
+	return s.AggregateDataByLinkContext(ctx, endpoint, agg, filters...)
+}
+
+// AggregateDataByLink requests aggregated data of resources link, e.g. Device.ResourcesLink().
+// Depending on agg.Mode, data may be aggregated locally from raw points, which are
+// then requested with filters.
+func (s *ResourcesServiceOp) AggregateDataByLink(link string, agg *Aggregation, filters ...interface{}) (map[string][]Bucket, error) {
+	return s.AggregateDataByLinkContext(context.Background(), link, agg, filters...)
+}
+
+// AggregateDataByLinkContext is like AggregateDataByLink but uses ctx for the underlying requests.
+func (s *ResourcesServiceOp) AggregateDataByLinkContext(ctx context.Context, link string, agg *Aggregation, filters ...interface{}) (map[string][]Bucket, error) {
+	ctx = withOperation(ctx, "Resources", "AggregateDataByLink")
+	if err := agg.Validate(); err != nil {
+		return nil, err
+	}
+	// range of local aggregation has to match range of requested points
+	filters = resolveTimeParams(filters)
+	link = strings.TrimSuffix(link, "/")
+
+	if agg.Mode != AggregateLocal {
+		res, err := s.aggregate(ctx, link, agg, filters...)
+		if err == nil || agg.Mode == AggregateServer || !aggregationUnsupported(err) {
+			return res, err
+		}
+		s.client.log(ctx, LevelInfo, "aggregation not supported by server, computing locally", "link", link)
+	}
+
+	var points []DataPoint
+	links := []string{link + "/data"}
+	if len(agg.Keys) > 0 {
+		links = links[:0]
+		for _, key := range agg.Keys {
+			links = append(links, link+"/data/"+url.PathEscape(key))
+		}
+	}
+	for _, l := range links {
+		stream := newStream[DataPoint](ctx, s.client, l, filters...)
+		for stream.Next() {
+			points = append(points, stream.Value())
+		}
+		if err := stream.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	var start, end time.Time
+	for _, f := range filters {
+		if t, ok := f.(*TimeParams); ok && t != nil {
+			from, to := t.Bounds(time.Now())
+			// excluded bounds are moved to the nearest time points may have
+			if from != nil {
+				start = *from
+				if t.ExcludeStart {
+					start = start.Add(time.Nanosecond)
+				}
+			}
+			if to != nil {
+				end = *to
+				if t.ExcludeEnd {
+					end = end.Add(-time.Nanosecond)
+				}
+			}
+		}
+	}
+	return AggregatePoints(points, agg, start, end)
+}
+
+// aggregate requests aggregation computed by CloudThing
+func (s *ResourcesServiceOp) aggregate(ctx context.Context, link string, agg *Aggregation, filters ...interface{}) (map[string][]Bucket, error) {
+	obj := &AggregatesResponse{}
+	endpoint := link + "/aggregates?" + agg.String()
+	if err := s.getResourcesByEndpoint(ctx, obj, endpoint, filters...); err != nil {
+		return nil, err
+	}
+	res := make(map[string][]Bucket, len(obj.Items))
+	for _, item := range obj.Items {
+		res[item.Key] = append(res[item.Key], item.Buckets...)
+	}
+	return res, nil
+}
+
+// aggregationUnsupported checks whether err means that server can't aggregate
+func aggregationUnsupported(err error) bool {
+	var apiErr ApiError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
+
+// AggregatePoints computes aggregation of points locally, giving the same result as
+// CloudThing. Start and end, if not zero, are inclusive bounds of the range filled
+// according to agg.Fill, otherwise it spans buckets of the first and the last point.
+// Points outside of the range and of keys other than agg.Keys are skipped. When both
+// bounds are set, series are filled even without points: the one under empty key, or
+// those of agg.Keys if grouped by key.
+func AggregatePoints(points []DataPoint, agg *Aggregation, start, end time.Time) (map[string][]Bucket, error) {
+	if err := agg.Validate(); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]bool, len(agg.Keys))
+	for _, k := range agg.Keys {
+		keys[k] = true
+	}
+
+	// accumulated buckets of every series, keyed by start
+	series := make(map[string]map[int64]*accumulator)
+	for _, p := range points {
+		if len(keys) > 0 && !keys[p.Key] {
+			continue
+		}
+		t, err := p.Timestamp()
+		if err != nil {
+			return nil, fmt.Errorf("point of key %q: %w", p.Key, err)
+		}
+		if (!start.IsZero() && t.Before(start)) || (!end.IsZero() && t.After(end)) {
+			continue
+		}
+		key := ""
+		if agg.GroupByKey {
+			key = p.Key
+		}
+		buckets, ok := series[key]
+		if !ok {
+			buckets = make(map[int64]*accumulator)
+			series[key] = buckets
+		}
+		bucket := bucketStart(t, agg.Interval).UnixNano()
+		acc, ok := buckets[bucket]
+		if !ok {
+			acc = &accumulator{}
+			buckets[bucket] = acc
+		}
+		acc.add(t, p)
+	}
+
+	if agg.fill() != FillNone && !start.IsZero() && !end.IsZero() {
+		keys := []string{""}
+		if agg.GroupByKey {
+			keys = agg.Keys
+		}
+		for _, key := range keys {
+			if _, ok := series[key]; !ok {
+				series[key] = make(map[int64]*accumulator)
+			}
+		}
+	}
+
+	res := make(map[string][]Bucket, len(series))
+	for key, buckets := range series {
+		res[key] = collectBuckets(buckets, agg, start, end)
+	}
+	return res, nil
+}
+
+// bucketStart returns start of bucket of interval containing t, aligned to multiples
+// of interval since Unix epoch
+func bucketStart(t time.Time, interval time.Duration) time.Time {
+	n := t.UnixNano()
+	r := n % int64(interval)
+	if r < 0 {
+		r += int64(interval)
+	}
+	return time.Unix(0, n-r).UTC()
+}
+
+// accumulator gathers points of single bucket
+type accumulator struct {
+	count    int64
+	numbers  int64
+	sum      float64
+	min, max float64
+	// values and times of the first and the last numeric point
+	first, last         float64
+	firstTime, lastTime time.Time
+}
+
+func (a *accumulator) add(t time.Time, p DataPoint) {
+	a.count++
+	v, err := p.FloatValue()
+	if err != nil {
+		return
+	}
+	if a.numbers == 0 || v < a.min {
+		a.min = v
+	}
+	if a.numbers == 0 || v > a.max {
+		a.max = v
+	}
+	if a.numbers == 0 || t.Before(a.firstTime) {
+		a.first, a.firstTime = v, t
+	}
+	if a.numbers == 0 || !t.Before(a.lastTime) {
+		a.last, a.lastTime = v, t
+	}
+	a.numbers++
+	a.sum += v
+}
+
+// bucket converts accumulated points into bucket with requested functions
+func (a *accumulator) bucket(start time.Time, funcs []AggregateFunc) Bucket {
+	b := Bucket{Start: start, Count: a.count}
+	if a.numbers == 0 {
+		return b
+	}
+	for _, f := range funcs {
+		var v float64
+		switch f {
+		case AggregateMean:
+			v = a.sum / float64(a.numbers)
+		case AggregateMin:
+			v = a.min
+		case AggregateMax:
+			v = a.max
+		case AggregateSum:
+			v = a.sum
+		case AggregateFirst:
+			v = a.first
+		case AggregateLast:
+			v = a.last
+		}
+		b.setValue(f, v)
+	}
+	return b
+}
+
+func (b *Bucket) setValue(f AggregateFunc, v float64) {
+	switch f {
+	case AggregateMean:
+		b.Mean = &v
+	case AggregateMin:
+		b.Min = &v
+	case AggregateMax:
+		b.Max = &v
+	case AggregateSum:
+		b.Sum = &v
+	case AggregateFirst:
+		b.First = &v
+	case AggregateLast:
+		b.Last = &v
+	}
+}
+
+// collectBuckets orders buckets of series and fills empty ones within range
+func collectBuckets(buckets map[int64]*accumulator, agg *Aggregation, start, end time.Time) []Bucket {
+	starts := make([]int64, 0, len(buckets))
+	for s := range buckets {
+		starts = append(starts, s)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+
+	funcs := agg.functions()
+	fill := agg.fill()
+	res := make([]Bucket, 0, len(starts))
+	if fill == FillNone {
+		for _, s := range starts {
+			res = append(res, buckets[s].bucket(time.Unix(0, s).UTC(), funcs))
+		}
+		return res
+	}
+
+	var first, last time.Time
+	if len(starts) > 0 {
+		first, last = time.Unix(0, starts[0]).UTC(), time.Unix(0, starts[len(starts)-1]).UTC()
+	}
+	if !start.IsZero() {
+		first = bucketStart(start, agg.Interval)
+	}
+	if !end.IsZero() {
+		last = bucketStart(end, agg.Interval)
+	}
+	if first.IsZero() || last.IsZero() {
+		return res
+	}
+	var previous *Bucket
+	for t := first; !t.After(last); t = t.Add(agg.Interval) {
+		if acc, ok := buckets[t.UnixNano()]; ok {
+			b := acc.bucket(t, funcs)
+			res = append(res, b)
+			if acc.numbers > 0 {
+				previous = &b
+			}
+			continue
+		}
+		b := Bucket{Start: t}
+		for _, f := range funcs {
+			switch {
+			case fill == FillZero:
+				b.setValue(f, 0)
+			case fill == FillPrevious && previous != nil:
+				if v := previous.Value(f); v != nil {
+					b.setValue(f, *v)
+				}
+			}
+		}
+		res = append(res, b)
+	}
+	return res
+}
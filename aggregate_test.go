@@ -0,0 +1,79 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAggregatePointsAlignsToEpoch(t *testing.T) {
+	interval := 7 * time.Minute
+	at := time.Date(2024, 3, 1, 10, 3, 0, 0, time.UTC)
+	points := []DataPoint{NewDataPoint("t", 1.0, at)}
+
+	res, err := AggregatePoints(points, &Aggregation{Interval: interval}, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buckets := res[""]
+	if len(buckets) != 1 {
+		t.Fatalf("got %d buckets, want 1", len(buckets))
+	}
+	start := buckets[0].Start
+	if start.UnixNano()%int64(interval) != 0 {
+		t.Errorf("bucket starts at %s, not a multiple of %s since epoch", start, interval)
+	}
+	if at.Before(start) || !at.Before(start.Add(interval)) {
+		t.Errorf("bucket [%s, +%s) doesn't contain point at %s", start, interval, at)
+	}
+}
+
+func TestAggregatePointsFillsWithoutPoints(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+
+	res, err := AggregatePoints(nil, &Aggregation{Interval: time.Hour, Fill: FillZero}, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buckets := res[""]
+	if len(buckets) != 4 {
+		t.Fatalf("got %d buckets, want 4", len(buckets))
+	}
+	for i, b := range buckets {
+		if want := start.Add(time.Duration(i) * time.Hour); !b.Start.Equal(want) {
+			t.Errorf("bucket %d starts at %s, want %s", i, b.Start, want)
+		}
+		if b.Mean == nil || *b.Mean != 0 || b.Count != 0 {
+			t.Errorf("bucket %d = %+v, want zero filled", i, b)
+		}
+	}
+
+	res, err = AggregatePoints(nil, &Aggregation{Interval: time.Hour, Fill: FillNull, GroupByKey: true, Keys: []string{"a", "b"}}, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res["a"]) != 4 || len(res["b"]) != 4 {
+		t.Errorf("got %d and %d buckets of keys, want 4 each", len(res["a"]), len(res["b"]))
+	}
+}
+
+func TestAggregatePointsExcludedEnd(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	points := []DataPoint{
+		NewDataPoint("t", 1.0, start),
+		NewDataPoint("t", 5.0, end),
+	}
+	// bounds as moved by AggregateDataByLink for ExcludeEnd
+	res, err := AggregatePoints(points, &Aggregation{Interval: time.Hour, Fill: FillZero, Functions: []AggregateFunc{AggregateSum}}, start, end.Add(-time.Nanosecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	buckets := res[""]
+	if len(buckets) != 2 {
+		t.Fatalf("got %d buckets, want 2", len(buckets))
+	}
+	if *buckets[0].Sum != 1 || *buckets[1].Sum != 0 {
+		t.Errorf("sums = %v, %v, want 1, 0", *buckets[0].Sum, *buckets[1].Sum)
+	}
+}
@@ -0,0 +1,150 @@
+package api_test
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+)
+
+// aggregatedDevice adds device with data of keys a, b and c over three hours
+func aggregatedDevice(t *testing.T, srv *apitest.Server, client *api.Client) (string, time.Time) {
+	t.Helper()
+	id := srv.Add("devices", map[string]interface{}{}, nil)
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	var data []api.DataPoint
+	for i := 0; i < 12; i++ {
+		at := start.Add(time.Duration(i) * 15 * time.Minute)
+		data = append(data, api.NewDataPoint("a", float64(i), at), api.NewDataPoint("b", float64(-i), at), api.NewDataPoint("c", 1.0, at))
+	}
+	if _, err := client.Resources.WriteDataForDeviceID(id, data); err != nil {
+		t.Fatal(err)
+	}
+	return id, start
+}
+
+func TestAggregateServer(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, start := aggregatedDevice(t, srv, client)
+	agg := &api.Aggregation{
+		Interval:   time.Hour,
+		Functions:  []api.AggregateFunc{api.AggregateMean, api.AggregateMax},
+		Fill:       api.FillZero,
+		GroupByKey: true,
+		Keys:       []string{"a", "b"},
+		Mode:       api.AggregateServer,
+	}
+	rng := api.TimeRange(start, start.Add(4*time.Hour))
+
+	res, err := client.Resources.AggregateDataByDeviceID(id, agg, rng)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.Requests()
+	last := reqs[len(reqs)-1]
+	if last.Path != "devices/"+id+"/resources/aggregates" {
+		t.Fatalf("requested %s, want aggregates", last.Path)
+	}
+	want := map[string]string{
+		"interval": "1h", "functions": "mean,max", "fill": "zero", "groupBy": "key", "keys": "a,b",
+		"start": "2024-03-01T10:00:00Z", "end": "2024-03-01T14:00:00Z",
+	}
+	for k, v := range want {
+		if got := last.Query.Get(k); got != v {
+			t.Errorf("query %s = %q, want %q", k, got, v)
+		}
+	}
+	if len(res) != 2 || len(res["a"]) != 5 || len(res["b"]) != 5 {
+		t.Fatalf("got %d series with %d and %d buckets, want a and b with 5 each", len(res), len(res["a"]), len(res["b"]))
+	}
+	if b := res["a"][0]; b.Count != 4 || *b.Mean != 1.5 || *b.Max != 3 || b.Min != nil {
+		t.Errorf("first bucket of a = %+v, want count 4, mean 1.5 and max 3", b)
+	}
+	if b := res["b"][4]; b.Count != 0 || *b.Mean != 0 {
+		t.Errorf("last bucket of b = %+v, want filled with zero", b)
+	}
+
+	agg.Mode = api.AggregateLocal
+	local, err := client.Resources.AggregateDataByDeviceID(id, agg, rng)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(res, local) {
+		t.Errorf("server aggregated %+v, local aggregation gives %+v", res, local)
+	}
+}
+
+func TestAggregateAutoFallback(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, start := aggregatedDevice(t, srv, client)
+	agg := &api.Aggregation{Interval: time.Hour, Functions: []api.AggregateFunc{api.AggregateSum}}
+	rng := api.TimeRange(start, start.Add(3*time.Hour))
+	link := "devices/" + id + "/resources/"
+
+	want, err := client.Resources.AggregateDataByDeviceID(id, agg, rng)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, status := range []int{http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented} {
+		srv.Fail("GET", link+"aggregates", status, 1, nil)
+		data := countRequests(srv, "GET", link+"data")
+		res, err := client.Resources.AggregateDataByDeviceID(id, agg, rng)
+		if err != nil {
+			t.Errorf("aggregation after %d = %v", status, err)
+			continue
+		}
+		if countRequests(srv, "GET", link+"data") == data {
+			t.Errorf("data not requested after %d", status)
+		}
+		if !reflect.DeepEqual(res, want) {
+			t.Errorf("aggregated locally after %d: %+v, want %+v", status, res, want)
+		}
+	}
+
+	// other errors aren't fallen back from
+	srv.Fail("GET", link+"aggregates", http.StatusForbidden, 1, nil)
+	if _, err := client.Resources.AggregateDataByDeviceID(id, agg, rng); !api.IsForbidden(err) {
+		t.Errorf("aggregation after 403 = %v, want forbidden", err)
+	}
+}
+
+func TestAggregateServerUnsupported(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	srv.DisableAggregates = true
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, start := aggregatedDevice(t, srv, client)
+	rng := api.TimeRange(start, start.Add(3*time.Hour))
+
+	_, err = client.Resources.AggregateDataByDeviceID(id, &api.Aggregation{Interval: time.Hour, Mode: api.AggregateServer}, rng)
+	if apiErr, ok := api.AsApiError(err); !ok || apiErr.StatusCode != http.StatusNotImplemented || apiErr.Code != "not_implemented" {
+		t.Errorf("server aggregation = %v, want 501 returned unchanged", err)
+	}
+	if n := countRequests(srv, "GET", "devices/"+id+"/resources/data"); n != 0 {
+		t.Errorf("data requested %d times by server aggregation", n)
+	}
+
+	res, err := client.Resources.AggregateDataByDeviceID(id, &api.Aggregation{Interval: time.Hour}, rng)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res[""]) != 3 || res[""][0].Count != 12 {
+		t.Errorf("aggregated %+v, want 3 buckets of 12 points", res)
+	}
+}
@@ -1,12 +1,17 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
@@ -53,12 +58,49 @@ type Client struct {
 	// User agent for client
 	UserAgent string
 
+	// Guards token, tenantId, credentials, refresh and schemas
+	mu sync.Mutex
+
 	// JWT token for authorization
 	token *Token
 
 	// Tenant ID
 	tenantId string
 
+	// Source of new tokens, nil if token can't be refreshed
+	credentials Credentials
+
+	// Retrieval of token in flight, nil if there is none
+	refresh *tokenRefresh
+
+	// Policy of retrying failed requests, nil if requests are not retried
+	retryPolicy *RetryPolicy
+
+	// Limiter shared by all services, nil if requests are not limited
+	rateLimiter *RateLimiter
+
+	// Logger for diagnostic messages, nil if disabled
+	logger Logger
+
+	// Whether headers and bodies are logged
+	logBodies atomic.Bool
+
+	// Products of devices used for validation of writes, nil if disabled
+	schemas *schemaCache
+
+	// Configuration of subscriptions to resources
+	subscriptions SubscriptionOptions
+
+	// Expansions used for GET requests without their own
+	defaultExpand *Expansion
+
+	// Middleware wrapping sending of requests, the first one is the outermost
+	middleware []Middleware
+
+	// Services used internally for converting responses into models, they stay
+	// in place when public services below are replaced, e.g. with mocks
+	conv services
+
 	// Services used for communication
 	Tenant             TenantService
 	Directories        DirectoriesService
@@ -77,6 +119,25 @@ type Client struct {
 	Resources          ResourcesService
 }
 
+// services holds concrete implementations of all services
+type services struct {
+	tenant             *TenantServiceOp
+	directories        *DirectoriesServiceOp
+	applications       *ApplicationsServiceOp
+	products           *ProductsServiceOp
+	devices            *DevicesServiceOp
+	clusters           *ClustersServiceOp
+	groups             *GroupsServiceOp
+	users              *UsersServiceOp
+	clusterMemberships *ClusterMembershipsServiceOp
+	groupMemberships   *GroupMembershipsServiceOp
+	memberships        *MembershipsServiceOp
+	usergroups         *UsergroupsServiceOp
+	apikeys            *ApikeysServiceOp
+	exports            *ExportsServiceOp
+	resources          *ResourcesServiceOp
+}
+
 // ListOptions specifies the optional parameters for requests with pagination support
 type ListOptions struct {
 	// Page of results to retrieve
@@ -93,6 +154,9 @@ type ListParams struct {
 	Next  *Link  `json:"next,omitempty"`
 }
 
+// ExpandParams lists relations to expand with pagination of expanded collections.
+//
+// Deprecated: use Expansion which supports nested relations.
 type ExpandParams map[string]*ListOptions
 
 func (l ListOptions) String() string {
@@ -100,19 +164,26 @@ func (l ListOptions) String() string {
 }
 
 func (e ExpandParams) String() string {
-	str := "expand="
-	ind := 0
-	for k, v := range e {
-		if ind != 0 {
-			str = fmt.Sprintf("%s,", str)
-		}
-		str = fmt.Sprintf("%s%s", str, k)
-		if v != nil {
-			str = fmt.Sprintf("%s(limit:%d,page:%d)", str, v.Limit, v.Page)
+	return e.Expansion().String()
+}
+
+// Expansion converts map of relations into Expansion, relations are sorted by name
+func (e ExpandParams) Expansion() *Expansion {
+	names := make([]string, 0, len(e))
+	for k := range e {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	exp := &Expansion{}
+	for _, k := range names {
+		if v := e[k]; v != nil {
+			exp.Expand(k, Limit(v.Limit), Page(v.Page))
+		} else {
+			exp.Expand(k)
 		}
-		ind++
 	}
-	return str
+	return exp
 }
 
 func (m ModelBase) GetId() string {
@@ -129,35 +200,81 @@ const (
 
 // NewClient returns a new CloudThing API client
 func NewClient(httpClient *http.Client, baseURL string) (*Client, error) {
-	if httpClient == nil {
-		httpClient = http.DefaultClient
+	return NewClientWithOptions(WithHTTPClient(httpClient), WithBaseURL(baseURL))
+}
+
+// NewClientWithOptions returns a new CloudThing API client configured with opts
+func NewClientWithOptions(opts ...Option) (*Client, error) {
+	o := &clientOptions{
+		apiPath:   apiEndpoint,
+		userAgent: userAgent,
+	}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
 	}
 
-	base, err := url.Parse(baseURL + apiEndpoint)
+	httpClient, err := o.client()
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := url.Parse(strings.TrimSuffix(o.baseURL, "/") + o.apiPath)
 	if err != nil {
 		return nil, err
 	}
 
 	c := &Client{
-		client:    httpClient,
-		BaseURL:   base,
-		UserAgent: userAgent,
-	}
-
-	c.Tenant = &TenantServiceOp{client: c}
-	c.Directories = &DirectoriesServiceOp{client: c}
-	c.Applications = &ApplicationsServiceOp{client: c}
-	c.Products = &ProductsServiceOp{client: c}
-	c.Devices = &DevicesServiceOp{client: c}
-	c.Clusters = &ClustersServiceOp{client: c}
-	c.Users = &UsersServiceOp{client: c}
-	c.ClusterMemberships = &ClusterMembershipsServiceOp{client: c}
-	c.GroupMemberships = &GroupMembershipsServiceOp{client: c}
-	c.Memberships = &MembershipsServiceOp{client: c}
-	c.Usergroups = &UsergroupsServiceOp{client: c}
-	c.Apikeys = &ApikeysServiceOp{client: c}
-	c.Exports = &ExportsServiceOp{client: c}
-	c.Resources = &ResourcesServiceOp{client: c}
+		client:        httpClient,
+		BaseURL:       base,
+		UserAgent:     o.userAgent,
+		credentials:   o.credentials,
+		retryPolicy:   o.retryPolicy,
+		rateLimiter:   o.rateLimiter,
+		logger:        o.logger,
+		defaultExpand: o.expand,
+		middleware:    o.middleware,
+		subscriptions: o.subscriptions,
+	}
+	c.logBodies.Store(o.logBodies)
+	if o.schemaValidation {
+		c.schemas = newSchemaCache(o.schemaTTL)
+	}
+
+	c.conv = services{
+		tenant:             &TenantServiceOp{client: c},
+		directories:        &DirectoriesServiceOp{client: c},
+		applications:       &ApplicationsServiceOp{client: c},
+		products:           &ProductsServiceOp{client: c},
+		devices:            &DevicesServiceOp{client: c},
+		clusters:           &ClustersServiceOp{client: c},
+		groups:             &GroupsServiceOp{client: c},
+		users:              &UsersServiceOp{client: c},
+		clusterMemberships: &ClusterMembershipsServiceOp{client: c},
+		groupMemberships:   &GroupMembershipsServiceOp{client: c},
+		memberships:        &MembershipsServiceOp{client: c},
+		usergroups:         &UsergroupsServiceOp{client: c},
+		apikeys:            &ApikeysServiceOp{client: c},
+		exports:            &ExportsServiceOp{client: c},
+		resources:          &ResourcesServiceOp{client: c},
+	}
+
+	c.Tenant = c.conv.tenant
+	c.Directories = c.conv.directories
+	c.Applications = c.conv.applications
+	c.Products = c.conv.products
+	c.Devices = c.conv.devices
+	c.Clusters = c.conv.clusters
+	c.Groups = c.conv.groups
+	c.Users = c.conv.users
+	c.ClusterMemberships = c.conv.clusterMemberships
+	c.GroupMemberships = c.conv.groupMemberships
+	c.Memberships = c.conv.memberships
+	c.Usergroups = c.conv.usergroups
+	c.Apikeys = c.conv.apikeys
+	c.Exports = c.conv.exports
+	c.Resources = c.conv.resources
 
 	return c, nil
 }
@@ -169,42 +286,51 @@ func (c *Client) SetUserAgent(ua string) {
 
 // SetBasicAuth uses provided basic authorization params for authenticating against
 // CloudThing API and retrieves and stores JWT token if succeeded for future requests.
+// Params are kept as credentials, so token is refreshed once it expires.
 func (c *Client) SetBasicAuth(username, password string) error {
-	token, err := c.GetAuthToken(username, password, "")
-	if err == nil {
-		c.setToken(token)
-	}
-	return err
+	return c.SetBasicAuthContext(context.Background(), username, password)
+}
+
+// SetBasicAuthContext is like SetBasicAuth but uses ctx for the token request.
+func (c *Client) SetBasicAuthContext(ctx context.Context, username, password string) error {
+	return c.SetCredentialsContext(ctx, &BasicCredentials{Username: username, Password: password})
 }
 
 // GetAuthToken uses provided basic authorization params for authenticating against
 // CloudThing API and retrieves and returns JWT token.
 func (c *Client) GetAuthToken(username, password, application string) (*Token, error) {
+	return c.GetAuthTokenContext(context.Background(), username, password, application)
+}
+
+// GetAuthTokenContext is like GetAuthToken but uses ctx for the token request.
+func (c *Client) GetAuthTokenContext(ctx context.Context, username, password, application string) (*Token, error) {
 	endpoint := "auth/token"
 	if application != "" {
-		endpoint = fmt.Sprintf("%s?application=%s", endpoint, application)
+		endpoint = fmt.Sprintf("%s?application=%s", endpoint, url.QueryEscape(application))
 	}
 	endp, err := url.Parse(endpoint)
 	if err != nil {
 		return nil, err
 	}
-	fmt.Println(endpoint)
 
 	u := c.BaseURL.ResolveReference(endp)
+	href := *u
+	href.RawQuery = ""
+	ctx = startOperation(newOperation(ctx, "Client", "GetAuthToken"), href.String(), "")
 
-	req, err := http.NewRequest("POST", u.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 	req.SetBasicAuth(username, password)
-	resp, err := c.client.Do(req)
+	resp, err := c.roundTrip(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, ApiError{StatusCode: resp.StatusCode, Message: "Failed to authenticate user"}
+		return nil, newApiError(resp, "Failed to authenticate user")
 	}
 
 	token := &Token{}
@@ -217,29 +343,43 @@ func (c *Client) GetAuthToken(username, password, application string) (*Token, e
 // SetTokenAuth uses provided JWT token for authenticating against CloudThing API
 // and stores it if succeeded for future requests.
 func (c *Client) SetTokenAuth(token *Token) error {
-	req, err := http.NewRequest("GET", c.BaseURL.String(), nil)
+	return c.SetTokenAuthContext(context.Background(), token)
+}
+
+// SetTokenAuthContext is like SetTokenAuth but uses ctx for the verification request.
+func (c *Client) SetTokenAuthContext(ctx context.Context, token *Token) error {
+	ctx = startOperation(newOperation(ctx, "Client", "SetTokenAuth"), c.BaseURL.String(), "")
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL.String(), nil)
 	if err != nil {
 		return err
 	}
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token.Token))
-	resp, err := c.client.Do(req)
+	resp, err := c.roundTrip(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return ApiError{StatusCode: resp.StatusCode, Message: "Failed to authenticate user"}
+		return newApiError(resp, "Failed to authenticate user")
 	}
 
-	c.setToken(token)
-	return nil
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.setToken(token)
 }
 
+// RevokeToken invalidates currently stored JWT token on CloudThing API side.
 func (c *Client) RevokeToken() error {
+	return c.RevokeTokenContext(context.Background())
+}
+
+// RevokeTokenContext is like RevokeToken but uses ctx for the revoke request.
+func (c *Client) RevokeTokenContext(ctx context.Context) error {
 	if !c.IsAuthenticated() {
 		return fmt.Errorf("Client is not authenticated")
 	}
+	token := c.GetToken()
 	endpoint := "auth/token"
 	endp, err := url.Parse(endpoint)
 	if err != nil {
@@ -247,57 +387,123 @@ func (c *Client) RevokeToken() error {
 	}
 
 	u := c.BaseURL.ResolveReference(endp)
-	req, err := http.NewRequest("DELETE", u.String(), nil)
+	ctx = startOperation(newOperation(ctx, "Client", "RevokeToken"), u.String(), c.currentTenantId(ctx))
+	req, err := http.NewRequestWithContext(ctx, "DELETE", u.String(), nil)
 	if err != nil {
 		return err
 	}
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.token.Token))
-	resp, err := c.client.Do(req)
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token.Token))
+	resp, err := c.roundTrip(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent {
-		return ApiError{StatusCode: resp.StatusCode, Message: "Failed to revoke token"}
+		return newApiError(resp, "Failed to revoke token")
 	}
 
 	return nil
 }
 
-// setToken parses JWT token, extracts tenant ID and sets token and in in client
-func (c *Client) setToken(t *Token) {
+// setToken parses JWT token, extracts tenant ID and sets token and in in client.
+// Tokens without issuer are rejected. c.mu must be held by caller.
+func (c *Client) setToken(t *Token) error {
 	token, _ := jwt.Parse(t.Token, nil)
-	claims := token.Claims.(jwt.MapClaims)
-	iss := strings.Split(claims["iss"].(string), "/")
+	if token == nil {
+		return fmt.Errorf("malformed JWT token")
+	}
+	claims, _ := token.Claims.(jwt.MapClaims)
+	issuer, _ := claims["iss"].(string)
+	if issuer == "" {
+		return fmt.Errorf("JWT token has no issuer identifying tenant")
+	}
+	iss := strings.Split(issuer, "/")
 	c.tenantId = iss[len(iss)-1]
 	c.token = t
+	return nil
 }
 
-// Creates new request or sending to API
-func (c *Client) request(method, endpoint string, body io.Reader, opts ...interface{}) (*http.Response, error) {
-	if !c.IsAuthenticated() {
-		return nil, fmt.Errorf("You need to authenticate first")
+// Creates new request or sending to API. Request is bound to ctx, so cancelling
+// it or exceeding its deadline aborts the call.
+func (c *Client) request(ctx context.Context, method, endpoint string, body io.Reader, opts ...interface{}) (*http.Response, error) {
+	token, err := c.currentToken(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	params := "?"
 	if strings.Contains(endpoint, params) {
 		params = ""
 	}
-	for _, a := range opts {
-		if v, ok := a.(*ListOptions); ok {
-			params = fmt.Sprintf("%s&%s", params, v.String())
-			continue
+	if c.defaultExpand != nil && method == "GET" && endpointFamily(endpoint) == FamilyManagement {
+		// links of following pages already carry expansion of the first one
+		expand := strings.Contains(endpoint, "?expand=") || strings.Contains(endpoint, "&expand=")
+		for _, a := range opts {
+			switch a.(type) {
+			case *ExpandParams, *Expansion:
+				expand = true
+			}
 		}
-		if v, ok := a.(*ExpandParams); ok {
-			params = fmt.Sprintf("%s&%s", params, v.String())
-			continue
+		if exp := c.defaultExpand.supported(resourceType(endpoint)); !expand && exp != nil {
+			opts = append(opts, exp)
 		}
-		if v, ok := a.(*TimeParams); ok {
+	}
+	// latest point is requested with its own paging params
+	latest := latestRequested(opts)
+	for _, a := range opts {
+		switch v := a.(type) {
+		case nil:
+		case *ListOptions:
+			if v != nil && !latest {
+				params = fmt.Sprintf("%s&%s", params, v.String())
+			}
+		case *ExpandParams:
+			if v == nil {
+				continue
+			}
+			exp := v.Expansion()
+			if err := exp.Validate(resourceType(endpoint)); err != nil {
+				return nil, err
+			}
+			params = fmt.Sprintf("%s&%s", params, exp.String())
+		case *Expansion:
+			if v == nil {
+				continue
+			}
+			if err := v.Validate(resourceType(endpoint)); err != nil {
+				return nil, err
+			}
 			params = fmt.Sprintf("%s&%s", params, v.String())
-			continue
+		case *TimeParams:
+			if v == nil {
+				continue
+			}
+			if err := v.Validate(); err != nil {
+				return nil, err
+			}
+			if q := v.String(); q != "" {
+				params = fmt.Sprintf("%s&%s", params, q)
+			}
+		case *PointsOptions:
+			// sent below together with order of TimeParams
+		case *Query:
+			if v == nil {
+				continue
+			}
+			if err := v.Validate(); err != nil {
+				return nil, err
+			}
+			if q := v.String(); q != "" {
+				params = fmt.Sprintf("%s&%s", params, q)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported request option of type %T", a)
 		}
 	}
+	if q := pointsOptions(opts).String(); q != "" {
+		params = fmt.Sprintf("%s&%s", params, q)
+	}
 
 	u, err := url.Parse(fmt.Sprintf("%s%s", endpoint, params))
 	if err != nil {
@@ -307,51 +513,92 @@ func (c *Client) request(method, endpoint string, body io.Reader, opts ...interf
 	if !u.IsAbs() {
 		u = c.BaseURL.ResolveReference(u)
 	}
+	href := *u
+	href.RawQuery, href.ForceQuery = "", false
+	ctx = startOperation(ctx, href.String(), c.currentTenantId(ctx))
+
+	// body is buffered, so request can be retried or sent again with refreshed token
+	var payload []byte
+	if body != nil {
+		payload, err = io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	req, err := http.NewRequest(method, u.String(), body)
+	resp, err := c.send(ctx, method, u.String(), payload, token)
 	if err != nil {
 		return nil, err
 	}
+	if resp.StatusCode == http.StatusUnauthorized && c.canRefresh() {
+		resp.Body.Close()
+		c.log(ctx, LevelInfo, "token rejected, refreshing", "method", method, "url", u.String())
+		token, err = c.refreshToken(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		return c.send(ctx, method, u.String(), payload, token)
+	}
+	return resp, nil
+}
 
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.token.Token))
+// do sends single request authorized with token
+func (c *Client) do(ctx context.Context, method, u string, payload []byte, token *Token) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token.Token))
 	req.Header.Add("Accept", mediaType)
 	req.Header.Add("Content-Type", mediaType)
 	req.Header.Add("User-Agent", c.UserAgent)
+	for k, v := range headerFromContext(ctx) {
+		req.Header[k] = v
+	}
 
-	resp, err := c.client.Do(req)
+	if err := c.rateLimiter.Wait(ctx, method, req.URL.Path); err != nil {
+		return nil, err
+	}
+	resp, err := c.roundTrip(req)
 	if err != nil {
 		return nil, err
 	}
+	c.rateLimiter.Update(method, req.URL.Path, resp)
 	return resp, nil
 }
 
 // Checkes whether Client is authentciated and able to create requests
 func (c *Client) IsAuthenticated() bool {
-	if c.token != nil {
-		t, _ := jwt.Parse(c.token.Token, nil)
-		var exp int64
-		var vexp bool
-		var err error
-		now := time.Now().Unix()
-		claims := t.Claims.(jwt.MapClaims)
-		switch num := claims["exp"].(type) {
-		case json.Number:
-			if exp, err = num.Int64(); err == nil {
-				vexp = true
-			}
-		case float64:
-			vexp = true
-			exp = int64(num)
-		}
-
-		if vexp && exp > now {
-			return true
-		}
-	}
-	return false
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	exp, ok := tokenExpiry(c.token)
+	return ok && time.Now().Before(exp)
 }
 
 // GetToken retr=urn curent JWT token
 func (c *Client) GetToken() *Token {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.token
 }
+
+// currentTenantId returns ID of tenant extracted from stored token. If no token was
+// retrieved yet and credentials are set, it is retrieved first.
+func (c *Client) currentTenantId(ctx context.Context) string {
+	c.mu.Lock()
+	pending := c.tenantId == "" && c.credentials != nil
+	c.mu.Unlock()
+	if pending {
+		// failure is reported by the request which follows
+		c.currentToken(ctx)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tenantId
+}
@@ -1,14 +1,174 @@
 package api
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
 )
 
+// Maximum size of error body kept in ApiError
+const maxErrorBodySize = 1 << 20
+
+// Sentinel errors matched by ApiError with errors.Is
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrValidation   = errors.New("validation failed")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrServer       = errors.New("server error")
+)
+
+// ApiError is returned when CloudThing API responds with unexpected status code
 type ApiError struct {
 	StatusCode int
-	Message string
+	Message    string
+
+	// Error code returned by API, empty if not provided
+	Code string
+	// Errors of particular fields, usually returned for validation failures
+	Errors []FieldError
+	// ID of request assigned by API, useful when contacting support
+	RequestId string
+
+	// Raw response body and headers
+	Body   []byte
+	Header http.Header
+}
+
+// FieldError describes problem with single field of request
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// errorResponse is error payload returned by CloudThing API
+type errorResponse struct {
+	Code      interface{}  `json:"code"`
+	Message   string       `json:"message"`
+	Errors    []FieldError `json:"errors"`
+	RequestId string       `json:"requestId"`
+}
+
+// newApiError creates ApiError from response, decoding error payload if present.
+// Message is used when API didn't return its own message.
+func newApiError(resp *http.Response, message string) ApiError {
+	apiErr := ApiError{
+		StatusCode: resp.StatusCode,
+		Message:    message,
+		Header:     resp.Header,
+		RequestId:  resp.Header.Get("X-Request-Id"),
+	}
+	if resp.Body == nil {
+		return apiErr
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil || len(body) == 0 {
+		return apiErr
+	}
+	apiErr.Body = body
+
+	payload := &errorResponse{}
+	if json.Unmarshal(body, payload) != nil {
+		return apiErr
+	}
+	if payload.Message != "" {
+		apiErr.Message = payload.Message
+	}
+	if payload.Code != nil {
+		apiErr.Code = fmt.Sprint(payload.Code)
+	}
+	if payload.RequestId != "" {
+		apiErr.RequestId = payload.RequestId
+	}
+	apiErr.Errors = payload.Errors
+	return apiErr
 }
 
 func (a ApiError) Error() string {
-	return fmt.Sprintf("Status code: %d, error message: %s", a.StatusCode, a.Message)
+	str := fmt.Sprintf("Status code: %d, error message: %s", a.StatusCode, a.Message)
+	if len(a.Errors) > 0 {
+		fields := make([]string, len(a.Errors))
+		for i, e := range a.Errors {
+			fields[i] = fmt.Sprintf("%s: %s", e.Field, e.Message)
+		}
+		str = fmt.Sprintf("%s (%s)", str, strings.Join(fields, ", "))
+	}
+	if a.RequestId != "" {
+		str = fmt.Sprintf("%s, request ID: %s", str, a.RequestId)
+	}
+	return str
+}
+
+// Is allows to match ApiError against sentinel errors with errors.Is
+func (a ApiError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return a.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return a.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return a.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return a.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return a.StatusCode == http.StatusConflict
+	case ErrValidation:
+		return a.StatusCode == http.StatusUnprocessableEntity ||
+			(a.StatusCode == http.StatusBadRequest && len(a.Errors) > 0)
+	case ErrRateLimited:
+		return a.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return a.StatusCode >= 500
+	}
+	return false
+}
+
+// IsNotFound checks whether err was caused by missing resource
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsConflict checks whether err was caused by conflicting resource state
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsUnauthorized checks whether err was caused by missing or invalid token
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsForbidden checks whether err was caused by insufficient permissions
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+// IsValidation checks whether err was caused by invalid request payload
+func IsValidation(err error) bool {
+	return errors.Is(err, ErrValidation)
+}
+
+// IsRateLimited checks whether err was caused by exceeding API rate limits
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// AsApiError returns ApiError found in err's chain
+func AsApiError(err error) (ApiError, bool) {
+	var apiErr ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	var ptr *ApiError
+	if errors.As(err, &ptr) && ptr != nil {
+		return *ptr, true
+	}
+	return ApiError{}, false
 }
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func errorResponseOf(status int, body string) *http.Response {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("X-Request-Id", "from-header")
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestNewApiError(t *testing.T) {
+	body := `{"code":"invalid_device","message":"Device is invalid","requestId":"req-1",` +
+		`"errors":[{"field":"name","code":"required","message":"is required"}]}`
+	apiErr := newApiError(errorResponseOf(http.StatusUnprocessableEntity, body), "non-ok status returned")
+
+	if apiErr.StatusCode != http.StatusUnprocessableEntity || apiErr.Code != "invalid_device" || apiErr.Message != "Device is invalid" {
+		t.Errorf("error = %+v, want decoded code and message", apiErr)
+	}
+	if len(apiErr.Errors) != 1 || apiErr.Errors[0] != (FieldError{Field: "name", Code: "required", Message: "is required"}) {
+		t.Errorf("field errors = %+v", apiErr.Errors)
+	}
+	if apiErr.RequestId != "req-1" {
+		t.Errorf("request ID = %q, want req-1 from body", apiErr.RequestId)
+	}
+	if string(apiErr.Body) != body || apiErr.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("raw body %q and header %v not kept", apiErr.Body, apiErr.Header)
+	}
+	want := "Status code: 422, error message: Device is invalid (name: is required), request ID: req-1"
+	if apiErr.Error() != want {
+		t.Errorf("Error() = %q, want %q", apiErr.Error(), want)
+	}
+
+	// numeric codes, request ID in header only
+	apiErr = newApiError(errorResponseOf(http.StatusConflict, `{"code":1001}`), "non-ok status returned")
+	if apiErr.Code != "1001" || apiErr.Message != "non-ok status returned" || apiErr.RequestId != "from-header" {
+		t.Errorf("error = %+v, want code 1001, default message and request ID from header", apiErr)
+	}
+
+	// bodies which aren't JSON are kept raw
+	apiErr = newApiError(errorResponseOf(http.StatusBadGateway, "<html>Bad gateway</html>"), "non-ok status returned")
+	if apiErr.Message != "non-ok status returned" || string(apiErr.Body) != "<html>Bad gateway</html>" {
+		t.Errorf("error = %+v, want default message and raw body", apiErr)
+	}
+}
+
+func TestApiErrorSentinels(t *testing.T) {
+	tests := []struct {
+		status   int
+		errors   []FieldError
+		sentinel error
+		is       func(error) bool
+	}{
+		{http.StatusNotFound, nil, ErrNotFound, IsNotFound},
+		{http.StatusConflict, nil, ErrConflict, IsConflict},
+		{http.StatusUnauthorized, nil, ErrUnauthorized, IsUnauthorized},
+		{http.StatusForbidden, nil, ErrForbidden, IsForbidden},
+		{http.StatusUnprocessableEntity, nil, ErrValidation, IsValidation},
+		{http.StatusBadRequest, []FieldError{{Field: "name"}}, ErrValidation, IsValidation},
+		{http.StatusTooManyRequests, nil, ErrRateLimited, IsRateLimited},
+	}
+	sentinels := []error{ErrBadRequest, ErrUnauthorized, ErrForbidden, ErrNotFound, ErrConflict, ErrValidation, ErrRateLimited, ErrServer}
+	for _, tt := range tests {
+		err := fmt.Errorf("getting device: %w", ApiError{StatusCode: tt.status, Errors: tt.errors})
+		if !tt.is(err) || !errors.Is(err, tt.sentinel) {
+			t.Errorf("%d doesn't match %v", tt.status, tt.sentinel)
+		}
+		for _, s := range sentinels {
+			expected := s == tt.sentinel || (s == ErrBadRequest && tt.status == http.StatusBadRequest)
+			if errors.Is(err, s) != expected {
+				t.Errorf("errors.Is(%d, %v) = %v", tt.status, s, !expected)
+			}
+		}
+
+		var apiErr ApiError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+			t.Errorf("errors.As of %d = %+v", tt.status, apiErr)
+		}
+		if apiErr, ok := AsApiError(err); !ok || apiErr.StatusCode != tt.status {
+			t.Errorf("AsApiError of %d = %+v, %v", tt.status, apiErr, ok)
+		}
+	}
+
+	if !errors.Is(ApiError{StatusCode: http.StatusServiceUnavailable}, ErrServer) {
+		t.Error("503 doesn't match ErrServer")
+	}
+	if apiErr, ok := AsApiError(fmt.Errorf("wrapped: %w", &ApiError{StatusCode: http.StatusNotFound})); !ok || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("AsApiError of pointer = %+v, %v", apiErr, ok)
+	}
+	if _, ok := AsApiError(ErrNotFound); ok {
+		t.Error("AsApiError of sentinel succeeded")
+	}
+	if IsNotFound(nil) || IsNotFound(errors.New("not found")) {
+		t.Error("IsNotFound matches errors other than ApiError")
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
     "bytes"
+    "context"
     "encoding/json"
     "fmt"
     "net/http"
@@ -13,18 +14,27 @@ import (
 // https://tenant-name.cloudthing.io/api/v1/apikeys
 type ApikeysService interface {
     GetById(string, ...interface{}) (*Apikey, error)
+    GetByIdContext(context.Context, string, ...interface{}) (*Apikey, error)
     GetByLink(string, ...interface{}) (*Apikey, error)
+    GetByLinkContext(context.Context, string, ...interface{}) (*Apikey, error)
     List(...interface{}) ([]Apikey, *ListParams, error)
+    ListContext(context.Context, ...interface{}) ([]Apikey, *ListParams, error)
+    Iterate(context.Context, ...interface{}) *Iterator[Apikey]
     ListByLink(string, ...interface{}) ([]Apikey, *ListParams, error)
+    ListByLinkContext(context.Context, string, ...interface{}) ([]Apikey, *ListParams, error)
+    IterateByLink(context.Context, string, ...interface{}) *Iterator[Apikey]
     Create(*ApikeyRequestCreate) (*Apikey, error)
+    CreateContext(context.Context, *ApikeyRequestCreate) (*Apikey, error)
     UpdateById(string, *ApikeyRequestUpdate) (*Apikey, error)
+    UpdateByIdContext(context.Context, string, *ApikeyRequestUpdate) (*Apikey, error)
     UpdateByLink(string, *ApikeyRequestUpdate) (*Apikey, error)
+    UpdateByLinkContext(context.Context, string, *ApikeyRequestUpdate) (*Apikey, error)
     Delete(*Apikey) (error)
+    DeleteContext(context.Context, *Apikey) (error)
     DeleteByLink(string) (error)
+    DeleteByLinkContext(context.Context, string) (error)
     DeleteById(string) (error)
-
-    get(*ApikeyResponse) (*Apikey, error)
-    getCollection(*ApikeysResponse) ([]Apikey, *ListParams, error)
+    DeleteByIdContext(context.Context, string) (error)
 }
 
 // ApikeysServiceOp handles communication with Apikeys related methods of API
@@ -112,6 +122,12 @@ func (d *Apikey) ApplicationsLink() (bool, string) {
     return (d.Applications != nil), d.applications
 }
 
+// Credentials returns credentials which authenticate Client with apikey's key and secret.
+// If application is not empty tokens are scoped to that application.
+func (d *Apikey) Credentials(application string) *ApikeyCredentials {
+    return &ApikeyCredentials{Key: d.Key, Secret: d.Secret, Application: application}
+}
+
 // Save is a helper method for updating apikey.
 // It calls UpdateByLink() on service under the hood.
 func (t *Apikey) Save() error {
@@ -145,15 +161,27 @@ func (t *Apikey) Delete() error {
 
 // GetById retrieves apikey by its ID
 func (s *ApikeysServiceOp) GetById(id string, args ...interface{}) (*Apikey, error) {
+    return s.GetByIdContext(context.Background(), id, args...)
+}
+
+// GetByIdContext is like GetById but uses ctx for the underlying request.
+func (s *ApikeysServiceOp) GetByIdContext(ctx context.Context, id string, args ...interface{}) (*Apikey, error) {
+    ctx = withOperation(ctx, "Apikeys", "GetById")
     endpoint := "apikeys/"
     endpoint = fmt.Sprintf("%s%s", endpoint, id)
 
-    return s.GetByLink(endpoint, args...)
+    return s.GetByLinkContext(ctx, endpoint, args...)
 }
 
 // GetById retrieves apikey by its full link
 func (s *ApikeysServiceOp) GetByLink(endpoint string, args ...interface{}) (*Apikey, error) {
-    resp, err := s.client.request("GET", endpoint, nil, args...)
+    return s.GetByLinkContext(context.Background(), endpoint, args...)
+}
+
+// GetByLinkContext is like GetByLink but uses ctx for the underlying request.
+func (s *ApikeysServiceOp) GetByLinkContext(ctx context.Context, endpoint string, args ...interface{}) (*Apikey, error) {
+    ctx = withOperation(ctx, "Apikeys", "GetByLink")
+    resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
     if err != nil {
         return nil, err
     }
@@ -161,7 +189,7 @@ func (s *ApikeysServiceOp) GetByLink(endpoint string, args ...interface{}) (*Api
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &ApikeyResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -188,7 +216,7 @@ func (s *ApikeysServiceOp) get(r *ApikeyResponse) (*Apikey, error) {
         }
         ten := &TenantResponse{}
         json.Unmarshal(bytes, ten)
-        t, err := s.client.Tenant.get(ten)
+        t, err := s.client.conv.tenant.get(ten)
         if err != nil {
             return nil, err
         }
@@ -201,7 +229,7 @@ func (s *ApikeysServiceOp) get(r *ApikeyResponse) (*Apikey, error) {
         }
         ten := &ApplicationsResponse{}
         json.Unmarshal(bytes, ten)
-        t, _, err := s.client.Applications.getCollection(ten)
+        t, _, err := s.client.conv.applications.getCollection(ten)
         if err != nil {
             return nil, err
         }
@@ -235,13 +263,32 @@ func (s *ApikeysServiceOp) getCollection(r *ApikeysResponse) ([]Apikey, *ListPar
 
 // GetById retrieves collection of apikeys of current tenant
 func (s *ApikeysServiceOp) List(args ...interface{}) ([]Apikey, *ListParams, error) {
-    endpoint := fmt.Sprintf("tenants/%s/apikeys", s.client.tenantId)
-    return s.ListByLink(endpoint, args...)
+    return s.ListContext(context.Background(), args...)
+}
+
+// ListContext is like List but uses ctx for the underlying request.
+func (s *ApikeysServiceOp) ListContext(ctx context.Context, args ...interface{}) ([]Apikey, *ListParams, error) {
+    ctx = withOperation(ctx, "Apikeys", "List")
+    endpoint := fmt.Sprintf("tenants/%s/apikeys", s.client.currentTenantId(ctx))
+    return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// Iterate is like List but returns iterator walking over all pages
+func (s *ApikeysServiceOp) Iterate(ctx context.Context, args ...interface{}) *Iterator[Apikey] {
+    ctx = withOperation(ctx, "Apikeys", "Iterate")
+    endpoint := fmt.Sprintf("tenants/%s/apikeys", s.client.currentTenantId(ctx))
+    return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of apikeys by link
 func (s *ApikeysServiceOp) ListByLink(endpoint string, args ...interface{}) ([]Apikey, *ListParams, error) {
-    resp, err := s.client.request("GET", endpoint, nil, args...)
+    return s.ListByLinkContext(context.Background(), endpoint, args...)
+}
+
+// ListByLinkContext is like ListByLink but uses ctx for the underlying request.
+func (s *ApikeysServiceOp) ListByLinkContext(ctx context.Context, endpoint string, args ...interface{}) ([]Apikey, *ListParams, error) {
+    ctx = withOperation(ctx, "Apikeys", "ListByLink")
+    resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
     if err != nil {
         return nil, nil, err
     }
@@ -249,7 +296,7 @@ func (s *ApikeysServiceOp) ListByLink(endpoint string, args ...interface{}) ([]A
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, nil, newApiError(resp, "non-ok status returned")
     }
     obj := &ApikeysResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -258,14 +305,32 @@ func (s *ApikeysServiceOp) ListByLink(endpoint string, args ...interface{}) ([]A
     return s.getCollection(obj)
 }
 
+// IterateByLink is like ListByLink but returns iterator walking over all pages
+func (s *ApikeysServiceOp) IterateByLink(ctx context.Context, endpoint string, args ...interface{}) *Iterator[Apikey] {
+    ctx = withOperation(ctx, "Apikeys", "IterateByLink")
+    return newIterator(ctx, endpoint, s.ListByLinkContext, args...)
+}
+
 // GetById updates apikey with specified ID
 func (s *ApikeysServiceOp) UpdateById(id string, t *ApikeyRequestUpdate) (*Apikey, error) {
+    return s.UpdateByIdContext(context.Background(), id, t)
+}
+
+// UpdateByIdContext is like UpdateById but uses ctx for the underlying request.
+func (s *ApikeysServiceOp) UpdateByIdContext(ctx context.Context, id string, t *ApikeyRequestUpdate) (*Apikey, error) {
+    ctx = withOperation(ctx, "Apikeys", "UpdateById")
     endpoint := fmt.Sprintf("apikeys/%s", id)
-    return s.UpdateByLink(endpoint, t)
+    return s.UpdateByLinkContext(ctx, endpoint, t)
 }
 
 // GetById updates apikey specified by link
 func (s *ApikeysServiceOp) UpdateByLink(endpoint string, t *ApikeyRequestUpdate) (*Apikey, error) {
+    return s.UpdateByLinkContext(context.Background(), endpoint, t)
+}
+
+// UpdateByLinkContext is like UpdateByLink but uses ctx for the underlying request.
+func (s *ApikeysServiceOp) UpdateByLinkContext(ctx context.Context, endpoint string, t *ApikeyRequestUpdate) (*Apikey, error) {
+    ctx = withOperation(ctx, "Apikeys", "UpdateByLink")
     enc, err := json.Marshal(t)
     if err != nil {
         return nil, err
@@ -273,7 +338,7 @@ func (s *ApikeysServiceOp) UpdateByLink(endpoint string, t *ApikeyRequestUpdate)
 
     buf := bytes.NewBuffer(enc)
 
-    resp, err := s.client.request("POST", endpoint, buf)
+    resp, err := s.client.request(ctx, "POST", endpoint, buf)
     if err != nil {
         return nil, err
     }
@@ -281,7 +346,7 @@ func (s *ApikeysServiceOp) UpdateByLink(endpoint string, t *ApikeyRequestUpdate)
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &ApikeyResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -291,7 +356,13 @@ func (s *ApikeysServiceOp) UpdateByLink(endpoint string, t *ApikeyRequestUpdate)
 
 // Create creates new apikey within tenant
 func (s *ApikeysServiceOp) Create(dir *ApikeyRequestCreate) (*Apikey, error) {
-    endpoint := fmt.Sprintf("tenants/%s/apikeys", s.client.tenantId)
+    return s.CreateContext(context.Background(), dir)
+}
+
+// CreateContext is like Create but uses ctx for the underlying request.
+func (s *ApikeysServiceOp) CreateContext(ctx context.Context, dir *ApikeyRequestCreate) (*Apikey, error) {
+    ctx = withOperation(ctx, "Apikeys", "Create")
+    endpoint := fmt.Sprintf("tenants/%s/apikeys", s.client.currentTenantId(ctx))
 
     enc, err := json.Marshal(dir)
     if err != nil {
@@ -300,7 +371,7 @@ func (s *ApikeysServiceOp) Create(dir *ApikeyRequestCreate) (*Apikey, error) {
 
     buf := bytes.NewBuffer(enc)
 
-    resp, err := s.client.request("POST", endpoint, buf)
+    resp, err := s.client.request(ctx, "POST", endpoint, buf)
     if err != nil {
         return nil, err
     }
@@ -308,7 +379,7 @@ func (s *ApikeysServiceOp) Create(dir *ApikeyRequestCreate) (*Apikey, error) {
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusCreated {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &ApikeyResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -318,18 +389,36 @@ func (s *ApikeysServiceOp) Create(dir *ApikeyRequestCreate) (*Apikey, error) {
 
 // Delete removes apikey
 func (s *ApikeysServiceOp) Delete(t *Apikey) (error) {
-    return s.DeleteByLink(t.Href)
+    return s.DeleteContext(context.Background(), t)
+}
+
+// DeleteContext is like Delete but uses ctx for the underlying request.
+func (s *ApikeysServiceOp) DeleteContext(ctx context.Context, t *Apikey) (error) {
+    ctx = withOperation(ctx, "Apikeys", "Delete")
+    return s.DeleteByLinkContext(ctx, t.Href)
 }
 
 // Delete removes apikey by ID
 func (s *ApikeysServiceOp) DeleteById(id string) (error) {
+    return s.DeleteByIdContext(context.Background(), id)
+}
+
+// DeleteByIdContext is like DeleteById but uses ctx for the underlying request.
+func (s *ApikeysServiceOp) DeleteByIdContext(ctx context.Context, id string) (error) {
+    ctx = withOperation(ctx, "Apikeys", "DeleteById")
     endpoint := fmt.Sprintf("apikeys/%s", id)
-    return s.DeleteByLink(endpoint)
+    return s.DeleteByLinkContext(ctx, endpoint)
 }
 
 // Delete removes apikey by link
 func (s *ApikeysServiceOp) DeleteByLink(endpoint string) (error) {
-    resp, err := s.client.request("DELETE", endpoint, nil)
+    return s.DeleteByLinkContext(context.Background(), endpoint)
+}
+
+// DeleteByLinkContext is like DeleteByLink but uses ctx for the underlying request.
+func (s *ApikeysServiceOp) DeleteByLinkContext(ctx context.Context, endpoint string) (error) {
+    ctx = withOperation(ctx, "Apikeys", "DeleteByLink")
+    resp, err := s.client.request(ctx, "DELETE", endpoint, nil)
     if err != nil {
         return err
     }
@@ -337,7 +426,7 @@ func (s *ApikeysServiceOp) DeleteByLink(endpoint string) (error) {
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusNoContent {
-        return ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return newApiError(resp, "non-ok status returned")
     }
     return nil
 }
@@ -0,0 +1,158 @@
+package apitest
+
+// subSpec describes collection nested under a resource, e.g. devices of product
+type subSpec struct {
+	// collection of listed resources
+	coll string
+	// link of listed resource pointing to parent, for one-to-many relations
+	field string
+	// membership collection and its links to parent and listed resource,
+	// for many-to-many relations
+	through     string
+	parentField string
+	targetField string
+	// custom resolver for relations which can't be described by fields above
+	custom func(s *Server, parent *object) []*object
+}
+
+// kindSpec describes relations of a resource type
+type kindSpec struct {
+	// to-one relations and collections they point to
+	links map[string]string
+	// nested collections
+	subs map[string]subSpec
+	// whether resource has data, events and commands resources
+	resources bool
+}
+
+func children(coll, field string) subSpec {
+	return subSpec{coll: coll, field: field}
+}
+
+func through(coll, membership, parentField, targetField string) subSpec {
+	return subSpec{coll: coll, through: membership, parentField: parentField, targetField: targetField}
+}
+
+// schema mirrors relations of CloudThing v1 resources
+var schema = map[string]kindSpec{
+	"tenants": {
+		links: map[string]string{},
+		subs: map[string]subSpec{
+			"directories":  children("directories", "tenant"),
+			"applications": children("applications", "tenant"),
+			"products":     children("products", "tenant"),
+			"apikeys":      children("apikeys", "tenant"),
+			"exports":      children("exports", "tenantExp"),
+		},
+	},
+	"directories": {
+		links: map[string]string{"tenant": "tenants"},
+		subs: map[string]subSpec{
+			"applications": children("applications", "directory"),
+			"users":        children("users", "directory"),
+			"usergroups":   children("usergroups", "directory"),
+		},
+	},
+	"applications": {
+		links: map[string]string{"tenant": "tenants", "directory": "directories"},
+		subs: map[string]subSpec{
+			"clusters": children("clusters", "application"),
+			"exports":  children("exports", "application"),
+			"devices":  {coll: "devices", custom: applicationDevices},
+		},
+	},
+	"apikeys": {
+		links: map[string]string{"tenant": "tenants"},
+		subs: map[string]subSpec{
+			"applications": {coll: "applications", custom: func(*Server, *object) []*object { return nil }},
+		},
+	},
+	"products": {
+		links: map[string]string{"tenant": "tenants"},
+		subs: map[string]subSpec{
+			"devices": children("devices", "product"),
+		},
+	},
+	"devices": {
+		links: map[string]string{"tenant": "tenants", "product": "products"},
+		subs: map[string]subSpec{
+			"clusters":           through("clusters", "clusterMemberships", "device", "cluster"),
+			"groups":             through("groups", "groupMemberships", "device", "group"),
+			"clusterMemberships": children("clusterMemberships", "device"),
+			"groupMemberships":   children("groupMemberships", "device"),
+		},
+		resources: true,
+	},
+	"clusters": {
+		links: map[string]string{"tenant": "tenants", "application": "applications"},
+		subs: map[string]subSpec{
+			"devices":     through("devices", "clusterMemberships", "cluster", "device"),
+			"groups":      children("groups", "cluster"),
+			"memberships": children("clusterMemberships", "cluster"),
+		},
+		resources: true,
+	},
+	"clusterMemberships": {
+		links: map[string]string{"device": "devices", "cluster": "clusters", "application": "applications"},
+		subs:  map[string]subSpec{},
+	},
+	"groups": {
+		links: map[string]string{"tenant": "tenants", "application": "applications", "cluster": "clusters"},
+		subs: map[string]subSpec{
+			"devices":          through("devices", "groupMemberships", "group", "device"),
+			"memberships":      children("groupMemberships", "group"),
+			"groupMemberships": children("groupMemberships", "group"),
+		},
+	},
+	"groupMemberships": {
+		links: map[string]string{"device": "devices", "group": "groups"},
+		subs:  map[string]subSpec{},
+	},
+	"users": {
+		links: map[string]string{"tenant": "tenants", "directory": "directories"},
+		subs: map[string]subSpec{
+			"memberships":  children("memberships", "user"),
+			"usergroups":   through("usergroups", "memberships", "user", "usergroup"),
+			"applications": {coll: "applications", custom: userApplications},
+		},
+	},
+	"usergroups": {
+		links: map[string]string{"tenant": "tenants", "directory": "directories"},
+		subs: map[string]subSpec{
+			"users":       through("users", "memberships", "usergroup", "user"),
+			"memberships": children("memberships", "usergroup"),
+		},
+	},
+	"memberships": {
+		links: map[string]string{"user": "users", "usergroup": "usergroups"},
+		subs:  map[string]subSpec{},
+	},
+	"exports": {
+		links: map[string]string{"tenantExp": "tenants", "tenantImp": "tenants", "application": "applications"},
+		subs:  map[string]subSpec{},
+	},
+}
+
+// applicationDevices lists devices which are members of application's clusters
+func applicationDevices(s *Server, app *object) []*object {
+	seen := map[string]bool{}
+	var res []*object
+	for _, c := range s.filter("clusters", "application", app.key()) {
+		for _, d := range s.list(c, through("devices", "clusterMemberships", "cluster", "device")) {
+			if !seen[d.key()] {
+				seen[d.key()] = true
+				res = append(res, d)
+			}
+		}
+	}
+	return res
+}
+
+// userApplications lists applications using user's directory
+func userApplications(s *Server, user *object) []*object {
+	dir, ok := user.links["directory"]
+	if !ok {
+		return nil
+	}
+	return s.filter("applications", "directory", dir)
+}
@@ -0,0 +1,904 @@
+// Package apitest provides in-memory fake of CloudThing v1 REST API, so code
+// using api.Client can be exercised end to end without network access.
+//
+//	srv := apitest.NewServer()
+//	defer srv.Close()
+//	client, err := srv.NewClient()
+//	devices, _, err := client.Devices.ListByProduct(productId)
+//
+// Server keeps all resources in memory, supports expansions, pagination,
+// query filters and resources (data, events and commands) of devices and
+// clusters, which are also streamed to subscriptions, and aggregates of data.
+// Tokens issued on auth/token are JWTs signed by the server.
+package apitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+
+	api "github.com/cloudthing-io/go-client-api"
+)
+
+const (
+	apiPath      = "/api/v1/"
+	defaultLimit = 25
+
+	// Credentials of user created with every server
+	AdminUsername = "admin"
+	AdminPassword = "admin-password"
+)
+
+// Request is a request received by Server
+type Request struct {
+	Method string
+	// Path relative to API root as sent, e.g. "devices/ID/resources/data/a%2Fb"
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// fault is an injected error response
+type fault struct {
+	method string
+	path   string
+	status int
+	header http.Header
+	times  int
+}
+
+// block holds requests until released
+type block struct {
+	method   string
+	path     string
+	released chan struct{}
+}
+
+// Server is a fake CloudThing API running on local httptest.Server
+type Server struct {
+	*httptest.Server
+
+	// Lifetime of issued tokens, one hour by default
+	TokenTTL time.Duration
+
+	// Whether streams of resources respond 404, so subscriptions have to poll.
+	// It has to be set before clients subscribe.
+	DisableStreaming bool
+	// Whether aggregates of data respond 501, as older versions of API do
+	DisableAggregates bool
+
+	mu         sync.Mutex
+	seq        int64
+	signingKey []byte
+	objects    map[string]*object
+	series     map[string]map[string][]map[string]interface{}
+	revoked    map[string]bool
+	requests   []Request
+	faults     []*fault
+	blocks     []*block
+
+	// closed and replaced when points are written or streams are dropped
+	written chan struct{}
+	dropped chan struct{}
+	closing chan struct{}
+	closed  sync.Once
+
+	tenant      *object
+	directory   *object
+	application *object
+	admin       *object
+}
+
+// NewServer starts fake API with a tenant, its default directory and application
+// and an admin user authenticated with AdminUsername and AdminPassword.
+func NewServer() *Server {
+	s := &Server{
+		TokenTTL:   time.Hour,
+		signingKey: []byte(newId()),
+		objects:    make(map[string]*object),
+		series:     make(map[string]map[string][]map[string]interface{}),
+		revoked:    make(map[string]bool),
+		written:    make(chan struct{}),
+		dropped:    make(chan struct{}),
+		closing:    make(chan struct{}),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	s.tenant = s.insert("tenants", map[string]interface{}{"shortName": "test", "name": "Test tenant"}, nil)
+	s.directory = s.insert("directories", map[string]interface{}{"name": "Default"}, nil)
+	s.application = s.insert("applications", map[string]interface{}{"name": "Default"},
+		map[string]string{"directory": s.directory.key()})
+	s.admin = s.insert("users", map[string]interface{}{"username": AdminUsername, "activated": true},
+		map[string]string{"directory": s.directory.key()})
+	s.admin.secret = AdminPassword
+	return s
+}
+
+// Close ends open streams and shuts down the server
+func (s *Server) Close() {
+	s.closed.Do(func() { close(s.closing) })
+	s.Server.Close()
+}
+
+// DropStreams ends streams which are open, so subscribed clients have to reconnect
+func (s *Server) DropStreams() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	close(s.dropped)
+	s.dropped = make(chan struct{})
+}
+
+// TenantId returns ID of server's tenant
+func (s *Server) TenantId() string {
+	return s.tenant.id
+}
+
+// DirectoryId returns ID of default directory
+func (s *Server) DirectoryId() string {
+	return s.directory.id
+}
+
+// ApplicationId returns ID of default application
+func (s *Server) ApplicationId() string {
+	return s.application.id
+}
+
+// NewClient creates client of the server authenticated as admin user
+func (s *Server) NewClient(opts ...api.Option) (*api.Client, error) {
+	opts = append([]api.Option{
+		api.WithBaseURL(s.URL),
+		api.WithHTTPClient(s.Client()),
+		api.WithCredentials(&api.BasicCredentials{Username: AdminUsername, Password: AdminPassword}),
+	}, opts...)
+	return api.NewClientWithOptions(opts...)
+}
+
+// AddUser creates user in default directory and returns its ID
+func (s *Server) AddUser(username, password string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := s.insert("users", map[string]interface{}{"username": username, "activated": true},
+		map[string]string{"directory": s.directory.key()})
+	o.secret = password
+	return o.id
+}
+
+// AddApikey creates enabled apikey and returns its ID
+func (s *Server) AddApikey(key, secret string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := s.insert("apikeys", map[string]interface{}{"key": key, "secret": secret, "status": "ENABLED"}, nil)
+	o.secret = secret
+	return o.id
+}
+
+// Add creates resource in collection, e.g. "devices", and returns its ID.
+// Links map relations to keys of related resources, e.g. "product": "products/ID".
+func (s *Server) Add(collection string, attrs map[string]interface{}, links map[string]string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.insert(collection, attrs, links).id
+}
+
+// Fail makes next times requests with method (any if empty) to path relative
+// to API root, e.g. "devices/ID", respond with status. Header is optional.
+func (s *Server) Fail(method, path string, status, times int, header http.Header) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault{method: method, path: strings.Trim(path, "/"), status: status, header: header, times: times})
+}
+
+// Block holds requests with method (any if empty) to path relative to API root
+// until release is called or their client gives up, e.g. to exercise cancellation
+// of requests in flight. Held requests are recorded on arrival and served when released.
+func (s *Server) Block(method, path string) (release func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := &block{method: method, path: strings.Trim(path, "/"), released: make(chan struct{})}
+	s.blocks = append(s.blocks, b)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			for i, other := range s.blocks {
+				if other == b {
+					s.blocks = append(s.blocks[:i], s.blocks[i+1:]...)
+					break
+				}
+			}
+			close(b.released)
+		})
+	}
+}
+
+// Requests returns all requests received so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// IssueToken signs token for admin user valid for ttl, negative ttl gives expired token
+func (s *Server) IssueToken(ttl time.Duration) *api.Token {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.issue(s.admin, "", ttl)
+}
+
+// issue signs token for subject, s.mu must be held
+func (s *Server) issue(subject *object, application string, ttl time.Duration) *api.Token {
+	claims := jwt.MapClaims{
+		"iss": s.href(s.tenant.key()),
+		"sub": s.href(subject.key()),
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(ttl).Unix(),
+		"jti": newId(),
+	}
+	if application != "" {
+		claims["application"] = s.href("applications/" + application)
+	}
+	signed, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.signingKey)
+	return &api.Token{Token: signed, Type: "Bearer", ExpiresIn: int64(ttl / time.Second)}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	path := strings.Trim(strings.TrimPrefix(r.URL.EscapedPath(), apiPath), "/")
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: path, Query: r.URL.Query(), Header: r.Header.Clone(), Body: body})
+	var held chan struct{}
+	for _, b := range s.blocks {
+		if (b.method == "" || b.method == r.Method) && b.path == path {
+			held = b.released
+			break
+		}
+	}
+	s.mu.Unlock()
+	if held != nil {
+		select {
+		case <-held:
+		case <-r.Context().Done():
+			return
+		case <-s.closing:
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !strings.HasPrefix(r.URL.Path+"/", apiPath) {
+		s.error(w, http.StatusNotFound, "not_found", "Unknown endpoint")
+		return
+	}
+	for i, f := range s.faults {
+		if (f.method == "" || f.method == r.Method) && f.path == path {
+			f.times--
+			if f.times <= 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+			for k, v := range f.header {
+				w.Header()[k] = v
+			}
+			s.error(w, f.status, "injected", "Injected failure")
+			return
+		}
+	}
+
+	if path == "auth/token" {
+		s.serveToken(w, r)
+		return
+	}
+	subject := s.authenticate(r)
+	if subject == nil {
+		s.error(w, http.StatusUnauthorized, "unauthorized", "Missing or invalid token")
+		return
+	}
+
+	// keys of resources may contain escaped slashes
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if unescaped, err := url.PathUnescape(seg); err == nil {
+			segments[i] = unescaped
+		}
+	}
+	switch {
+	case path == "":
+		s.json(w, http.StatusOK, map[string]interface{}{"href": s.href("")})
+	case len(segments) == 2:
+		s.serveItem(w, r, segments, subject, body)
+	case len(segments) == 3:
+		s.serveCollection(w, r, segments, body)
+	case len(segments) >= 4 && segments[2] == "resources":
+		s.serveResources(w, r, segments, body)
+	default:
+		s.error(w, http.StatusNotFound, "not_found", "Unknown endpoint")
+	}
+}
+
+// serveToken issues and revokes tokens
+func (s *Server) serveToken(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "POST":
+		username, password, ok := r.BasicAuth()
+		if !ok {
+			s.error(w, http.StatusUnauthorized, "unauthorized", "Missing credentials")
+			return
+		}
+		var subject *object
+		for _, o := range s.objects {
+			if o.secret == "" || o.secret != password {
+				continue
+			}
+			if (o.coll == "users" && (o.attrs["username"] == username || o.attrs["email"] == username)) ||
+				(o.coll == "apikeys" && o.attrs["key"] == username && o.attrs["status"] != "DISABLED") {
+				subject = o
+				break
+			}
+		}
+		if subject == nil {
+			s.error(w, http.StatusUnauthorized, "unauthorized", "Invalid credentials")
+			return
+		}
+		app := r.URL.Query().Get("application")
+		if app != "" && s.get("applications/"+app) == nil {
+			s.error(w, http.StatusNotFound, "not_found", "Application not found")
+			return
+		}
+		s.json(w, http.StatusOK, s.issue(subject, app, s.TokenTTL))
+	case "DELETE":
+		if s.authenticate(r) == nil {
+			s.error(w, http.StatusUnauthorized, "unauthorized", "Missing or invalid token")
+			return
+		}
+		s.revoked[bearer(r)] = true
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.error(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+	}
+}
+
+func bearer(r *http.Request) string {
+	return strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+}
+
+// authenticate returns subject of request's token, nil if token is invalid
+func (s *Server) authenticate(r *http.Request) *object {
+	raw := bearer(r)
+	if raw == "" || s.revoked[raw] {
+		return nil
+	}
+	token, err := jwt.Parse(raw, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return s.signingKey, nil
+	})
+	if err != nil || !token.Valid {
+		return nil
+	}
+	sub, _ := token.Claims.(jwt.MapClaims)["sub"].(string)
+	return s.get(s.keyOf(sub))
+}
+
+// serveItem handles single resources, e.g. devices/ID
+func (s *Server) serveItem(w http.ResponseWriter, r *http.Request, segments []string, subject *object, body []byte) {
+	coll, id := segments[0], segments[1]
+	if _, ok := schema[coll]; !ok {
+		s.error(w, http.StatusNotFound, "not_found", "Unknown collection")
+		return
+	}
+	o := s.get(coll + "/" + id)
+	switch {
+	case coll == "tenants" && id == "current":
+		o = s.tenant
+	case coll == "users" && id == "current" && subject.coll == "users":
+		o = subject
+	}
+	if o == nil {
+		s.error(w, http.StatusNotFound, "not_found", fmt.Sprintf("Resource %s/%s not found", coll, id))
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		exp, err := parseExpansion(r.URL.Query().Get("expand"))
+		if r.URL.Query().Get("expand") == "" {
+			exp, err = expansion{}, nil
+		}
+		if err != nil {
+			s.error(w, http.StatusBadRequest, "invalid_expand", err.Error())
+			return
+		}
+		s.json(w, http.StatusOK, s.render(o, exp))
+	case "POST":
+		attrs, links, err := s.decode(o.coll, body)
+		if err != nil {
+			s.error(w, http.StatusBadRequest, "invalid_body", err.Error())
+			return
+		}
+		s.update(o, attrs, links)
+		s.json(w, http.StatusOK, s.render(o, expansion{}))
+	case "DELETE":
+		if o == s.tenant {
+			s.error(w, http.StatusForbidden, "forbidden", "Tenant can't be deleted")
+			return
+		}
+		s.remove(o)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.error(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+	}
+}
+
+// serveCollection handles collections nested in resources, e.g. products/ID/devices
+func (s *Server) serveCollection(w http.ResponseWriter, r *http.Request, segments []string, body []byte) {
+	coll, id, name := segments[0], segments[1], segments[2]
+	spec, ok := schema[coll]
+	if !ok {
+		s.error(w, http.StatusNotFound, "not_found", "Unknown collection")
+		return
+	}
+	sub, ok := spec.subs[name]
+	if !ok {
+		s.error(w, http.StatusNotFound, "not_found", "Unknown collection")
+		return
+	}
+	parent := s.get(coll + "/" + id)
+	if coll == "tenants" && id == "current" {
+		parent = s.tenant
+	}
+	if parent == nil {
+		s.error(w, http.StatusNotFound, "not_found", fmt.Sprintf("Resource %s/%s not found", coll, id))
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		query := r.URL.Query()
+		exp := expansion{}
+		if v := query.Get("expand"); v != "" {
+			var err error
+			if exp, err = parseExpansion(v); err != nil {
+				s.error(w, http.StatusBadRequest, "invalid_expand", err.Error())
+				return
+			}
+		}
+		items := s.list(parent, sub)
+		rendered := make([]map[string]interface{}, 0, len(items))
+		for _, o := range items {
+			rendered = append(rendered, s.render(o, exp))
+		}
+		rendered, err := applyQuery(rendered, query)
+		if err != nil {
+			s.error(w, http.StatusBadRequest, "invalid_query", err.Error())
+			return
+		}
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		page, _ := strconv.Atoi(query.Get("page"))
+		passed := url.Values{}
+		for k, v := range query {
+			if k != "limit" && k != "page" {
+				passed[k] = v
+			}
+		}
+		s.json(w, http.StatusOK, s.paginate(parent.key()+"/"+name, rendered, limit, page, passed))
+	case "POST":
+		if sub.field == "" {
+			s.error(w, http.StatusMethodNotAllowed, "method_not_allowed", "Collection is read only")
+			return
+		}
+		attrs, links, err := s.decode(sub.coll, body)
+		if err != nil {
+			s.error(w, http.StatusBadRequest, "invalid_body", err.Error())
+			return
+		}
+		links[sub.field] = parent.key()
+		// resources inherit application of their parent, e.g. groups of cluster
+		if _, ok := schema[sub.coll].links["application"]; ok {
+			if _, ok := links["application"]; !ok {
+				if app, ok := parent.links["application"]; ok {
+					links["application"] = app
+				} else if parent.coll == "clusters" || parent.coll == "groups" {
+					if c := s.get(links["cluster"]); c != nil {
+						links["application"] = c.links["application"]
+					}
+				}
+			}
+		}
+		if fe := s.validate(sub.coll, attrs, links); len(fe) > 0 {
+			s.json(w, http.StatusUnprocessableEntity, map[string]interface{}{
+				"code": "validation_failed", "message": "Validation failed", "errors": fe, "requestId": newId(),
+			})
+			return
+		}
+		o := s.insert(sub.coll, attrs, links)
+		s.prepare(o)
+		s.json(w, http.StatusCreated, s.render(o, expansion{}))
+	default:
+		s.error(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+	}
+}
+
+// decode splits request body into attributes and links of resource
+func (s *Server) decode(coll string, body []byte) (map[string]interface{}, map[string]string, error) {
+	attrs := map[string]interface{}{}
+	links := map[string]string{}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return attrs, links, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&attrs); err != nil {
+		return nil, nil, err
+	}
+	for rel := range schema[coll].links {
+		v, ok := attrs[rel]
+		if !ok {
+			continue
+		}
+		delete(attrs, rel)
+		if m, ok := v.(map[string]interface{}); ok {
+			if href, ok := m["href"].(string); ok {
+				links[rel] = s.keyOf(href)
+			}
+		}
+	}
+	for _, k := range []string{"href", "createdAt", "updatedAt"} {
+		delete(attrs, k)
+	}
+	return attrs, links, nil
+}
+
+// validate checks required fields of new resource
+func (s *Server) validate(coll string, attrs map[string]interface{}, links map[string]string) []map[string]string {
+	var errs []map[string]string
+	require := func(rel string) {
+		if s.get(links[rel]) == nil {
+			errs = append(errs, map[string]string{"field": rel, "code": "required", "message": rel + " must link existing resource"})
+		}
+	}
+	switch coll {
+	case "clusterMemberships":
+		require("device")
+		require("cluster")
+	case "groupMemberships":
+		require("device")
+		require("group")
+	case "memberships":
+		require("user")
+		require("usergroup")
+	case "users":
+		if v, _ := attrs["username"].(string); v == "" {
+			if e, _ := attrs["email"].(string); e == "" {
+				errs = append(errs, map[string]string{"field": "username", "code": "required", "message": "username or email is required"})
+			}
+		}
+	}
+	return errs
+}
+
+// prepare fills server generated fields of new resource
+func (s *Server) prepare(o *object) {
+	switch o.coll {
+	case "devices":
+		o.attrs["token"] = newId()
+		if _, ok := o.attrs["activated"]; !ok {
+			o.attrs["activated"] = false
+		}
+	case "users":
+		if p, ok := o.attrs["password"].(string); ok {
+			o.secret = p
+		}
+		delete(o.attrs, "password")
+	case "apikeys":
+		o.attrs["key"] = newId()
+		o.attrs["secret"] = newId()
+		o.secret = o.attrs["secret"].(string)
+		if _, ok := o.attrs["status"]; !ok {
+			o.attrs["status"] = "ENABLED"
+		}
+	}
+}
+
+// update merges attributes into resource
+func (s *Server) update(o *object, attrs map[string]interface{}, links map[string]string) {
+	for k, v := range attrs {
+		if k == "password" && o.coll == "users" {
+			o.secret, _ = v.(string)
+			continue
+		}
+		o.attrs[k] = v
+	}
+	for k, v := range links {
+		o.links[k] = v
+	}
+	o.updatedAt = time.Now().UTC()
+}
+
+func (s *Server) json(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// error responds with CloudThing error payload
+func (s *Server) error(w http.ResponseWriter, status int, code, message string) {
+	id := newId()
+	w.Header().Set("X-Request-Id", id)
+	s.json(w, status, map[string]interface{}{"code": code, "message": message, "requestId": id})
+}
+
+// serveResources handles data, events and commands of devices and clusters,
+// e.g. devices/ID/resources/data or devices/ID/resources/data/temperature,
+// their stream, devices/ID/resources/stream, and aggregates of their data,
+// devices/ID/resources/aggregates
+func (s *Server) serveResources(w http.ResponseWriter, r *http.Request, segments []string, body []byte) {
+	coll, id, kind := segments[0], segments[1], segments[3]
+	if schema[coll].resources && kind == "stream" && len(segments) == 4 && !s.DisableStreaming {
+		if o := s.get(coll + "/" + id); o != nil {
+			s.serveStream(w, r, o)
+			return
+		}
+	}
+	if schema[coll].resources && kind == "aggregates" && len(segments) == 4 {
+		if s.DisableAggregates {
+			s.error(w, http.StatusNotImplemented, "not_implemented", "Aggregates not supported")
+			return
+		}
+		if o := s.get(coll + "/" + id); o != nil {
+			s.serveAggregates(w, r, o)
+			return
+		}
+	}
+	if !schema[coll].resources || (kind != "data" && kind != "events" && kind != "commands") || len(segments) > 5 {
+		s.error(w, http.StatusNotFound, "not_found", "Unknown endpoint")
+		return
+	}
+	o := s.get(coll + "/" + id)
+	if o == nil {
+		s.error(w, http.StatusNotFound, "not_found", fmt.Sprintf("Resource %s/%s not found", coll, id))
+		return
+	}
+	key := ""
+	if len(segments) == 5 {
+		key = segments[4]
+	}
+
+	switch r.Method {
+	case "GET":
+		query := r.URL.Query()
+		var start, end time.Time
+		for name, t := range map[string]*time.Time{"start": &start, "end": &end} {
+			if v := query.Get(name); v != "" {
+				parsed, err := time.Parse(time.RFC3339Nano, v)
+				if err != nil {
+					s.error(w, http.StatusBadRequest, "invalid_query", fmt.Sprintf("invalid %s: %v", name, err))
+					return
+				}
+				*t = parsed
+			}
+		}
+		points := make([]map[string]interface{}, 0)
+		for _, p := range s.series[o.key()][kind] {
+			if key != "" && p["key"] != key {
+				continue
+			}
+			t, _ := time.Parse(time.RFC3339Nano, fmt.Sprint(p["time"]))
+			if (!start.IsZero() && t.Before(start)) || (!end.IsZero() && t.After(end)) {
+				continue
+			}
+			points = append(points, p)
+		}
+		points, err := applyQuery(points, query)
+		if err != nil {
+			s.error(w, http.StatusBadRequest, "invalid_query", err.Error())
+			return
+		}
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		page, _ := strconv.Atoi(query.Get("page"))
+		passed := url.Values{}
+		for k, v := range query {
+			if k != "limit" && k != "page" {
+				passed[k] = v
+			}
+		}
+		s.json(w, http.StatusOK, s.paginate(strings.Join(segments, "/"), points, limit, page, passed))
+	case "POST":
+		var points []map[string]interface{}
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		if err := dec.Decode(&points); err != nil {
+			s.error(w, http.StatusBadRequest, "invalid_body", err.Error())
+			return
+		}
+		for _, p := range points {
+			if key != "" {
+				p["key"] = key
+			}
+			v, _ := p["time"].(string)
+			t, err := time.Parse(time.RFC3339Nano, v)
+			if v == "" {
+				t, err = time.Now().UTC(), nil
+			}
+			if err != nil {
+				s.error(w, http.StatusBadRequest, "invalid_body", fmt.Sprintf("invalid time %q", v))
+				return
+			}
+			p["time"] = t.UTC().Format(time.RFC3339Nano)
+		}
+		if s.series[o.key()] == nil {
+			s.series[o.key()] = make(map[string][]map[string]interface{})
+		}
+		series := append(s.series[o.key()][kind], points...)
+		sort.SliceStable(series, func(i, j int) bool {
+			return compare(fmt.Sprint(series[i]["time"]), fmt.Sprint(series[j]["time"])) < 0
+		})
+		s.series[o.key()][kind] = series
+		close(s.written)
+		s.written = make(chan struct{})
+		s.json(w, http.StatusOK, points)
+	default:
+		s.error(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+	}
+}
+
+// serveAggregates aggregates data of o the same way as api.AggregatePoints
+func (s *Server) serveAggregates(w http.ResponseWriter, r *http.Request, o *object) {
+	if r.Method != "GET" {
+		s.error(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+	query := r.URL.Query()
+	interval, err := time.ParseDuration(query.Get("interval"))
+	if err != nil {
+		s.error(w, http.StatusBadRequest, "invalid_query", fmt.Sprintf("invalid interval: %v", err))
+		return
+	}
+	agg := &api.Aggregation{
+		Interval:   interval,
+		Fill:       api.FillPolicy(query.Get("fill")),
+		GroupByKey: query.Get("groupBy") == "key",
+	}
+	if v := query.Get("functions"); v != "" {
+		for _, f := range strings.Split(v, ",") {
+			agg.Functions = append(agg.Functions, api.AggregateFunc(f))
+		}
+	}
+	if v := query.Get("keys"); v != "" {
+		agg.Keys = strings.Split(v, ",")
+	}
+	// excluded bounds are moved to the nearest time points may have
+	var start, end time.Time
+	for name, bound := range map[string]struct {
+		t     *time.Time
+		shift time.Duration
+	}{
+		"start": {&start, 0}, "end": {&end, 0},
+		"time[gt]": {&start, time.Nanosecond}, "time[lt]": {&end, -time.Nanosecond},
+	} {
+		if v := query.Get(name); v != "" {
+			t, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				s.error(w, http.StatusBadRequest, "invalid_query", fmt.Sprintf("invalid %s: %v", name, err))
+				return
+			}
+			*bound.t = t.Add(bound.shift)
+		}
+	}
+
+	var points []api.DataPoint
+	for _, p := range s.series[o.key()]["data"] {
+		points = append(points, api.DataPoint{Key: fmt.Sprint(p["key"]), Value: p["value"], Time: fmt.Sprint(p["time"])})
+	}
+	res, err := api.AggregatePoints(points, agg, start, end)
+	if err != nil {
+		s.error(w, http.StatusBadRequest, "invalid_query", err.Error())
+		return
+	}
+	keys := make([]string, 0, len(res))
+	for k := range res {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	items := make([]map[string]interface{}, 0, len(keys))
+	for _, k := range keys {
+		items = append(items, map[string]interface{}{"key": k, "buckets": res[k]})
+	}
+	s.json(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+// serveStream sends points of resources as Server-Sent Events, named after kind of
+// points and identified by their time. Points since time in query or Last-Event-ID,
+// whichever is later, are sent first, then points as they are written. It is called
+// with s.mu held, which is released while waiting for points.
+func (s *Server) serveStream(w http.ResponseWriter, r *http.Request, o *object) {
+	if r.Method != "GET" {
+		s.error(w, http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed")
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.error(w, http.StatusNotImplemented, "not_implemented", "Streaming not supported")
+		return
+	}
+	kinds := []string{"data", "events", "commands"}
+	if v := r.URL.Query().Get("kinds"); v != "" {
+		kinds = strings.Split(v, ",")
+	}
+	for _, k := range kinds {
+		if k != "data" && k != "events" && k != "commands" {
+			s.error(w, http.StatusBadRequest, "invalid_query", fmt.Sprintf("unknown kind %q", k))
+			return
+		}
+	}
+	since := time.Now().UTC()
+	if v := r.URL.Query().Get("since"); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			s.error(w, http.StatusBadRequest, "invalid_query", fmt.Sprintf("invalid since: %v", err))
+			return
+		}
+		since = t
+	}
+	if t, err := time.Parse(time.RFC3339Nano, r.Header.Get("Last-Event-ID")); err == nil && t.After(since) {
+		since = t
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// points are sent once, though they are matched again after every write
+	sent := make(map[string]bool)
+	for {
+		var events bytes.Buffer
+		for _, kind := range kinds {
+			for _, p := range s.series[o.key()][kind] {
+				t, _ := time.Parse(time.RFC3339Nano, fmt.Sprint(p["time"]))
+				id := fmt.Sprintf("%s|%s|%v", kind, p["time"], p["key"])
+				if t.Before(since) || sent[id] {
+					continue
+				}
+				sent[id] = true
+				b, _ := json.Marshal(p)
+				fmt.Fprintf(&events, "id: %s\nevent: %s\ndata: %s\n\n", p["time"], kind, b)
+			}
+		}
+		written, dropped := s.written, s.dropped
+
+		s.mu.Unlock()
+		_, err := w.Write(events.Bytes())
+		flusher.Flush()
+		if err == nil {
+			select {
+			case <-written:
+			case <-dropped:
+				err = io.EOF
+			case <-s.closing:
+				err = io.EOF
+			case <-r.Context().Done():
+				err = r.Context().Err()
+			}
+		}
+		s.mu.Lock()
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+)
+
+func newClient(t *testing.T, srv *apitest.Server, opts ...api.Option) *api.Client {
+	t.Helper()
+	client, err := srv.NewClient(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestServerDevices(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	product, err := client.Products.Create(&api.ProductRequestCreate{Name: "Lamp"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	created, err := client.Devices.CreateByProduct(product.GetId(), &api.DeviceRequestCreate{Custom: map[string]interface{}{"room": "kitchen"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Token == "" {
+		t.Error("created device has no token")
+	}
+
+	device, err := client.Devices.GetById(created.GetId(), api.Expand("product"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if device.Custom["room"] != "kitchen" {
+		t.Errorf("custom = %v, want room kitchen", device.Custom)
+	}
+	if device.Product == nil || device.Product.Name != "Lamp" {
+		t.Errorf("expanded product = %+v, want Lamp", device.Product)
+	}
+
+	if _, err := client.Devices.UpdateById(device.GetId(), &api.DeviceRequestUpdate{Custom: map[string]interface{}{"room": "hall"}}); err != nil {
+		t.Fatal(err)
+	}
+	if device, err = client.Devices.GetById(device.GetId()); err != nil {
+		t.Fatal(err)
+	} else if device.Custom["room"] != "hall" {
+		t.Errorf("custom after update = %v, want room hall", device.Custom)
+	}
+
+	if err := client.Devices.DeleteById(device.GetId()); err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Devices.GetById(device.GetId())
+	if apiErr, ok := api.AsApiError(err); !ok || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("get of deleted device = %v, want 404", err)
+	}
+}
+
+func TestServerPagination(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	product := srv.Add("products", map[string]interface{}{"name": "Lamp"}, nil)
+	for i := 0; i < 5; i++ {
+		srv.Add("devices", map[string]interface{}{}, map[string]string{"product": "products/" + product})
+	}
+
+	devices, params, err := client.Devices.ListByProduct(product, &api.ListOptions{Limit: 2, Page: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 2 || params.Size != 5 || params.Next == nil {
+		t.Fatalf("first page has %d devices of %d, next %v, want 2 of 5 with next", len(devices), params.Size, params.Next)
+	}
+	devices, params, err = client.Devices.ListByLink(params.Next.Href)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 2 || params.Page != 2 {
+		t.Errorf("next page %d has %d devices, want page 2 with 2", params.Page, len(devices))
+	}
+	devices, params, err = client.Devices.ListByProduct(product, &api.ListOptions{Limit: 2, Page: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 1 || params.Next != nil {
+		t.Errorf("last page has %d devices, next %v, want 1 without next", len(devices), params.Next)
+	}
+}
+
+func TestServerFail(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv, api.WithRetryPolicy(nil))
+
+	id := srv.Add("devices", map[string]interface{}{}, nil)
+	srv.Fail("GET", "devices/"+id, http.StatusServiceUnavailable, 2, http.Header{"Retry-After": {"1"}})
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Devices.GetById(id)
+		apiErr, ok := api.AsApiError(err)
+		if !ok || apiErr.StatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("request %d = %v, want injected 503", i+1, err)
+		}
+		if apiErr.Header.Get("Retry-After") != "1" {
+			t.Errorf("request %d has Retry-After %q, want 1", i+1, apiErr.Header.Get("Retry-After"))
+		}
+	}
+	if _, err := client.Devices.GetById(id); err != nil {
+		t.Errorf("request after injected failures: %v", err)
+	}
+
+	var gets int
+	for _, r := range srv.Requests() {
+		if r.Method == "GET" && r.Path == "devices/"+id {
+			gets++
+		}
+	}
+	if gets != 3 {
+		t.Errorf("server recorded %d requests, want 3", gets)
+	}
+}
+
+func TestServerAuthentication(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	id := srv.Add("devices", map[string]interface{}{}, nil)
+
+	client, err := api.NewClientWithOptions(api.WithBaseURL(srv.URL), api.WithHTTPClient(srv.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SetBasicAuth(apitest.AdminUsername, "wrong"); err == nil {
+		t.Error("authentication with wrong password succeeded")
+	}
+
+	srv.AddApikey("key", "secret")
+	if err := client.SetApikeyAuth("key", "secret", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Devices.GetById(id); err != nil {
+		t.Errorf("request authenticated with apikey: %v", err)
+	}
+
+	client, err = api.NewClientWithOptions(api.WithBaseURL(srv.URL), api.WithHTTPClient(srv.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SetTokenAuth(srv.IssueToken(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.RevokeToken(); err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Devices.GetById(id)
+	if apiErr, ok := api.AsApiError(err); !ok || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("request with revoked token = %v, want 401", err)
+	}
+}
+
+func TestServerResources(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client := newClient(t, srv)
+
+	id := srv.Add("devices", map[string]interface{}{}, nil)
+	points := []api.DataPoint{
+		{Key: "temperature", Value: 21.5, Time: "2024-03-01T10:00:00Z"},
+		{Key: "temperature", Value: 22.5, Time: "2024-03-01T11:00:00Z"},
+		{Key: "humidity", Value: 40.0, Time: "2024-03-01T10:00:00Z"},
+	}
+	if _, err := client.Resources.WriteDataForDeviceID(id, points); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _, err := client.Resources.GetDataByDeviceKey(id, "temperature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 2 {
+		t.Fatalf("got %d temperature points, want 2", len(data))
+	}
+	if data, _, err = client.Resources.GetDataByDeviceID(id); err != nil {
+		t.Fatal(err)
+	} else if len(data) != 3 {
+		t.Errorf("got %d points, want 3", len(data))
+	}
+}
@@ -0,0 +1,461 @@
+package apitest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// object is a single stored resource
+type object struct {
+	coll      string
+	id        string
+	seq       int64
+	createdAt time.Time
+	updatedAt time.Time
+	attrs     map[string]interface{}
+	// to-one relations, values are keys of related objects, e.g. "products/ID"
+	links map[string]string
+	// password or secret used for authentication, never rendered
+	secret string
+}
+
+func (o *object) key() string {
+	return o.coll + "/" + o.id
+}
+
+func newId() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// insert stores new object, s.mu must be held
+func (s *Server) insert(coll string, attrs map[string]interface{}, links map[string]string) *object {
+	now := time.Now().UTC()
+	s.seq++
+	o := &object{
+		coll:      coll,
+		id:        newId(),
+		seq:       s.seq,
+		createdAt: now,
+		updatedAt: now,
+		attrs:     map[string]interface{}{},
+		links:     map[string]string{},
+	}
+	for k, v := range attrs {
+		o.attrs[k] = v
+	}
+	for k, v := range links {
+		o.links[k] = v
+	}
+	if _, ok := schema[coll].links["tenant"]; ok && s.tenant != nil {
+		if _, ok := o.links["tenant"]; !ok {
+			o.links["tenant"] = s.tenant.key()
+		}
+	}
+	s.objects[o.key()] = o
+	return o
+}
+
+// get returns object by key, s.mu must be held
+func (s *Server) get(key string) *object {
+	return s.objects[key]
+}
+
+// remove deletes object together with memberships referencing it, s.mu must be held
+func (s *Server) remove(o *object) {
+	delete(s.objects, o.key())
+	for _, m := range []string{"clusterMemberships", "groupMemberships", "memberships"} {
+		for _, field := range []string{"device", "cluster", "group", "user", "usergroup"} {
+			for _, x := range s.filter(m, field, o.key()) {
+				delete(s.objects, x.key())
+			}
+		}
+	}
+	delete(s.series, o.key())
+}
+
+// filter returns objects of collection whose link field points to key, s.mu must be held
+func (s *Server) filter(coll, field, key string) []*object {
+	var res []*object
+	for _, o := range s.objects {
+		if o.coll == coll && o.links[field] == key {
+			res = append(res, o)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].seq < res[j].seq })
+	return res
+}
+
+// list returns items of collection nested under parent, s.mu must be held
+func (s *Server) list(parent *object, sub subSpec) []*object {
+	switch {
+	case sub.custom != nil:
+		return sub.custom(s, parent)
+	case sub.through != "":
+		var res []*object
+		for _, m := range s.filter(sub.through, sub.parentField, parent.key()) {
+			if o := s.get(m.links[sub.targetField]); o != nil {
+				res = append(res, o)
+			}
+		}
+		return res
+	default:
+		return s.filter(sub.coll, sub.field, parent.key())
+	}
+}
+
+// href returns absolute link to key
+func (s *Server) href(key string) string {
+	return s.URL + apiPath + key
+}
+
+// keyOf converts link, absolute or relative, into key of object
+func (s *Server) keyOf(link string) string {
+	if u, err := url.Parse(link); err == nil {
+		link = u.Path
+	}
+	link = strings.TrimPrefix(link, apiPath)
+	return strings.Trim(link, "/")
+}
+
+// render converts object into JSON representation with requested expansions, s.mu must be held
+func (s *Server) render(o *object, exp expansion) map[string]interface{} {
+	m := make(map[string]interface{}, len(o.attrs)+8)
+	for k, v := range o.attrs {
+		m[k] = v
+	}
+	m["href"] = s.href(o.key())
+	m["createdAt"] = o.createdAt.Format(time.RFC3339Nano)
+	m["updatedAt"] = o.updatedAt.Format(time.RFC3339Nano)
+
+	spec := schema[o.coll]
+	for rel := range spec.links {
+		key, ok := o.links[rel]
+		if !ok {
+			continue
+		}
+		if e, ok := exp[rel]; ok {
+			if target := s.get(key); target != nil {
+				m[rel] = s.render(target, e.nested)
+				continue
+			}
+		}
+		m[rel] = map[string]interface{}{"href": s.href(key)}
+	}
+	for name, sub := range spec.subs {
+		link := o.key() + "/" + name
+		if e, ok := exp[name]; ok {
+			m[name] = s.renderCollection(link, s.list(o, sub), e.limit, e.page, e.nested)
+			continue
+		}
+		m[name] = map[string]interface{}{"href": s.href(link)}
+	}
+	return m
+}
+
+// renderCollection renders single page of items, s.mu must be held
+func (s *Server) renderCollection(link string, items []*object, limit, page int, exp expansion) map[string]interface{} {
+	rendered := make([]map[string]interface{}, 0, len(items))
+	for _, o := range items {
+		rendered = append(rendered, s.render(o, exp))
+	}
+	return s.paginate(link, rendered, limit, page, nil)
+}
+
+// paginate renders page of already rendered items with links to neighbour pages
+func (s *Server) paginate(link string, items []map[string]interface{}, limit, page int, query url.Values) map[string]interface{} {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if page <= 0 {
+		page = 1
+	}
+	start := (page - 1) * limit
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+
+	pageLink := func(p int) map[string]interface{} {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		q.Set("limit", strconv.Itoa(limit))
+		q.Set("page", strconv.Itoa(p))
+		return map[string]interface{}{"href": s.href(link) + "?" + q.Encode()}
+	}
+
+	m := map[string]interface{}{
+		"href":  s.href(link),
+		"size":  len(items),
+		"limit": limit,
+		"page":  page,
+		"items": items[start:end],
+	}
+	if end < len(items) {
+		m["next"] = pageLink(page + 1)
+	}
+	if page > 1 {
+		m["prev"] = pageLink(page - 1)
+	}
+	return m
+}
+
+// expansion is parsed 'expand' query parameter
+type expansion map[string]expandItem
+
+type expandItem struct {
+	limit  int
+	page   int
+	nested expansion
+}
+
+// parseExpansion parses expansions rendered by client, e.g.
+// "clusters(limit:10,page:2),product(expand:(tenant))"
+func parseExpansion(str string) (expansion, error) {
+	p := &expandParser{str: str}
+	exp, err := p.list()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.str) {
+		return nil, fmt.Errorf("unexpected %q in expand at %d", p.str[p.pos], p.pos)
+	}
+	return exp, nil
+}
+
+type expandParser struct {
+	str string
+	pos int
+}
+
+func (p *expandParser) peek() byte {
+	if p.pos < len(p.str) {
+		return p.str[p.pos]
+	}
+	return 0
+}
+
+func (p *expandParser) ident() string {
+	start := p.pos
+	for p.pos < len(p.str) && strings.IndexByte("(),:", p.str[p.pos]) < 0 {
+		p.pos++
+	}
+	return strings.TrimSpace(p.str[start:p.pos])
+}
+
+func (p *expandParser) list() (expansion, error) {
+	exp := expansion{}
+	for {
+		name := p.ident()
+		if name == "" {
+			return nil, fmt.Errorf("empty relation in expand at %d", p.pos)
+		}
+		item := expandItem{}
+		if p.peek() == '(' {
+			p.pos++
+			if err := p.options(&item); err != nil {
+				return nil, err
+			}
+		}
+		exp[name] = item
+		if p.peek() != ',' {
+			return exp, nil
+		}
+		p.pos++
+	}
+}
+
+func (p *expandParser) options(item *expandItem) error {
+	for {
+		key := p.ident()
+		if p.peek() != ':' {
+			return fmt.Errorf("missing value of %s in expand", key)
+		}
+		p.pos++
+		switch key {
+		case "expand":
+			if p.peek() != '(' {
+				return fmt.Errorf("nested expand must be parenthesized")
+			}
+			p.pos++
+			nested, err := p.list()
+			if err != nil {
+				return err
+			}
+			if p.peek() != ')' {
+				return fmt.Errorf("unterminated nested expand")
+			}
+			p.pos++
+			item.nested = nested
+		case "limit", "page":
+			n, err := strconv.Atoi(p.ident())
+			if err != nil {
+				return fmt.Errorf("invalid %s in expand: %v", key, err)
+			}
+			if key == "limit" {
+				item.limit = n
+			} else {
+				item.page = n
+			}
+		default:
+			return fmt.Errorf("unknown expand option %s", key)
+		}
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return nil
+		default:
+			return fmt.Errorf("unterminated expand options")
+		}
+	}
+}
+
+// reserved query parameters which are not filters
+var reservedParams = map[string]bool{
+	"limit": true, "page": true, "expand": true, "sort": true, "fields": true,
+	"start": true, "end": true, "tz": true, "application": true,
+}
+
+// applyQuery filters, sorts and trims rendered items according to query parameters
+func applyQuery(items []map[string]interface{}, query url.Values) ([]map[string]interface{}, error) {
+	type cond struct {
+		field, op, value string
+	}
+	var conds []cond
+	for k, vs := range query {
+		if reservedParams[k] {
+			continue
+		}
+		field, op := k, "eq"
+		if i := strings.IndexByte(k, '['); i > 0 && strings.HasSuffix(k, "]") {
+			field, op = k[:i], k[i+1:len(k)-1]
+		}
+		switch op {
+		case "eq", "ne", "gt", "gte", "lt", "lte", "prefix":
+		default:
+			return nil, fmt.Errorf("unknown filter operator %s", op)
+		}
+		for _, v := range vs {
+			conds = append(conds, cond{field, op, v})
+		}
+	}
+
+	res := make([]map[string]interface{}, 0, len(items))
+	for _, it := range items {
+		ok := true
+		for _, c := range conds {
+			v, found := lookup(it, c.field)
+			if !found || !matches(v, c.op, c.value) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			res = append(res, it)
+		}
+	}
+
+	if s := query.Get("sort"); s != "" {
+		fields := strings.Split(s, ",")
+		sort.SliceStable(res, func(i, j int) bool {
+			for _, f := range fields {
+				desc := strings.HasPrefix(f, "-")
+				f = strings.TrimPrefix(f, "-")
+				a, _ := lookup(res[i], f)
+				b, _ := lookup(res[j], f)
+				c := compare(fmt.Sprint(a), fmt.Sprint(b))
+				if c == 0 {
+					continue
+				}
+				return (c < 0) != desc
+			}
+			return false
+		})
+	}
+
+	if f := query.Get("fields"); f != "" {
+		fields := strings.Split(f, ",")
+		for i, it := range res {
+			trimmed := map[string]interface{}{"href": it["href"]}
+			for _, name := range fields {
+				if v, ok := it[name]; ok {
+					trimmed[name] = v
+				}
+			}
+			res[i] = trimmed
+		}
+	}
+	return res, nil
+}
+
+// lookup returns value of dotted field, e.g. custom.site
+func lookup(m map[string]interface{}, field string) (interface{}, bool) {
+	var cur interface{} = m
+	for _, part := range strings.Split(field, ".") {
+		mm, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		cur, ok = mm[part]
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+func matches(v interface{}, op, value string) bool {
+	str := fmt.Sprint(v)
+	switch op {
+	case "eq":
+		return compare(str, value) == 0
+	case "ne":
+		return compare(str, value) != 0
+	case "gt":
+		return compare(str, value) > 0
+	case "gte":
+		return compare(str, value) >= 0
+	case "lt":
+		return compare(str, value) < 0
+	case "lte":
+		return compare(str, value) <= 0
+	case "prefix":
+		return strings.HasPrefix(str, value)
+	}
+	return false
+}
+
+// compare compares values as numbers, times or strings, whichever fits both
+func compare(a, b string) int {
+	if x, err := strconv.ParseFloat(a, 64); err == nil {
+		if y, err := strconv.ParseFloat(b, 64); err == nil {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+	if x, err := time.Parse(time.RFC3339Nano, a); err == nil {
+		if y, err := time.Parse(time.RFC3339Nano, b); err == nil {
+			return x.Compare(y)
+		}
+	}
+	return strings.Compare(a, b)
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -14,18 +15,27 @@ import (
 // https://tenant-name.cloudthing.io/api/v1/applications
 type ApplicationsService interface {
 	GetById(string, ...interface{}) (*Application, error)
+	GetByIdContext(context.Context, string, ...interface{}) (*Application, error)
 	GetByLink(string, ...interface{}) (*Application, error)
+	GetByLinkContext(context.Context, string, ...interface{}) (*Application, error)
 	List(...interface{}) ([]Application, *ListParams, error)
+	ListContext(context.Context, ...interface{}) ([]Application, *ListParams, error)
+	Iterate(context.Context, ...interface{}) *Iterator[Application]
 	ListByLink(string, ...interface{}) ([]Application, *ListParams, error)
+	ListByLinkContext(context.Context, string, ...interface{}) ([]Application, *ListParams, error)
+	IterateByLink(context.Context, string, ...interface{}) *Iterator[Application]
 	Create(*ApplicationRequestCreate) (*Application, error)
+	CreateContext(context.Context, *ApplicationRequestCreate) (*Application, error)
 	UpdateById(string, *ApplicationRequestUpdate) (*Application, error)
+	UpdateByIdContext(context.Context, string, *ApplicationRequestUpdate) (*Application, error)
 	UpdateByLink(string, *ApplicationRequestUpdate) (*Application, error)
+	UpdateByLinkContext(context.Context, string, *ApplicationRequestUpdate) (*Application, error)
 	Delete(*Application) error
+	DeleteContext(context.Context, *Application) error
 	DeleteByLink(string) error
+	DeleteByLinkContext(context.Context, string) error
 	DeleteById(string) error
-
-	get(*ApplicationResponse) (*Application, error)
-	getCollection(*ApplicationsResponse) ([]Application, *ListParams, error)
+	DeleteByIdContext(context.Context, string) error
 }
 
 // ApplicationsServiceOp handles communication with Applications related methods of API
@@ -171,15 +181,27 @@ func (t *Application) Delete() error {
 
 // GetById retrieves application by its ID
 func (s *ApplicationsServiceOp) GetById(id string, args ...interface{}) (*Application, error) {
+	return s.GetByIdContext(context.Background(), id, args...)
+}
+
+// GetByIdContext is like GetById but uses ctx for the underlying request.
+func (s *ApplicationsServiceOp) GetByIdContext(ctx context.Context, id string, args ...interface{}) (*Application, error) {
+	ctx = withOperation(ctx, "Applications", "GetById")
 	endpoint := "applications/"
 	endpoint = fmt.Sprintf("%s%s", endpoint, id)
 
-	return s.GetByLink(endpoint, args...)
+	return s.GetByLinkContext(ctx, endpoint, args...)
 }
 
 // GetById retrieves application by its full link
 func (s *ApplicationsServiceOp) GetByLink(endpoint string, args ...interface{}) (*Application, error) {
-	resp, err := s.client.request("GET", endpoint, nil, args...)
+	return s.GetByLinkContext(context.Background(), endpoint, args...)
+}
+
+// GetByLinkContext is like GetByLink but uses ctx for the underlying request.
+func (s *ApplicationsServiceOp) GetByLinkContext(ctx context.Context, endpoint string, args ...interface{}) (*Application, error) {
+	ctx = withOperation(ctx, "Applications", "GetByLink")
+	resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -187,7 +209,7 @@ func (s *ApplicationsServiceOp) GetByLink(endpoint string, args ...interface{})
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &ApplicationResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -219,25 +241,51 @@ func (s *ApplicationsServiceOp) get(r *ApplicationResponse) (*Application, error
 		}
 		ten := &TenantResponse{}
 		json.Unmarshal(bytes, ten)
-		t, err := s.client.Tenant.get(ten)
+		t, err := s.client.conv.tenant.get(ten)
 		if err != nil {
 			return nil, err
 		}
 		obj.Tenant = t
 	}
-	/*   if len(r.Directory) > 1 {
-	     bytes, err := json.Marshal(r.Directory)
-	     if err != nil {
-	         return nil, err
-	     }
-	     ten := &DirectoryResponse{}
-	     json.Unmarshal(bytes, ten)
-	     t, err := s.client.Directories.get(ten)
-	     if err != nil {
-	         return nil, err
-	     }
-	     obj.Directory = t
-	 }*/
+	if len(r.Directory) > 1 {
+		bytes, err := json.Marshal(r.Directory)
+		if err != nil {
+			return nil, err
+		}
+		ten := &DirectoryResponse{}
+		json.Unmarshal(bytes, ten)
+		t, err := s.client.conv.directories.get(ten)
+		if err != nil {
+			return nil, err
+		}
+		obj.Directory = t
+	}
+	if len(r.Devices) > 1 {
+		bytes, err := json.Marshal(r.Devices)
+		if err != nil {
+			return nil, err
+		}
+		ten := &DevicesResponse{}
+		json.Unmarshal(bytes, ten)
+		t, _, err := s.client.conv.devices.getCollection(ten)
+		if err != nil {
+			return nil, err
+		}
+		obj.Devices = t
+	}
+	if len(r.Clusters) > 1 {
+		bytes, err := json.Marshal(r.Clusters)
+		if err != nil {
+			return nil, err
+		}
+		ten := &ClustersResponse{}
+		json.Unmarshal(bytes, ten)
+		t, _, err := s.client.conv.clusters.getCollection(ten)
+		if err != nil {
+			return nil, err
+		}
+		obj.Clusters = t
+	}
 	obj.service = s
 	return obj, nil
 }
@@ -266,13 +314,32 @@ func (s *ApplicationsServiceOp) getCollection(r *ApplicationsResponse) ([]Applic
 
 // GetById retrieves collection of applications of current tenant
 func (s *ApplicationsServiceOp) List(args ...interface{}) ([]Application, *ListParams, error) {
-	endpoint := fmt.Sprintf("tenants/%s/applications", s.client.tenantId)
-	return s.ListByLink(endpoint, args...)
+	return s.ListContext(context.Background(), args...)
+}
+
+// ListContext is like List but uses ctx for the underlying request.
+func (s *ApplicationsServiceOp) ListContext(ctx context.Context, args ...interface{}) ([]Application, *ListParams, error) {
+	ctx = withOperation(ctx, "Applications", "List")
+	endpoint := fmt.Sprintf("tenants/%s/applications", s.client.currentTenantId(ctx))
+	return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// Iterate is like List but returns iterator walking over all pages
+func (s *ApplicationsServiceOp) Iterate(ctx context.Context, args ...interface{}) *Iterator[Application] {
+	ctx = withOperation(ctx, "Applications", "Iterate")
+	endpoint := fmt.Sprintf("tenants/%s/applications", s.client.currentTenantId(ctx))
+	return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of applications by link
 func (s *ApplicationsServiceOp) ListByLink(endpoint string, args ...interface{}) ([]Application, *ListParams, error) {
-	resp, err := s.client.request("GET", endpoint, nil, args...)
+	return s.ListByLinkContext(context.Background(), endpoint, args...)
+}
+
+// ListByLinkContext is like ListByLink but uses ctx for the underlying request.
+func (s *ApplicationsServiceOp) ListByLinkContext(ctx context.Context, endpoint string, args ...interface{}) ([]Application, *ListParams, error) {
+	ctx = withOperation(ctx, "Applications", "ListByLink")
+	resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -280,7 +347,7 @@ func (s *ApplicationsServiceOp) ListByLink(endpoint string, args ...interface{})
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &ApplicationsResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -289,14 +356,32 @@ func (s *ApplicationsServiceOp) ListByLink(endpoint string, args ...interface{})
 	return s.getCollection(obj)
 }
 
+// IterateByLink is like ListByLink but returns iterator walking over all pages
+func (s *ApplicationsServiceOp) IterateByLink(ctx context.Context, endpoint string, args ...interface{}) *Iterator[Application] {
+	ctx = withOperation(ctx, "Applications", "IterateByLink")
+	return newIterator(ctx, endpoint, s.ListByLinkContext, args...)
+}
+
 // GetById updates application with specified ID
 func (s *ApplicationsServiceOp) UpdateById(id string, t *ApplicationRequestUpdate) (*Application, error) {
+	return s.UpdateByIdContext(context.Background(), id, t)
+}
+
+// UpdateByIdContext is like UpdateById but uses ctx for the underlying request.
+func (s *ApplicationsServiceOp) UpdateByIdContext(ctx context.Context, id string, t *ApplicationRequestUpdate) (*Application, error) {
+	ctx = withOperation(ctx, "Applications", "UpdateById")
 	endpoint := fmt.Sprintf("applications/%s", id)
-	return s.UpdateByLink(endpoint, t)
+	return s.UpdateByLinkContext(ctx, endpoint, t)
 }
 
 // GetById updates application specified by link
 func (s *ApplicationsServiceOp) UpdateByLink(endpoint string, t *ApplicationRequestUpdate) (*Application, error) {
+	return s.UpdateByLinkContext(context.Background(), endpoint, t)
+}
+
+// UpdateByLinkContext is like UpdateByLink but uses ctx for the underlying request.
+func (s *ApplicationsServiceOp) UpdateByLinkContext(ctx context.Context, endpoint string, t *ApplicationRequestUpdate) (*Application, error) {
+	ctx = withOperation(ctx, "Applications", "UpdateByLink")
 	enc, err := json.Marshal(t)
 	if err != nil {
 		return nil, err
@@ -304,7 +389,7 @@ func (s *ApplicationsServiceOp) UpdateByLink(endpoint string, t *ApplicationRequ
 
 	buf := bytes.NewBuffer(enc)
 
-	resp, err := s.client.request("POST", endpoint, buf)
+	resp, err := s.client.request(ctx, "POST", endpoint, buf)
 	if err != nil {
 		return nil, err
 	}
@@ -312,7 +397,7 @@ func (s *ApplicationsServiceOp) UpdateByLink(endpoint string, t *ApplicationRequ
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &ApplicationResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -322,7 +407,13 @@ func (s *ApplicationsServiceOp) UpdateByLink(endpoint string, t *ApplicationRequ
 
 // Create creates new application within tenant
 func (s *ApplicationsServiceOp) Create(dir *ApplicationRequestCreate) (*Application, error) {
-	endpoint := fmt.Sprintf("tenants/%s/applications", s.client.tenantId)
+	return s.CreateContext(context.Background(), dir)
+}
+
+// CreateContext is like Create but uses ctx for the underlying request.
+func (s *ApplicationsServiceOp) CreateContext(ctx context.Context, dir *ApplicationRequestCreate) (*Application, error) {
+	ctx = withOperation(ctx, "Applications", "Create")
+	endpoint := fmt.Sprintf("tenants/%s/applications", s.client.currentTenantId(ctx))
 
 	enc, err := json.Marshal(dir)
 	if err != nil {
@@ -331,7 +422,7 @@ func (s *ApplicationsServiceOp) Create(dir *ApplicationRequestCreate) (*Applicat
 
 	buf := bytes.NewBuffer(enc)
 
-	resp, err := s.client.request("POST", endpoint, buf)
+	resp, err := s.client.request(ctx, "POST", endpoint, buf)
 	if err != nil {
 		return nil, err
 	}
@@ -339,7 +430,7 @@ func (s *ApplicationsServiceOp) Create(dir *ApplicationRequestCreate) (*Applicat
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &ApplicationResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -349,18 +440,36 @@ func (s *ApplicationsServiceOp) Create(dir *ApplicationRequestCreate) (*Applicat
 
 // Delete removes application
 func (s *ApplicationsServiceOp) Delete(t *Application) error {
-	return s.DeleteByLink(t.Href)
+	return s.DeleteContext(context.Background(), t)
+}
+
+// DeleteContext is like Delete but uses ctx for the underlying request.
+func (s *ApplicationsServiceOp) DeleteContext(ctx context.Context, t *Application) error {
+	ctx = withOperation(ctx, "Applications", "Delete")
+	return s.DeleteByLinkContext(ctx, t.Href)
 }
 
 // Delete removes application by ID
 func (s *ApplicationsServiceOp) DeleteById(id string) error {
+	return s.DeleteByIdContext(context.Background(), id)
+}
+
+// DeleteByIdContext is like DeleteById but uses ctx for the underlying request.
+func (s *ApplicationsServiceOp) DeleteByIdContext(ctx context.Context, id string) error {
+	ctx = withOperation(ctx, "Applications", "DeleteById")
 	endpoint := fmt.Sprintf("applications/%s", id)
-	return s.DeleteByLink(endpoint)
+	return s.DeleteByLinkContext(ctx, endpoint)
 }
 
 // Delete removes application by link
 func (s *ApplicationsServiceOp) DeleteByLink(endpoint string) error {
-	resp, err := s.client.request("DELETE", endpoint, nil)
+	return s.DeleteByLinkContext(context.Background(), endpoint)
+}
+
+// DeleteByLinkContext is like DeleteByLink but uses ctx for the underlying request.
+func (s *ApplicationsServiceOp) DeleteByLinkContext(ctx context.Context, endpoint string) error {
+	ctx = withOperation(ctx, "Applications", "DeleteByLink")
+	resp, err := s.client.request(ctx, "DELETE", endpoint, nil)
 	if err != nil {
 		return err
 	}
@@ -368,7 +477,7 @@ func (s *ApplicationsServiceOp) DeleteByLink(endpoint string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent {
-		return ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return newApiError(resp, "non-ok status returned")
 	}
 	return nil
 }
@@ -0,0 +1,470 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrBatchWriterClosed is returned by writes to closed BatchWriter
+var ErrBatchWriterClosed = errors.New("batch writer is closed")
+
+// BatchOptions configures BatchWriter, zero values are replaced by defaults
+type BatchOptions struct {
+	// Number of buffered points of a link which triggers its flush, 500 by default
+	MaxPoints int
+	// Encoded size of buffered points of a link which triggers its flush, 256 KiB by default
+	MaxBytes int
+	// Period of flushing all buffers, 1s by default
+	Interval time.Duration
+	// Number of batches written concurrently, 4 by default
+	Workers int
+	// Number of attempts to write a batch, 3 by default
+	MaxAttempts int
+	// Delay before the second attempt, doubled with every next one, 500ms by default
+	Backoff time.Duration
+	// Capacity of Errors channel, 64 by default
+	ErrorBuffer int
+}
+
+func (o BatchOptions) withDefaults() BatchOptions {
+	if o.MaxPoints <= 0 {
+		o.MaxPoints = 500
+	}
+	if o.MaxBytes <= 0 {
+		o.MaxBytes = 256 << 10
+	}
+	if o.Interval <= 0 {
+		o.Interval = time.Second
+	}
+	if o.Workers <= 0 {
+		o.Workers = 4
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 3
+	}
+	if o.Backoff <= 0 {
+		o.Backoff = 500 * time.Millisecond
+	}
+	if o.ErrorBuffer <= 0 {
+		o.ErrorBuffer = 64
+	}
+	return o
+}
+
+// BatchError reports batch which couldn't be written. Only one of Data and Events is set.
+type BatchError struct {
+	Link     string
+	Data     []DataPoint
+	Events   []EventPoint
+	Attempts int
+	Err      error
+}
+
+func (e *BatchError) Error() string {
+	n := len(e.Data) + len(e.Events)
+	return fmt.Sprintf("writing %d points to %s failed after %d attempts: %v", n, e.Link, e.Attempts, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// batchKey identifies buffer, data and events of the same link are written separately
+type batchKey struct {
+	link string
+	kind ResourceKind
+}
+
+// batch is a set of points written with a single request
+type batch struct {
+	batchKey
+	data   []DataPoint
+	events []EventPoint
+	size   int
+	// context of Flush which queued batch, nil if queued by writer itself
+	ctx context.Context
+}
+
+func (b *batch) len() int {
+	return len(b.data) + len(b.events)
+}
+
+// BatchWriter buffers points per device or cluster link and writes them in batches.
+// Buffer of a link is flushed when it reaches MaxPoints or MaxBytes, and all buffers
+// are flushed every Interval. Failed batches are retried, batches which couldn't be
+// written are reported via Errors.
+//
+//	w := api.NewBatchWriter(client.Resources, api.BatchOptions{})
+//	go func() {
+//		for err := range w.Errors() {
+//			log.Print(err)
+//		}
+//	}()
+//	w.WriteDataForDeviceID(deviceId, api.DataPoint{Key: "temperature", Value: 21.5})
+//	...
+//	w.Close()
+type BatchWriter struct {
+	service ResourcesService
+	opts    BatchOptions
+
+	// context of all writes, cancelled when CloseContext gives up waiting
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	buffers map[batchKey]*batch
+	closed  bool
+	// number of batches taken from buffers and not written yet
+	inflight int
+	idle     *sync.Cond
+
+	queue   chan *batch
+	errs    chan *BatchError
+	workers sync.WaitGroup
+	stop    chan struct{}
+	ticker  sync.WaitGroup
+	once    sync.Once
+}
+
+// NewBatchWriter creates writer using service and starts its workers
+func NewBatchWriter(service ResourcesService, opts BatchOptions) *BatchWriter {
+	opts = opts.withDefaults()
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &BatchWriter{
+		service: service,
+		opts:    opts,
+		ctx:     ctx,
+		cancel:  cancel,
+		buffers: make(map[batchKey]*batch),
+		queue:   make(chan *batch, opts.Workers),
+		errs:    make(chan *BatchError, opts.ErrorBuffer),
+		stop:    make(chan struct{}),
+	}
+	w.idle = sync.NewCond(&w.mu)
+	w.workers.Add(opts.Workers)
+	for i := 0; i < opts.Workers; i++ {
+		go w.work()
+	}
+	w.ticker.Add(1)
+	go w.tick()
+	return w
+}
+
+// Errors returns channel of batches which couldn't be written. It is closed by Close.
+// When the channel is full, further errors are dropped, so it should be drained.
+func (w *BatchWriter) Errors() <-chan *BatchError {
+	return w.errs
+}
+
+// WriteData buffers data points for link, e.g. "devices/ID/resources/data"
+func (w *BatchWriter) WriteData(link string, points ...DataPoint) error {
+	return w.add(link, points, nil)
+}
+
+// WriteEvents buffers events for link, e.g. "devices/ID/resources/events"
+func (w *BatchWriter) WriteEvents(link string, points ...EventPoint) error {
+	return w.add(link, nil, points)
+}
+
+// WriteDataForDeviceID buffers data points of device
+func (w *BatchWriter) WriteDataForDeviceID(deviceID string, points ...DataPoint) error {
+	return w.WriteData(fmt.Sprintf("devices/%s/resources/data", deviceID), points...)
+}
+
+// WriteEventsForDeviceID buffers events of device
+func (w *BatchWriter) WriteEventsForDeviceID(deviceID string, points ...EventPoint) error {
+	return w.WriteEvents(fmt.Sprintf("devices/%s/resources/events", deviceID), points...)
+}
+
+// WriteDataForClusterID buffers data points of cluster
+func (w *BatchWriter) WriteDataForClusterID(clusterID string, points ...DataPoint) error {
+	return w.WriteData(fmt.Sprintf("clusters/%s/resources/data", clusterID), points...)
+}
+
+// WriteEventsForClusterID buffers events of cluster
+func (w *BatchWriter) WriteEventsForClusterID(clusterID string, points ...EventPoint) error {
+	return w.WriteEvents(fmt.Sprintf("clusters/%s/resources/events", clusterID), points...)
+}
+
+// add appends points to buffer of link, full batches are queued for writing
+func (w *BatchWriter) add(link string, data []DataPoint, events []EventPoint) error {
+	sizes := make([]int, 0, len(data)+len(events))
+	for _, p := range data {
+		b, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		sizes = append(sizes, len(b)+1)
+	}
+	for _, p := range events {
+		b, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		sizes = append(sizes, len(b)+1)
+	}
+
+	var full []*batch
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return ErrBatchWriterClosed
+	}
+	for i, size := range sizes {
+		key := batchKey{link: link, kind: ResourceData}
+		if i >= len(data) {
+			key.kind = ResourceEvents
+		}
+		b, ok := w.buffers[key]
+		if !ok {
+			b = &batch{batchKey: key}
+			w.buffers[key] = b
+		}
+		if i < len(data) {
+			b.data = append(b.data, data[i])
+		} else {
+			b.events = append(b.events, events[i-len(data)])
+		}
+		b.size += size
+		if b.len() >= w.opts.MaxPoints || b.size >= w.opts.MaxBytes {
+			delete(w.buffers, key)
+			full = append(full, b)
+		}
+	}
+	// batches are counted before lock is released, so Flush waits for them
+	w.inflight += len(full)
+	w.mu.Unlock()
+
+	for _, b := range full {
+		w.queue <- b
+	}
+	return nil
+}
+
+// take removes all buffers, w.mu must be held
+func (w *BatchWriter) take() []*batch {
+	batches := make([]*batch, 0, len(w.buffers))
+	for key, b := range w.buffers {
+		batches = append(batches, b)
+		delete(w.buffers, key)
+	}
+	w.inflight += len(batches)
+	return batches
+}
+
+// wait blocks until all taken batches are written, w.mu must be held
+func (w *BatchWriter) wait() {
+	for w.inflight > 0 {
+		w.idle.Wait()
+	}
+}
+
+// Flush writes all buffered points and waits until all queued batches are written
+// or ctx is done. Failures are reported via Errors. Writes of flushed batches are
+// bound to ctx, batches which weren't queued before it is done stay buffered.
+func (w *BatchWriter) Flush(ctx context.Context) error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return ErrBatchWriterClosed
+	}
+	batches := w.take()
+	w.mu.Unlock()
+
+	for i, b := range batches {
+		b.ctx = ctx
+		select {
+		case w.queue <- b:
+		case <-ctx.Done():
+			w.mu.Lock()
+			for _, b := range batches[i:] {
+				w.requeue(b)
+			}
+			w.mu.Unlock()
+			return ctx.Err()
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		w.mu.Lock()
+		w.wait()
+		w.mu.Unlock()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// requeue returns batch which wasn't queued into buffers, w.mu must be held
+func (w *BatchWriter) requeue(b *batch) {
+	w.inflight--
+	if w.inflight == 0 {
+		w.idle.Broadcast()
+	}
+	b.ctx = nil
+	cur, ok := w.buffers[b.batchKey]
+	if !ok {
+		w.buffers[b.batchKey] = b
+		return
+	}
+	cur.data = append(b.data, cur.data...)
+	cur.events = append(b.events, cur.events...)
+	cur.size += b.size
+}
+
+// Close flushes all buffered points, waits until they are written and stops workers.
+// Errors channel is closed afterwards. Writes after Close return ErrBatchWriterClosed.
+func (w *BatchWriter) Close() error {
+	return w.CloseContext(context.Background())
+}
+
+// CloseContext is like Close but stops waiting when ctx is done. Writes which are
+// still in progress, including their retries, are then cancelled and reported via
+// Errors, and ctx's error is returned.
+func (w *BatchWriter) CloseContext(ctx context.Context) error {
+	var err error
+	w.once.Do(func() {
+		close(w.stop)
+		w.ticker.Wait()
+
+		w.mu.Lock()
+		w.closed = true
+		batches := w.take()
+		w.mu.Unlock()
+
+		done := make(chan struct{})
+		watched := make(chan struct{})
+		go func() {
+			defer close(watched)
+			select {
+			case <-ctx.Done():
+				err = ctx.Err()
+				w.cancel()
+			case <-done:
+			}
+		}()
+
+		// cancelled writes fail right away, so queue keeps draining
+		for _, b := range batches {
+			w.queue <- b
+		}
+		w.mu.Lock()
+		w.wait()
+		w.mu.Unlock()
+		close(done)
+		<-watched
+
+		close(w.queue)
+		w.workers.Wait()
+		w.cancel()
+		close(w.errs)
+	})
+	return err
+}
+
+// tick periodically flushes all buffers
+func (w *BatchWriter) tick() {
+	defer w.ticker.Done()
+	t := time.NewTicker(w.opts.Interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-t.C:
+			w.mu.Lock()
+			batches := w.take()
+			w.mu.Unlock()
+			for _, b := range batches {
+				select {
+				case w.queue <- b:
+				case <-w.stop:
+					w.mu.Lock()
+					w.requeue(b)
+					w.mu.Unlock()
+				}
+			}
+		}
+	}
+}
+
+// work writes queued batches
+func (w *BatchWriter) work() {
+	defer w.workers.Done()
+	for b := range w.queue {
+		w.write(b)
+		w.mu.Lock()
+		w.inflight--
+		if w.inflight == 0 {
+			w.idle.Broadcast()
+		}
+		w.mu.Unlock()
+	}
+}
+
+// write sends batch, retrying failures which may be temporary. Writes stop when
+// context of writer or of Flush which queued batch is done.
+func (w *BatchWriter) write(b *batch) {
+	ctx := w.ctx
+	if b.ctx != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(b.ctx)
+		defer cancel()
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			select {
+			case <-w.ctx.Done():
+				cancel()
+			case <-stop:
+			}
+		}()
+	}
+
+	wait := w.opts.Backoff
+	var err error
+	attempt := 1
+	for ; ; attempt++ {
+		if b.kind == ResourceEvents {
+			_, err = w.service.WriteEventsForLinkContext(ctx, b.link, b.events)
+		} else {
+			_, err = w.service.WriteDataForLinkContext(ctx, b.link, b.data)
+		}
+		if err == nil {
+			return
+		}
+		if attempt >= w.opts.MaxAttempts || ctx.Err() != nil || !batchRetryable(err) {
+			break
+		}
+		if !sleepContext(ctx, wait) {
+			err = ctx.Err()
+			break
+		}
+		wait *= 2
+	}
+
+	e := &BatchError{Link: b.link, Data: b.data, Events: b.events, Attempts: attempt, Err: err}
+	select {
+	case w.errs <- e:
+	default:
+	}
+}
+
+// batchRetryable checks whether writing batch may succeed when repeated
+func batchRetryable(err error) bool {
+	var apiErr ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	return true
+}
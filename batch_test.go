@@ -0,0 +1,177 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+	"github.com/cloudthing-io/go-client-api/mocks"
+)
+
+func TestBatchWriterDataAndEvents(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := srv.Add("devices", map[string]interface{}{}, nil)
+
+	w := api.NewBatchWriter(client.Resources, api.BatchOptions{Interval: time.Hour})
+	if err := w.WriteDataForDeviceID(id, api.DataPoint{Key: "t", Value: 1.0}, api.DataPoint{Key: "t", Value: 2.0}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteEventsForDeviceID(id, api.EventPoint{Key: "alarm", Payload: map[string]interface{}{"level": 1.0}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	for err := range w.Errors() {
+		t.Error(err)
+	}
+
+	data, _, err := client.Resources.GetDataByDeviceID(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, _, err := client.Resources.GetEventsByDeviceID(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 2 || len(events) != 1 {
+		t.Errorf("got %d data points and %d events, want 2 and 1", len(data), len(events))
+	}
+	if err := w.WriteDataForDeviceID(id, api.DataPoint{Key: "t", Value: 3.0}); !errors.Is(err, api.ErrBatchWriterClosed) {
+		t.Errorf("write after Close = %v, want ErrBatchWriterClosed", err)
+	}
+}
+
+func TestBatchWriterMaxPoints(t *testing.T) {
+	written := make(chan []api.DataPoint, 1)
+	service := &mocks.ResourcesService{
+		WriteDataForLinkFunc: func(ctx context.Context, link string, points []api.DataPoint) ([]api.DataPoint, error) {
+			written <- points
+			return points, nil
+		},
+	}
+	w := api.NewBatchWriter(service, api.BatchOptions{MaxPoints: 3, Interval: time.Hour})
+	defer w.Close()
+
+	for i := 0; i < 3; i++ {
+		if err := w.WriteDataForDeviceID("1", api.DataPoint{Key: "t", Value: float64(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	select {
+	case points := <-written:
+		if len(points) != 3 {
+			t.Errorf("wrote batch of %d points, want 3", len(points))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("full buffer wasn't written")
+	}
+}
+
+func TestBatchWriterRetries(t *testing.T) {
+	var calls int32
+	service := &mocks.ResourcesService{
+		WriteDataForLinkFunc: func(ctx context.Context, link string, points []api.DataPoint) ([]api.DataPoint, error) {
+			switch atomic.AddInt32(&calls, 1) {
+			case 1:
+				return nil, api.ApiError{StatusCode: http.StatusServiceUnavailable}
+			case 2:
+				return points, nil
+			default:
+				return nil, api.ApiError{StatusCode: http.StatusBadRequest}
+			}
+		},
+	}
+	w := api.NewBatchWriter(service, api.BatchOptions{Interval: time.Hour, Backoff: time.Millisecond})
+
+	w.WriteDataForDeviceID("1", api.DataPoint{Key: "t", Value: 1.0})
+	if err := w.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("%d attempts after temporary failure, want 2", n)
+	}
+
+	// client errors aren't retried
+	w.WriteDataForDeviceID("1", api.DataPoint{Key: "t", Value: 2.0})
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	var errs []*api.BatchError
+	for err := range w.Errors() {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || errs[0].Attempts != 1 || len(errs[0].Data) != 1 {
+		t.Fatalf("errors = %v, want single failure after 1 attempt", errs)
+	}
+	if errs[0].Link != "devices/1/resources/data" {
+		t.Errorf("failed batch of %s, want devices/1/resources/data", errs[0].Link)
+	}
+}
+
+// blockingService writes nothing until ctx of the write is done
+func blockingService() *mocks.ResourcesService {
+	return &mocks.ResourcesService{
+		WriteDataForLinkFunc: func(ctx context.Context, link string, points []api.DataPoint) ([]api.DataPoint, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+}
+
+func TestBatchWriterFlushContext(t *testing.T) {
+	w := api.NewBatchWriter(blockingService(), api.BatchOptions{Interval: time.Hour, Backoff: time.Millisecond})
+	defer w.Close()
+
+	w.WriteDataForDeviceID("1", api.DataPoint{Key: "t", Value: 1.0})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := w.Flush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Flush = %v, want deadline exceeded", err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("Flush returned after %s", d)
+	}
+	select {
+	case err := <-w.Errors():
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("batch failed with %v, want deadline exceeded", err.Err)
+		}
+	case <-time.After(time.Second):
+		t.Error("write bound to Flush context wasn't cancelled")
+	}
+}
+
+func TestBatchWriterCloseContext(t *testing.T) {
+	w := api.NewBatchWriter(blockingService(), api.BatchOptions{Interval: time.Hour})
+	w.WriteDataForDeviceID("1", api.DataPoint{Key: "t", Value: 1.0})
+	w.WriteDataForDeviceID("2", api.DataPoint{Key: "t", Value: 1.0})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := w.CloseContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("CloseContext = %v, want deadline exceeded", err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("CloseContext returned after %s", d)
+	}
+	var failed int
+	for range w.Errors() {
+		failed++
+	}
+	if failed != 2 {
+		t.Errorf("%d batches reported, want 2 cancelled", failed)
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
     "bytes"
+    "context"
     "encoding/json" 
     "fmt"   
     "net/http"
@@ -13,19 +14,30 @@ import (
 // https://tenant-name.cloudthing.io/api/v1/clusterMemberships
 type ClusterMembershipsService interface {
     GetById(string, ...interface{}) (*ClusterMembership, error)
+    GetByIdContext(context.Context, string, ...interface{}) (*ClusterMembership, error)
     GetByLink(string, ...interface{}) (*ClusterMembership, error)
+    GetByLinkContext(context.Context, string, ...interface{}) (*ClusterMembership, error)
     ListByLink(string, ...interface{}) ([]ClusterMembership, *ListParams, error)
+    ListByLinkContext(context.Context, string, ...interface{}) ([]ClusterMembership, *ListParams, error)
+    IterateByLink(context.Context, string, ...interface{}) *Iterator[ClusterMembership]
     ListByDevice(string, ...interface{}) ([]ClusterMembership, *ListParams, error)
+    ListByDeviceContext(context.Context, string, ...interface{}) ([]ClusterMembership, *ListParams, error)
+    IterateByDevice(context.Context, string, ...interface{}) *Iterator[ClusterMembership]
     ListByCluster(string, ...interface{}) ([]ClusterMembership, *ListParams, error)
+    ListByClusterContext(context.Context, string, ...interface{}) ([]ClusterMembership, *ListParams, error)
+    IterateByCluster(context.Context, string, ...interface{}) *Iterator[ClusterMembership]
     CreateByLink(string, *ClusterMembershipRequestCreate) (*ClusterMembership, error)
+    CreateByLinkContext(context.Context, string, *ClusterMembershipRequestCreate) (*ClusterMembership, error)
     CreateByDevice(string, *ClusterMembershipRequestCreate) (*ClusterMembership, error)
+    CreateByDeviceContext(context.Context, string, *ClusterMembershipRequestCreate) (*ClusterMembership, error)
     CreateByCluster(string, *ClusterMembershipRequestCreate) (*ClusterMembership, error)
+    CreateByClusterContext(context.Context, string, *ClusterMembershipRequestCreate) (*ClusterMembership, error)
     Delete(*ClusterMembership) (error)
+    DeleteContext(context.Context, *ClusterMembership) (error)
     DeleteByLink(string) (error)
+    DeleteByLinkContext(context.Context, string) (error)
     DeleteById(string) (error)
-
-    get(*ClusterMembershipResponse) (*ClusterMembership, error)
-    getCollection(*ClusterMembershipsResponse) ([]ClusterMembership, *ListParams, error)
+    DeleteByIdContext(context.Context, string) (error)
 }
 
 // ClusterMembershipsServiceOp handles communication with ClusterMemberships related methods of API
@@ -106,15 +118,27 @@ func (t *ClusterMembership) Delete() error {
 
 // GetById retrieves product by its ID
 func (s *ClusterMembershipsServiceOp) GetById(id string, args ...interface{}) (*ClusterMembership, error) {
+    return s.GetByIdContext(context.Background(), id, args...)
+}
+
+// GetByIdContext is like GetById but uses ctx for the underlying request.
+func (s *ClusterMembershipsServiceOp) GetByIdContext(ctx context.Context, id string, args ...interface{}) (*ClusterMembership, error) {
+    ctx = withOperation(ctx, "ClusterMemberships", "GetById")
     endpoint := "clusterMemberships/"
     endpoint = fmt.Sprintf("%s%s", endpoint, id)
 
-    return s.GetByLink(endpoint, args...)
+    return s.GetByLinkContext(ctx, endpoint, args...)
 }
 
 // GetById retrieves product by its full link
 func (s *ClusterMembershipsServiceOp) GetByLink(endpoint string, args ...interface{}) (*ClusterMembership, error) {
-    resp, err := s.client.request("GET", endpoint, nil, args...)
+    return s.GetByLinkContext(context.Background(), endpoint, args...)
+}
+
+// GetByLinkContext is like GetByLink but uses ctx for the underlying request.
+func (s *ClusterMembershipsServiceOp) GetByLinkContext(ctx context.Context, endpoint string, args ...interface{}) (*ClusterMembership, error) {
+    ctx = withOperation(ctx, "ClusterMemberships", "GetByLink")
+    resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
     if err != nil {
         return nil, err
     }
@@ -122,7 +146,7 @@ func (s *ClusterMembershipsServiceOp) GetByLink(endpoint string, args ...interfa
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &ClusterMembershipResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -151,7 +175,7 @@ func (s *ClusterMembershipsServiceOp) get(r *ClusterMembershipResponse) (*Cluste
         }
         ten := &DeviceResponse{}
         json.Unmarshal(bytes, ten)
-        t, err := s.client.Devices.get(ten)
+        t, err := s.client.conv.devices.get(ten)
         if err != nil {
             return nil, err
         }
@@ -164,7 +188,7 @@ func (s *ClusterMembershipsServiceOp) get(r *ClusterMembershipResponse) (*Cluste
         }
         ten := &ClusterResponse{}
         json.Unmarshal(bytes, ten)
-        t, err := s.client.Clusters.get(ten)
+        t, err := s.client.conv.clusters.get(ten)
         if err != nil {
             return nil, err
         }
@@ -177,7 +201,7 @@ func (s *ClusterMembershipsServiceOp) get(r *ClusterMembershipResponse) (*Cluste
         }
         ten := &ApplicationResponse{}
         json.Unmarshal(bytes, ten)
-        t, err := s.client.Applications.get(ten)
+        t, err := s.client.conv.applications.get(ten)
         if err != nil {
             return nil, err
         }
@@ -211,19 +235,51 @@ func (s *ClusterMembershipsServiceOp) getCollection(r *ClusterMembershipsRespons
 
 // GetById retrieves collection of clusterMemberships of current tenant
 func (s *ClusterMembershipsServiceOp) ListByDevice(id string, args ...interface{}) ([]ClusterMembership, *ListParams, error) {
+    return s.ListByDeviceContext(context.Background(), id, args...)
+}
+
+// ListByDeviceContext is like ListByDevice but uses ctx for the underlying request.
+func (s *ClusterMembershipsServiceOp) ListByDeviceContext(ctx context.Context, id string, args ...interface{}) ([]ClusterMembership, *ListParams, error) {
+    ctx = withOperation(ctx, "ClusterMemberships", "ListByDevice")
+    endpoint := fmt.Sprintf("devices/%s/clusterMemberships", id)
+    return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// IterateByDevice is like ListByDevice but returns iterator walking over all pages
+func (s *ClusterMembershipsServiceOp) IterateByDevice(ctx context.Context, id string, args ...interface{}) *Iterator[ClusterMembership] {
+    ctx = withOperation(ctx, "ClusterMemberships", "IterateByDevice")
     endpoint := fmt.Sprintf("devices/%s/clusterMemberships", id)
-    return s.ListByLink(endpoint, args...)
+    return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of clusterMemberships of current tenant
 func (s *ClusterMembershipsServiceOp) ListByCluster(id string, args ...interface{}) ([]ClusterMembership, *ListParams, error) {
+    return s.ListByClusterContext(context.Background(), id, args...)
+}
+
+// ListByClusterContext is like ListByCluster but uses ctx for the underlying request.
+func (s *ClusterMembershipsServiceOp) ListByClusterContext(ctx context.Context, id string, args ...interface{}) ([]ClusterMembership, *ListParams, error) {
+    ctx = withOperation(ctx, "ClusterMemberships", "ListByCluster")
     endpoint := fmt.Sprintf("clusters/%s/memberships", id)
-    return s.ListByLink(endpoint, args...)
+    return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// IterateByCluster is like ListByCluster but returns iterator walking over all pages
+func (s *ClusterMembershipsServiceOp) IterateByCluster(ctx context.Context, id string, args ...interface{}) *Iterator[ClusterMembership] {
+    ctx = withOperation(ctx, "ClusterMemberships", "IterateByCluster")
+    endpoint := fmt.Sprintf("clusters/%s/memberships", id)
+    return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of clusterMemberships by link
 func (s *ClusterMembershipsServiceOp) ListByLink(endpoint string, args ...interface{}) ([]ClusterMembership, *ListParams, error) {
-    resp, err := s.client.request("GET", endpoint, nil, args...)
+    return s.ListByLinkContext(context.Background(), endpoint, args...)
+}
+
+// ListByLinkContext is like ListByLink but uses ctx for the underlying request.
+func (s *ClusterMembershipsServiceOp) ListByLinkContext(ctx context.Context, endpoint string, args ...interface{}) ([]ClusterMembership, *ListParams, error) {
+    ctx = withOperation(ctx, "ClusterMemberships", "ListByLink")
+    resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
     if err != nil {
         return nil, nil, err
     }
@@ -231,7 +287,7 @@ func (s *ClusterMembershipsServiceOp) ListByLink(endpoint string, args ...interf
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, nil, newApiError(resp, "non-ok status returned")
     }
     obj := &ClusterMembershipsResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -240,26 +296,49 @@ func (s *ClusterMembershipsServiceOp) ListByLink(endpoint string, args ...interf
     return s.getCollection(obj)
 }
 
+// IterateByLink is like ListByLink but returns iterator walking over all pages
+func (s *ClusterMembershipsServiceOp) IterateByLink(ctx context.Context, endpoint string, args ...interface{}) *Iterator[ClusterMembership] {
+    ctx = withOperation(ctx, "ClusterMemberships", "IterateByLink")
+    return newIterator(ctx, endpoint, s.ListByLinkContext, args...)
+}
+
 func (s *ClusterMembershipsServiceOp) CreateByDevice(id string, dir *ClusterMembershipRequestCreate) (*ClusterMembership, error) {
+    return s.CreateByDeviceContext(context.Background(), id, dir)
+}
+
+// CreateByDeviceContext is like CreateByDevice but uses ctx for the underlying request.
+func (s *ClusterMembershipsServiceOp) CreateByDeviceContext(ctx context.Context, id string, dir *ClusterMembershipRequestCreate) (*ClusterMembership, error) {
+    ctx = withOperation(ctx, "ClusterMemberships", "CreateByDevice")
     endpoint := fmt.Sprintf("devices/%s/clusterMemberships", id)
-    return s.CreateByLink(endpoint, dir)
+    return s.CreateByLinkContext(ctx, endpoint, dir)
 }
 
 func (s *ClusterMembershipsServiceOp) CreateByCluster(id string, dir *ClusterMembershipRequestCreate) (*ClusterMembership, error) {
+    return s.CreateByClusterContext(context.Background(), id, dir)
+}
+
+// CreateByClusterContext is like CreateByCluster but uses ctx for the underlying request.
+func (s *ClusterMembershipsServiceOp) CreateByClusterContext(ctx context.Context, id string, dir *ClusterMembershipRequestCreate) (*ClusterMembership, error) {
+    ctx = withOperation(ctx, "ClusterMemberships", "CreateByCluster")
     endpoint := fmt.Sprintf("clusters/%s/memberships", id)
-    return s.CreateByLink(endpoint, dir)
+    return s.CreateByLinkContext(ctx, endpoint, dir)
 }
 
 // Create creates new product within tenant
 func (s *ClusterMembershipsServiceOp) CreateByLink(endpoint string, dir *ClusterMembershipRequestCreate) (*ClusterMembership, error) {
+    return s.CreateByLinkContext(context.Background(), endpoint, dir)
+}
+
+// CreateByLinkContext is like CreateByLink but uses ctx for the underlying request.
+func (s *ClusterMembershipsServiceOp) CreateByLinkContext(ctx context.Context, endpoint string, dir *ClusterMembershipRequestCreate) (*ClusterMembership, error) {
+    ctx = withOperation(ctx, "ClusterMemberships", "CreateByLink")
     enc, err := json.Marshal(dir)
     if err != nil {
         return nil, err
     }
 
     buf := bytes.NewBuffer(enc)
-    fmt.Println(buf.String())
-    resp, err := s.client.request("POST", endpoint, buf)
+    resp, err := s.client.request(ctx, "POST", endpoint, buf)
     if err != nil {
         return nil, err
     }
@@ -267,7 +346,7 @@ func (s *ClusterMembershipsServiceOp) CreateByLink(endpoint string, dir *Cluster
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusCreated {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &ClusterMembershipResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -277,18 +356,36 @@ func (s *ClusterMembershipsServiceOp) CreateByLink(endpoint string, dir *Cluster
 
 // Delete removes product
 func (s *ClusterMembershipsServiceOp) Delete(t *ClusterMembership) (error) {
-    return s.DeleteByLink(t.Href)
+    return s.DeleteContext(context.Background(), t)
+}
+
+// DeleteContext is like Delete but uses ctx for the underlying request.
+func (s *ClusterMembershipsServiceOp) DeleteContext(ctx context.Context, t *ClusterMembership) (error) {
+    ctx = withOperation(ctx, "ClusterMemberships", "Delete")
+    return s.DeleteByLinkContext(ctx, t.Href)
 }
 
 // Delete removes product by ID
 func (s *ClusterMembershipsServiceOp) DeleteById(id string) (error) {
+    return s.DeleteByIdContext(context.Background(), id)
+}
+
+// DeleteByIdContext is like DeleteById but uses ctx for the underlying request.
+func (s *ClusterMembershipsServiceOp) DeleteByIdContext(ctx context.Context, id string) (error) {
+    ctx = withOperation(ctx, "ClusterMemberships", "DeleteById")
     endpoint := fmt.Sprintf("clusterMemberships/%s", id)
-    return s.DeleteByLink(endpoint)
+    return s.DeleteByLinkContext(ctx, endpoint)
 }
 
 // Delete removes product by link
 func (s *ClusterMembershipsServiceOp) DeleteByLink(endpoint string) (error) {
-    resp, err := s.client.request("DELETE", endpoint, nil)
+    return s.DeleteByLinkContext(context.Background(), endpoint)
+}
+
+// DeleteByLinkContext is like DeleteByLink but uses ctx for the underlying request.
+func (s *ClusterMembershipsServiceOp) DeleteByLinkContext(ctx context.Context, endpoint string) (error) {
+    ctx = withOperation(ctx, "ClusterMemberships", "DeleteByLink")
+    resp, err := s.client.request(ctx, "DELETE", endpoint, nil)
     if err != nil {
         return err
     }
@@ -296,7 +393,7 @@ func (s *ClusterMembershipsServiceOp) DeleteByLink(endpoint string) (error) {
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusNoContent {
-        return ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return newApiError(resp, "non-ok status returned")
     }
     return nil
 }
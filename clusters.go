@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -13,20 +14,32 @@ import (
 // https://tenant-name.cloudthing.io/api/v1/clusters
 type ClustersService interface {
 	GetById(string, ...interface{}) (*Cluster, error)
+	GetByIdContext(context.Context, string, ...interface{}) (*Cluster, error)
 	GetByLink(string, ...interface{}) (*Cluster, error)
+	GetByLinkContext(context.Context, string, ...interface{}) (*Cluster, error)
 	ListByLink(string, ...interface{}) ([]Cluster, *ListParams, error)
+	ListByLinkContext(context.Context, string, ...interface{}) ([]Cluster, *ListParams, error)
+	IterateByLink(context.Context, string, ...interface{}) *Iterator[Cluster]
 	ListByApplication(string, ...interface{}) ([]Cluster, *ListParams, error)
+	ListByApplicationContext(context.Context, string, ...interface{}) ([]Cluster, *ListParams, error)
+	IterateByApplication(context.Context, string, ...interface{}) *Iterator[Cluster]
 	ListByDevice(string, ...interface{}) ([]Cluster, *ListParams, error)
+	ListByDeviceContext(context.Context, string, ...interface{}) ([]Cluster, *ListParams, error)
+	IterateByDevice(context.Context, string, ...interface{}) *Iterator[Cluster]
 	CreateByLink(string, *ClusterRequestCreate) (*Cluster, error)
+	CreateByLinkContext(context.Context, string, *ClusterRequestCreate) (*Cluster, error)
 	CreateByApplication(string, *ClusterRequestCreate) (*Cluster, error)
+	CreateByApplicationContext(context.Context, string, *ClusterRequestCreate) (*Cluster, error)
 	UpdateById(string, *ClusterRequestUpdate) (*Cluster, error)
+	UpdateByIdContext(context.Context, string, *ClusterRequestUpdate) (*Cluster, error)
 	UpdateByLink(string, *ClusterRequestUpdate) (*Cluster, error)
+	UpdateByLinkContext(context.Context, string, *ClusterRequestUpdate) (*Cluster, error)
 	Delete(*Cluster) error
+	DeleteContext(context.Context, *Cluster) error
 	DeleteByLink(string) error
+	DeleteByLinkContext(context.Context, string) error
 	DeleteById(string) error
-
-	get(*ClusterResponse) (*Cluster, error)
-	getCollection(*ClustersResponse) ([]Cluster, *ListParams, error)
+	DeleteByIdContext(context.Context, string) error
 }
 
 // ClustersServiceOp handles communication with Clusters related methods of API
@@ -201,15 +214,27 @@ func (t *Cluster) Delete() error {
 
 // GetById retrieves apikey by its ID
 func (s *ClustersServiceOp) GetById(id string, args ...interface{}) (*Cluster, error) {
+	return s.GetByIdContext(context.Background(), id, args...)
+}
+
+// GetByIdContext is like GetById but uses ctx for the underlying request.
+func (s *ClustersServiceOp) GetByIdContext(ctx context.Context, id string, args ...interface{}) (*Cluster, error) {
+	ctx = withOperation(ctx, "Clusters", "GetById")
 	endpoint := "clusters/"
 	endpoint = fmt.Sprintf("%s%s", endpoint, id)
 
-	return s.GetByLink(endpoint, args...)
+	return s.GetByLinkContext(ctx, endpoint, args...)
 }
 
 // GetById retrieves apikey by its full link
 func (s *ClustersServiceOp) GetByLink(endpoint string, args ...interface{}) (*Cluster, error) {
-	resp, err := s.client.request("GET", endpoint, nil, args...)
+	return s.GetByLinkContext(context.Background(), endpoint, args...)
+}
+
+// GetByLinkContext is like GetByLink but uses ctx for the underlying request.
+func (s *ClustersServiceOp) GetByLinkContext(ctx context.Context, endpoint string, args ...interface{}) (*Cluster, error) {
+	ctx = withOperation(ctx, "Clusters", "GetByLink")
+	resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -217,7 +242,7 @@ func (s *ClustersServiceOp) GetByLink(endpoint string, args ...interface{}) (*Cl
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &ClusterResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -252,7 +277,7 @@ func (s *ClustersServiceOp) get(r *ClusterResponse) (*Cluster, error) {
 		}
 		ten := &TenantResponse{}
 		json.Unmarshal(bytes, ten)
-		t, err := s.client.Tenant.get(ten)
+		t, err := s.client.conv.tenant.get(ten)
 		if err != nil {
 			return nil, err
 		}
@@ -265,7 +290,7 @@ func (s *ClustersServiceOp) get(r *ClusterResponse) (*Cluster, error) {
 		}
 		ten := &ApplicationResponse{}
 		json.Unmarshal(bytes, ten)
-		t, err := s.client.Applications.get(ten)
+		t, err := s.client.conv.applications.get(ten)
 		if err != nil {
 			return nil, err
 		}
@@ -278,7 +303,7 @@ func (s *ClustersServiceOp) get(r *ClusterResponse) (*Cluster, error) {
 		}
 		ten := &DevicesResponse{}
 		json.Unmarshal(bytes, ten)
-		t, _, err := s.client.Devices.getCollection(ten)
+		t, _, err := s.client.conv.devices.getCollection(ten)
 		if err != nil {
 			return nil, err
 		}
@@ -291,7 +316,7 @@ func (s *ClustersServiceOp) get(r *ClusterResponse) (*Cluster, error) {
 		}
 		ten := &GroupsResponse{}
 		json.Unmarshal(bytes, ten)
-		t, _, err := s.client.Groups.getCollection(ten)
+		t, _, err := s.client.conv.groups.getCollection(ten)
 		if err != nil {
 			return nil, err
 		}
@@ -304,7 +329,7 @@ func (s *ClustersServiceOp) get(r *ClusterResponse) (*Cluster, error) {
 		}
 		ten := &ClusterMembershipsResponse{}
 		json.Unmarshal(bytes, ten)
-		t, _, err := s.client.ClusterMemberships.getCollection(ten)
+		t, _, err := s.client.conv.clusterMemberships.getCollection(ten)
 		if err != nil {
 			return nil, err
 		}
@@ -338,19 +363,51 @@ func (s *ClustersServiceOp) getCollection(r *ClustersResponse) ([]Cluster, *List
 
 // GetById retrieves collection of clusters of current tenant
 func (s *ClustersServiceOp) ListByApplication(id string, args ...interface{}) ([]Cluster, *ListParams, error) {
+	return s.ListByApplicationContext(context.Background(), id, args...)
+}
+
+// ListByApplicationContext is like ListByApplication but uses ctx for the underlying request.
+func (s *ClustersServiceOp) ListByApplicationContext(ctx context.Context, id string, args ...interface{}) ([]Cluster, *ListParams, error) {
+	ctx = withOperation(ctx, "Clusters", "ListByApplication")
 	endpoint := fmt.Sprintf("applications/%s/clusters", id)
-	return s.ListByLink(endpoint, args...)
+	return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// IterateByApplication is like ListByApplication but returns iterator walking over all pages
+func (s *ClustersServiceOp) IterateByApplication(ctx context.Context, id string, args ...interface{}) *Iterator[Cluster] {
+	ctx = withOperation(ctx, "Clusters", "IterateByApplication")
+	endpoint := fmt.Sprintf("applications/%s/clusters", id)
+	return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of clusters of current tenant
 func (s *ClustersServiceOp) ListByDevice(id string, args ...interface{}) ([]Cluster, *ListParams, error) {
+	return s.ListByDeviceContext(context.Background(), id, args...)
+}
+
+// ListByDeviceContext is like ListByDevice but uses ctx for the underlying request.
+func (s *ClustersServiceOp) ListByDeviceContext(ctx context.Context, id string, args ...interface{}) ([]Cluster, *ListParams, error) {
+	ctx = withOperation(ctx, "Clusters", "ListByDevice")
+	endpoint := fmt.Sprintf("devices/%s/clusters", id)
+	return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// IterateByDevice is like ListByDevice but returns iterator walking over all pages
+func (s *ClustersServiceOp) IterateByDevice(ctx context.Context, id string, args ...interface{}) *Iterator[Cluster] {
+	ctx = withOperation(ctx, "Clusters", "IterateByDevice")
 	endpoint := fmt.Sprintf("devices/%s/clusters", id)
-	return s.ListByLink(endpoint, args...)
+	return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of clusters by link
 func (s *ClustersServiceOp) ListByLink(endpoint string, args ...interface{}) ([]Cluster, *ListParams, error) {
-	resp, err := s.client.request("GET", endpoint, nil, args...)
+	return s.ListByLinkContext(context.Background(), endpoint, args...)
+}
+
+// ListByLinkContext is like ListByLink but uses ctx for the underlying request.
+func (s *ClustersServiceOp) ListByLinkContext(ctx context.Context, endpoint string, args ...interface{}) ([]Cluster, *ListParams, error) {
+	ctx = withOperation(ctx, "Clusters", "ListByLink")
+	resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -358,7 +415,7 @@ func (s *ClustersServiceOp) ListByLink(endpoint string, args ...interface{}) ([]
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &ClustersResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -367,14 +424,32 @@ func (s *ClustersServiceOp) ListByLink(endpoint string, args ...interface{}) ([]
 	return s.getCollection(obj)
 }
 
+// IterateByLink is like ListByLink but returns iterator walking over all pages
+func (s *ClustersServiceOp) IterateByLink(ctx context.Context, endpoint string, args ...interface{}) *Iterator[Cluster] {
+	ctx = withOperation(ctx, "Clusters", "IterateByLink")
+	return newIterator(ctx, endpoint, s.ListByLinkContext, args...)
+}
+
 // GetById updates apikey with specified ID
 func (s *ClustersServiceOp) UpdateById(id string, t *ClusterRequestUpdate) (*Cluster, error) {
+	return s.UpdateByIdContext(context.Background(), id, t)
+}
+
+// UpdateByIdContext is like UpdateById but uses ctx for the underlying request.
+func (s *ClustersServiceOp) UpdateByIdContext(ctx context.Context, id string, t *ClusterRequestUpdate) (*Cluster, error) {
+	ctx = withOperation(ctx, "Clusters", "UpdateById")
 	endpoint := fmt.Sprintf("clusters/%s", id)
-	return s.UpdateByLink(endpoint, t)
+	return s.UpdateByLinkContext(ctx, endpoint, t)
 }
 
 // GetById updates apikey specified by link
 func (s *ClustersServiceOp) UpdateByLink(endpoint string, t *ClusterRequestUpdate) (*Cluster, error) {
+	return s.UpdateByLinkContext(context.Background(), endpoint, t)
+}
+
+// UpdateByLinkContext is like UpdateByLink but uses ctx for the underlying request.
+func (s *ClustersServiceOp) UpdateByLinkContext(ctx context.Context, endpoint string, t *ClusterRequestUpdate) (*Cluster, error) {
+	ctx = withOperation(ctx, "Clusters", "UpdateByLink")
 	enc, err := json.Marshal(t)
 	if err != nil {
 		return nil, err
@@ -382,7 +457,7 @@ func (s *ClustersServiceOp) UpdateByLink(endpoint string, t *ClusterRequestUpdat
 
 	buf := bytes.NewBuffer(enc)
 
-	resp, err := s.client.request("POST", endpoint, buf)
+	resp, err := s.client.request(ctx, "POST", endpoint, buf)
 	if err != nil {
 		return nil, err
 	}
@@ -390,7 +465,7 @@ func (s *ClustersServiceOp) UpdateByLink(endpoint string, t *ClusterRequestUpdat
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &ClusterResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -399,12 +474,24 @@ func (s *ClustersServiceOp) UpdateByLink(endpoint string, t *ClusterRequestUpdat
 }
 
 func (s *ClustersServiceOp) CreateByApplication(id string, dir *ClusterRequestCreate) (*Cluster, error) {
+	return s.CreateByApplicationContext(context.Background(), id, dir)
+}
+
+// CreateByApplicationContext is like CreateByApplication but uses ctx for the underlying request.
+func (s *ClustersServiceOp) CreateByApplicationContext(ctx context.Context, id string, dir *ClusterRequestCreate) (*Cluster, error) {
+	ctx = withOperation(ctx, "Clusters", "CreateByApplication")
 	endpoint := fmt.Sprintf("applications/%s/clusters", id)
-	return s.CreateByLink(endpoint, dir)
+	return s.CreateByLinkContext(ctx, endpoint, dir)
 }
 
 // Create creates new apikey within tenant
 func (s *ClustersServiceOp) CreateByLink(endpoint string, dir *ClusterRequestCreate) (*Cluster, error) {
+	return s.CreateByLinkContext(context.Background(), endpoint, dir)
+}
+
+// CreateByLinkContext is like CreateByLink but uses ctx for the underlying request.
+func (s *ClustersServiceOp) CreateByLinkContext(ctx context.Context, endpoint string, dir *ClusterRequestCreate) (*Cluster, error) {
+	ctx = withOperation(ctx, "Clusters", "CreateByLink")
 	enc, err := json.Marshal(dir)
 	if err != nil {
 		return nil, err
@@ -412,7 +499,7 @@ func (s *ClustersServiceOp) CreateByLink(endpoint string, dir *ClusterRequestCre
 
 	buf := bytes.NewBuffer(enc)
 
-	resp, err := s.client.request("POST", endpoint, buf)
+	resp, err := s.client.request(ctx, "POST", endpoint, buf)
 	if err != nil {
 		return nil, err
 	}
@@ -420,7 +507,7 @@ func (s *ClustersServiceOp) CreateByLink(endpoint string, dir *ClusterRequestCre
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &ClusterResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -430,18 +517,36 @@ func (s *ClustersServiceOp) CreateByLink(endpoint string, dir *ClusterRequestCre
 
 // Delete removes apikey
 func (s *ClustersServiceOp) Delete(t *Cluster) error {
-	return s.DeleteByLink(t.Href)
+	return s.DeleteContext(context.Background(), t)
+}
+
+// DeleteContext is like Delete but uses ctx for the underlying request.
+func (s *ClustersServiceOp) DeleteContext(ctx context.Context, t *Cluster) error {
+	ctx = withOperation(ctx, "Clusters", "Delete")
+	return s.DeleteByLinkContext(ctx, t.Href)
 }
 
 // Delete removes apikey by ID
 func (s *ClustersServiceOp) DeleteById(id string) error {
+	return s.DeleteByIdContext(context.Background(), id)
+}
+
+// DeleteByIdContext is like DeleteById but uses ctx for the underlying request.
+func (s *ClustersServiceOp) DeleteByIdContext(ctx context.Context, id string) error {
+	ctx = withOperation(ctx, "Clusters", "DeleteById")
 	endpoint := fmt.Sprintf("clusters/%s", id)
-	return s.DeleteByLink(endpoint)
+	return s.DeleteByLinkContext(ctx, endpoint)
 }
 
 // Delete removes apikey by link
 func (s *ClustersServiceOp) DeleteByLink(endpoint string) error {
-	resp, err := s.client.request("DELETE", endpoint, nil)
+	return s.DeleteByLinkContext(context.Background(), endpoint)
+}
+
+// DeleteByLinkContext is like DeleteByLink but uses ctx for the underlying request.
+func (s *ClustersServiceOp) DeleteByLinkContext(ctx context.Context, endpoint string) error {
+	ctx = withOperation(ctx, "Clusters", "DeleteByLink")
+	resp, err := s.client.request(ctx, "DELETE", endpoint, nil)
 	if err != nil {
 		return err
 	}
@@ -449,7 +554,7 @@ func (s *ClustersServiceOp) DeleteByLink(endpoint string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent {
-		return ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return newApiError(resp, "non-ok status returned")
 	}
 	return nil
 }
@@ -0,0 +1,298 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// ErrUnknownSerialization is returned for payloads whose serialization has no registered codec
+var ErrUnknownSerialization = errors.New("unknown payload serialization")
+
+// ErrNoPayload is returned by DecodePayload when command doesn't contain requested payload
+var ErrNoPayload = errors.New("command has no such payload")
+
+// Serializations of command payloads with built-in codecs
+const (
+	// Payload is any JSON value
+	SerializationJSON = "json"
+	// Payload is a string holding text as it is, bytes which aren't valid UTF-8
+	// are rejected as JSON can't carry them; base64 or hex suit binary payloads
+	SerializationRaw = "raw"
+	// Payload is a string holding bytes encoded with standard base64
+	SerializationBase64 = "base64"
+	// Payload is a string holding bytes encoded as hexadecimal
+	SerializationHex = "hex"
+	// Payload is a string holding CBOR document encoded with standard base64
+	SerializationCBOR = "cbor"
+	// Payload is a string holding MessagePack document encoded with standard base64
+	SerializationMessagePack = "msgpack"
+)
+
+// PayloadCodec converts Go values into payloads of commands and back.
+// Payload is a value placed into JSON of command, e.g. a string.
+type PayloadCodec interface {
+	Encode(v interface{}) (interface{}, error)
+	// Decode stores payload, as decoded from JSON, into v which has to be a pointer
+	Decode(payload interface{}, v interface{}) error
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]PayloadCodec{
+		SerializationJSON:        jsonCodec{},
+		SerializationRaw:         bytesCodec{},
+		SerializationBase64:      bytesCodec{encoding: base64.StdEncoding},
+		SerializationHex:         bytesCodec{encoding: hexEncoding{}},
+		SerializationCBOR:        binaryCodec{marshal: cbor.Marshal, unmarshal: cbor.Unmarshal},
+		SerializationMessagePack: binaryCodec{marshal: msgpack.Marshal, unmarshal: msgpack.Unmarshal},
+		"messagepack":            binaryCodec{marshal: msgpack.Marshal, unmarshal: msgpack.Unmarshal},
+	}
+)
+
+// RegisterPayloadCodec registers codec of serialization, replacing existing one.
+// Names of serializations are case insensitive.
+func RegisterPayloadCodec(serialization string, codec PayloadCodec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[strings.ToLower(serialization)] = codec
+}
+
+// PayloadCodecFor returns codec registered for serialization
+func PayloadCodecFor(serialization string) (PayloadCodec, error) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	codec, ok := codecs[strings.ToLower(serialization)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSerialization, serialization)
+	}
+	return codec, nil
+}
+
+// Command returns declared command with id
+func (r *ProductResources) Command(id string) (*ProductCommandResource, bool) {
+	for i := range r.Commands {
+		if r.Commands[i].Id == id {
+			return &r.Commands[i], true
+		}
+	}
+	return nil, false
+}
+
+// Payload returns declared payload with name
+func (c *ProductCommandResource) Payload(name string) (*ProductPayload, bool) {
+	for i := range c.Payloads {
+		if c.Payloads[i].Name == name {
+			return &c.Payloads[i], true
+		}
+	}
+	return nil, false
+}
+
+// EncodePayload encodes values of payloads, keyed by names, with their declared serializations
+func (c *ProductCommandResource) EncodePayload(values map[string]interface{}) (map[string]interface{}, error) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	payload := make(map[string]interface{}, len(values))
+	for _, name := range names {
+		p, ok := c.Payload(name)
+		if !ok {
+			return nil, fmt.Errorf("payload %q is not declared by command %q", name, c.Id)
+		}
+		codec, err := PayloadCodecFor(p.Serialization)
+		if err != nil {
+			return nil, fmt.Errorf("payload %q of command %q: %w", name, c.Id, err)
+		}
+		enc, err := codec.Encode(values[name])
+		if err != nil {
+			return nil, fmt.Errorf("encoding payload %q of command %q: %w", name, c.Id, err)
+		}
+		payload[name] = enc
+	}
+	return payload, nil
+}
+
+// NewCommandPoint creates command with payload encoded from values, see EncodePayload.
+// Zero t leaves time empty.
+func (c *ProductCommandResource) NewCommandPoint(values map[string]interface{}, t time.Time) (CommandPoint, error) {
+	payload, err := c.EncodePayload(values)
+	if err != nil {
+		return CommandPoint{}, err
+	}
+	return CommandPoint{Key: c.Id, Payload: payload, Time: FormatPointTime(t, PrecisionMillisecond)}, nil
+}
+
+// DecodePayload decodes payload with name of command into v, according to its declared
+// serialization. ErrNoPayload is returned if command doesn't contain the payload.
+func (c *ProductCommandResource) DecodePayload(command CommandPoint, name string, v interface{}) error {
+	p, ok := c.Payload(name)
+	if !ok {
+		return fmt.Errorf("payload %q is not declared by command %q", name, c.Id)
+	}
+	codec, err := PayloadCodecFor(p.Serialization)
+	if err != nil {
+		return fmt.Errorf("payload %q of command %q: %w", name, c.Id, err)
+	}
+	payload, err := payloadFields(command.Payload)
+	if err != nil {
+		return err
+	}
+	raw, ok := payload[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrNoPayload, name)
+	}
+	if err := codec.Decode(raw, v); err != nil {
+		return fmt.Errorf("decoding payload %q of command %q: %w", name, c.Id, err)
+	}
+	return nil
+}
+
+// payloadFields returns payloads of command keyed by names
+func payloadFields(payload interface{}) (map[string]interface{}, error) {
+	switch p := payload.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		return p, nil
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, fmt.Errorf("payload of command is not an object: %w", err)
+	}
+	return fields, nil
+}
+
+// jsonCodec places values into command as JSON
+type jsonCodec struct{}
+
+func (jsonCodec) Encode(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(b), nil
+}
+
+func (jsonCodec) Decode(payload interface{}, v interface{}) error {
+	b, ok := payload.(json.RawMessage)
+	if !ok {
+		var err error
+		if b, err = json.Marshal(payload); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(b, v)
+}
+
+// textEncoding converts bytes to text and back
+type textEncoding interface {
+	EncodeToString([]byte) string
+	DecodeString(string) ([]byte, error)
+}
+
+type hexEncoding struct{}
+
+func (hexEncoding) EncodeToString(b []byte) string {
+	return hex.EncodeToString(b)
+}
+
+func (hexEncoding) DecodeString(s string) ([]byte, error) {
+	return hex.DecodeString(s)
+}
+
+// bytesCodec places bytes or strings into command as string, encoded if encoding is set.
+// Without encoding only valid UTF-8 is accepted.
+type bytesCodec struct {
+	encoding textEncoding
+}
+
+func (c bytesCodec) Encode(v interface{}) (interface{}, error) {
+	var b []byte
+	switch t := v.(type) {
+	case []byte:
+		b = t
+	case string:
+		b = []byte(t)
+	case json.RawMessage:
+		b = t
+	default:
+		return nil, fmt.Errorf("%w: %T is not bytes or string", ErrValueType, v)
+	}
+	if c.encoding == nil {
+		if !utf8.Valid(b) {
+			return nil, fmt.Errorf("%w: raw payload is not valid UTF-8", ErrValueType)
+		}
+		return string(b), nil
+	}
+	return c.encoding.EncodeToString(b), nil
+}
+
+// Decode stores bytes into *[]byte, *string or *interface{}, which receives []byte
+func (c bytesCodec) Decode(payload interface{}, v interface{}) error {
+	b, err := c.bytes(payload)
+	if err != nil {
+		return err
+	}
+	switch t := v.(type) {
+	case *[]byte:
+		*t = b
+	case *string:
+		*t = string(b)
+	case *interface{}:
+		*t = b
+	default:
+		return fmt.Errorf("%w: can't decode bytes into %T", ErrValueType, v)
+	}
+	return nil
+}
+
+func (c bytesCodec) bytes(payload interface{}) ([]byte, error) {
+	s, ok := payload.(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: payload %T is not a string", ErrValueType, payload)
+	}
+	if c.encoding == nil {
+		return []byte(s), nil
+	}
+	return c.encoding.DecodeString(s)
+}
+
+// binaryCodec places binary documents into command as base64 strings
+type binaryCodec struct {
+	marshal   func(interface{}) ([]byte, error)
+	unmarshal func([]byte, interface{}) error
+}
+
+func (c binaryCodec) Encode(v interface{}) (interface{}, error) {
+	b, err := c.marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func (c binaryCodec) Decode(payload interface{}, v interface{}) error {
+	b, err := bytesCodec{encoding: base64.StdEncoding}.bytes(payload)
+	if err != nil {
+		return err
+	}
+	return c.unmarshal(b, v)
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRawPayloadRejectsInvalidUTF8(t *testing.T) {
+	codec, err := PayloadCodecFor(SerializationRaw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := codec.Encode([]byte{0xff, 0xfe, 0x00}); !errors.Is(err, ErrValueType) {
+		t.Errorf("Encode of invalid UTF-8 = %v, want ErrValueType", err)
+	}
+	enc, err := codec.Encode([]byte("zażółć"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b []byte
+	if err := codec.Decode(enc, &b); err != nil || string(b) != "zażółć" {
+		t.Errorf("Decode = %q, %v, want original text", b, err)
+	}
+}
+
+func TestBinaryPayloadsRoundTrip(t *testing.T) {
+	data := []byte{0xff, 0xfe, 0x00, 0x80}
+	for _, serialization := range []string{SerializationBase64, SerializationHex} {
+		codec, err := PayloadCodecFor(serialization)
+		if err != nil {
+			t.Fatal(err)
+		}
+		enc, err := codec.Encode(data)
+		if err != nil {
+			t.Fatalf("%s: %v", serialization, err)
+		}
+		var b []byte
+		if err := codec.Decode(enc, &b); err != nil || !bytes.Equal(b, data) {
+			t.Errorf("%s: Decode = %x, %v, want %x", serialization, b, err, data)
+		}
+	}
+}
+
+type lampState struct {
+	Level int
+	Mode  string
+	Tags  []string
+}
+
+// lampCommand declares payload of every structured serialization and of one without codec
+func lampCommand() *ProductCommandResource {
+	return &ProductCommandResource{
+		ProductSimpleResource: ProductSimpleResource{Id: "set"},
+		Payloads: []ProductPayload{
+			{Name: "json", Serialization: SerializationJSON},
+			{Name: "cbor", Serialization: SerializationCBOR},
+			{Name: "msgpack", Serialization: "MsgPack"},
+			{Name: "blob", Serialization: SerializationBase64},
+			{Name: "custom", Serialization: "upper-test"},
+		},
+	}
+}
+
+func TestStructuredPayloadsRoundTrip(t *testing.T) {
+	state := lampState{Level: 2, Mode: "eco", Tags: []string{"a", "b"}}
+	command := lampCommand()
+	at := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	point, err := command.NewCommandPoint(map[string]interface{}{
+		"json": state, "cbor": state, "msgpack": state, "blob": []byte{0xff, 0x00},
+	}, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if point.Key != "set" || point.Time != "2024-03-01T10:00:00.000Z" {
+		t.Errorf("command = %+v, want key set at %s", point, at)
+	}
+
+	// command is sent to and returned by API as JSON
+	b, err := json.Marshal(point)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var received CommandPoint
+	if err := json.Unmarshal(b, &received); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []CommandPoint{point, received} {
+		for _, name := range []string{"json", "cbor", "msgpack"} {
+			var s lampState
+			if err := command.DecodePayload(p, name, &s); err != nil || s.Level != 2 || s.Mode != "eco" || len(s.Tags) != 2 {
+				t.Errorf("%s: DecodePayload = %+v, %v, want %+v", name, s, err, state)
+			}
+		}
+		var blob []byte
+		if err := command.DecodePayload(p, "blob", &blob); err != nil || !bytes.Equal(blob, []byte{0xff, 0x00}) {
+			t.Errorf("blob: DecodePayload = %x, %v", blob, err)
+		}
+	}
+	fields := received.Payload.(map[string]interface{})
+	if _, ok := fields["cbor"].(string); !ok {
+		t.Errorf("cbor payload sent as %T, want base64 string", fields["cbor"])
+	}
+}
+
+func TestCommandPayloadErrors(t *testing.T) {
+	command := lampCommand()
+	if _, err := command.EncodePayload(map[string]interface{}{"custom": "x"}); !errors.Is(err, ErrUnknownSerialization) {
+		t.Errorf("EncodePayload without codec = %v, want ErrUnknownSerialization", err)
+	}
+	if _, err := command.NewCommandPoint(map[string]interface{}{"color": "red"}, time.Time{}); err == nil {
+		t.Error("NewCommandPoint with undeclared payload succeeded")
+	}
+	point, err := command.NewCommandPoint(map[string]interface{}{"json": 1}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if point.Time != "" {
+		t.Errorf("time of command = %q, want empty", point.Time)
+	}
+	var v interface{}
+	if err := command.DecodePayload(point, "cbor", &v); !errors.Is(err, ErrNoPayload) {
+		t.Errorf("DecodePayload of missing payload = %v, want ErrNoPayload", err)
+	}
+	if err := command.DecodePayload(CommandPoint{Key: "set"}, "json", &v); !errors.Is(err, ErrNoPayload) {
+		t.Errorf("DecodePayload of command without payload = %v, want ErrNoPayload", err)
+	}
+	if err := command.DecodePayload(point, "custom", &v); !errors.Is(err, ErrUnknownSerialization) {
+		t.Errorf("DecodePayload without codec = %v, want ErrUnknownSerialization", err)
+	}
+	if err := command.DecodePayload(point, "color", &v); err == nil {
+		t.Error("DecodePayload of undeclared payload succeeded")
+	}
+	if err := command.DecodePayload(CommandPoint{Payload: map[string]interface{}{"blob": "!!!"}}, "blob", &v); err == nil {
+		t.Error("DecodePayload of invalid base64 succeeded")
+	}
+}
+
+// upperCodec places strings into commands in upper case
+type upperCodec struct{}
+
+func (upperCodec) Encode(v interface{}) (interface{}, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, ErrValueType
+	}
+	return strings.ToUpper(s), nil
+}
+
+func (upperCodec) Decode(payload interface{}, v interface{}) error {
+	s, ok := payload.(string)
+	p, isString := v.(*string)
+	if !ok || !isString {
+		return ErrValueType
+	}
+	*p = strings.ToLower(s)
+	return nil
+}
+
+func TestRegisterPayloadCodec(t *testing.T) {
+	RegisterPayloadCodec("Upper-Test", upperCodec{})
+	t.Cleanup(func() {
+		codecsMu.Lock()
+		delete(codecs, "upper-test")
+		codecsMu.Unlock()
+	})
+	for _, name := range []string{"upper-test", "UPPER-TEST"} {
+		if _, err := PayloadCodecFor(name); err != nil {
+			t.Errorf("PayloadCodecFor(%s) = %v", name, err)
+		}
+	}
+	if _, err := PayloadCodecFor("JSON"); err != nil {
+		t.Errorf("PayloadCodecFor(JSON) = %v", err)
+	}
+	if _, err := PayloadCodecFor("protobuf"); !errors.Is(err, ErrUnknownSerialization) {
+		t.Errorf("PayloadCodecFor(protobuf) = %v, want ErrUnknownSerialization", err)
+	}
+
+	command := lampCommand()
+	point, err := command.NewCommandPoint(map[string]interface{}{"custom": "on"}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields := point.Payload.(map[string]interface{}); fields["custom"] != "ON" {
+		t.Errorf("payload = %v, want encoded by registered codec", fields)
+	}
+	var s string
+	if err := command.DecodePayload(point, "custom", &s); err != nil || s != "on" {
+		t.Errorf("DecodePayload = %q, %v, want on", s, err)
+	}
+}
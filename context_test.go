@@ -0,0 +1,71 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+)
+
+// arrived waits until n requests with method to path were received
+func arrived(t *testing.T, srv *apitest.Server, method, path string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for countRequests(srv, method, path) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("%s %s wasn't received", method, path)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestContextCancelsRequest(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := srv.Add("devices", map[string]interface{}{}, nil)
+	release := srv.Block("GET", "devices/"+id)
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Devices.GetByIdContext(ctx, id)
+		done <- err
+	}()
+	arrived(t, srv, "GET", "devices/"+id, 1)
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want context canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request in flight wasn't aborted")
+	}
+	// aborted request isn't retried
+	release()
+	if n := countRequests(srv, "GET", "devices/"+id); n != 1 {
+		t.Errorf("device requested %d times, want once", n)
+	}
+
+	// deadline aborts writes as well
+	release = srv.Block("POST", "tenants/"+srv.TenantId()+"/products")
+	defer release()
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = client.Products.CreateContext(ctx, &api.ProductRequestCreate{Name: "Lamp"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want deadline exceeded", err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("write was aborted after %s", d)
+	}
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// Tokens are refreshed when they are about to expire in less than tokenRefreshLeeway
+const tokenRefreshLeeway = 30 * time.Second
+
+// Credentials is a source of JWT tokens. When set on Client it's used to obtain
+// new token before the stored one expires and after API rejects it with 401.
+// Token is called while requests of Client wait for the new token, so it must not
+// use methods relying on stored token (e.g. services), GetAuthToken is fine.
+type Credentials interface {
+	Token(context.Context, *Client) (*Token, error)
+}
+
+// BasicCredentials obtains tokens using username and password of CloudThing user
+type BasicCredentials struct {
+	Username string
+	Password string
+	// Optional application ID the token is scoped to
+	Application string
+}
+
+// Token retrieves new JWT token with basic authorization params
+func (b *BasicCredentials) Token(ctx context.Context, c *Client) (*Token, error) {
+	return c.GetAuthTokenContext(ctx, b.Username, b.Password, b.Application)
+}
+
+// ApikeyCredentials obtains tokens by exchanging key and secret of an Apikey
+type ApikeyCredentials struct {
+	Key    string
+	Secret string
+	// Optional application ID the token is scoped to
+	Application string
+}
+
+// Token retrieves new JWT token for apikey
+func (a *ApikeyCredentials) Token(ctx context.Context, c *Client) (*Token, error) {
+	return c.GetAuthTokenContext(ctx, a.Key, a.Secret, a.Application)
+}
+
+// CredentialsFunc allows to use ordinary function as Credentials
+type CredentialsFunc func(context.Context, *Client) (*Token, error)
+
+// Token calls f(ctx, c)
+func (f CredentialsFunc) Token(ctx context.Context, c *Client) (*Token, error) {
+	return f(ctx, c)
+}
+
+// SetCredentials sets source of tokens for Client, retrieves the first token
+// and stores it. Stored token is then refreshed automatically.
+func (c *Client) SetCredentials(creds Credentials) error {
+	return c.SetCredentialsContext(context.Background(), creds)
+}
+
+// SetCredentialsContext is like SetCredentials but uses ctx for the token request.
+func (c *Client) SetCredentialsContext(ctx context.Context, creds Credentials) error {
+	token, err := creds.Token(ctx, c)
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return fmt.Errorf("Credentials returned no token")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.setToken(token); err != nil {
+		return err
+	}
+	c.credentials = creds
+	return nil
+}
+
+// SetApikeyAuth authenticates against CloudThing API with apikey's key and secret
+// and stores retrieved JWT token for future requests. If application is not empty
+// token is scoped to that application. Token is refreshed once it expires.
+func (c *Client) SetApikeyAuth(key, secret, application string) error {
+	return c.SetApikeyAuthContext(context.Background(), key, secret, application)
+}
+
+// SetApikeyAuthContext is like SetApikeyAuth but uses ctx for the token request.
+func (c *Client) SetApikeyAuthContext(ctx context.Context, key, secret, application string) error {
+	return c.SetCredentialsContext(ctx, &ApikeyCredentials{Key: key, Secret: secret, Application: application})
+}
+
+// currentToken returns token which may be used for next request. If stored token
+// is missing or about to expire and credentials are set, new token is retrieved.
+func (c *Client) currentToken(ctx context.Context) (*Token, error) {
+	c.mu.Lock()
+	if exp, ok := tokenExpiry(c.token); ok && time.Now().Add(tokenRefreshLeeway).Before(exp) {
+		defer c.mu.Unlock()
+		return c.token, nil
+	}
+	if c.credentials == nil {
+		defer c.mu.Unlock()
+		if exp, ok := tokenExpiry(c.token); ok && time.Now().Before(exp) {
+			return c.token, nil
+		}
+		return nil, fmt.Errorf("You need to authenticate first")
+	}
+	r := c.startRefresh(ctx)
+	c.mu.Unlock()
+	return c.waitRefresh(ctx, r)
+}
+
+// refreshToken retrieves new token after stale one was rejected by API.
+// If other goroutine has already replaced stale token, its token is returned.
+func (c *Client) refreshToken(ctx context.Context, stale *Token) (*Token, error) {
+	c.mu.Lock()
+	if c.token != stale {
+		defer c.mu.Unlock()
+		return c.token, nil
+	}
+	r := c.startRefresh(ctx)
+	c.mu.Unlock()
+	return c.waitRefresh(ctx, r)
+}
+
+// tokenRefresh is retrieval of token shared by all goroutines which need it
+type tokenRefresh struct {
+	// closed when token and err are set
+	done  chan struct{}
+	token *Token
+	err   error
+
+	// number of goroutines waiting for the token, guarded by Client.mu. Retrieval
+	// is cancelled when all of them give up.
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// startRefresh joins retrieval of token in flight or starts a new one, which is not
+// bound to ctx but to goroutines waiting for it. c.mu must be held.
+func (c *Client) startRefresh(ctx context.Context) *tokenRefresh {
+	if r := c.refresh; r != nil && r.waiters > 0 {
+		r.waiters++
+		return r
+	}
+	fetchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	r := &tokenRefresh{done: make(chan struct{}), waiters: 1, cancel: cancel}
+	c.refresh = r
+	creds := c.credentials
+	go func() {
+		defer cancel()
+		token, err := creds.Token(fetchCtx, c)
+		if err == nil && token == nil {
+			err = fmt.Errorf("Credentials returned no token")
+		}
+		c.mu.Lock()
+		// abandoned retrieval may have been replaced by a newer one
+		if c.refresh == r {
+			c.refresh = nil
+			if err == nil {
+				err = c.setToken(token)
+			}
+		}
+		c.mu.Unlock()
+		if err != nil {
+			token = nil
+		}
+		r.token, r.err = token, err
+		close(r.done)
+	}()
+	return r
+}
+
+// waitRefresh waits for token retrieved by r until ctx is done
+func (c *Client) waitRefresh(ctx context.Context, r *tokenRefresh) (*Token, error) {
+	select {
+	case <-r.done:
+		return r.token, r.err
+	case <-ctx.Done():
+		c.mu.Lock()
+		r.waiters--
+		if r.waiters == 0 {
+			r.cancel()
+		}
+		c.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// canRefresh checks whether Client is able to obtain new tokens on its own
+func (c *Client) canRefresh() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.credentials != nil
+}
+
+// tokenExpiry extracts expiration time from 'exp' claim of JWT token
+func tokenExpiry(token *Token) (time.Time, bool) {
+	if token == nil {
+		return time.Time{}, false
+	}
+	t, _ := jwt.Parse(token.Token, nil)
+	if t == nil {
+		return time.Time{}, false
+	}
+	claims, ok := t.Claims.(jwt.MapClaims)
+	if !ok {
+		return time.Time{}, false
+	}
+	switch num := claims["exp"].(type) {
+	case json.Number:
+		if exp, err := num.Int64(); err == nil {
+			return time.Unix(exp, 0), true
+		}
+	case float64:
+		return time.Unix(int64(num), 0), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,295 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+)
+
+func TestCredentialsRefreshExpiringToken(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	// tokens expiring within refresh leeway are replaced before every request
+	srv.TokenTTL = 10 * time.Second
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := srv.Add("devices", map[string]interface{}{}, nil)
+	if _, err := client.Devices.GetById(id); err != nil {
+		t.Fatal(err)
+	}
+	first := client.GetToken()
+	if _, err := client.Devices.GetById(id); err != nil {
+		t.Fatal(err)
+	}
+	if client.GetToken() == first {
+		t.Error("token about to expire was not refreshed")
+	}
+
+	var issued int
+	for _, r := range srv.Requests() {
+		if r.Method == "POST" && r.Path == "auth/token" {
+			issued++
+		}
+	}
+	if issued != 2 {
+		t.Errorf("%d tokens issued, want 2", issued)
+	}
+}
+
+func TestCredentialsRefreshRejectedToken(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	var calls int32
+	creds := api.CredentialsFunc(func(ctx context.Context, c *api.Client) (*api.Token, error) {
+		atomic.AddInt32(&calls, 1)
+		return srv.IssueToken(time.Hour), nil
+	})
+	client, err := srv.NewClient(api.WithCredentials(creds))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := srv.Add("devices", map[string]interface{}{}, nil)
+	if _, err := client.Devices.GetById(id); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.RevokeToken(); err != nil {
+		t.Fatal(err)
+	}
+	before := atomic.LoadInt32(&calls)
+
+	// concurrent requests rejected with the same token share one refresh
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Devices.GetById(id); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := atomic.LoadInt32(&calls) - before; n != 1 {
+		t.Errorf("credentials called %d times after rejection, want 1", n)
+	}
+}
+
+func TestTokenWithoutCredentials(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, err := api.NewClientWithOptions(api.WithBaseURL(srv.URL), api.WithHTTPClient(srv.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SetTokenAuth(srv.IssueToken(-time.Minute)); err == nil {
+		t.Error("expired token was accepted")
+	}
+
+	// token can't be refreshed, so it is used until it expires
+	if err := client.SetTokenAuth(srv.IssueToken(10 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Devices.GetById(srv.Add("devices", map[string]interface{}{}, nil)); err != nil {
+		t.Errorf("request with token about to expire: %v", err)
+	}
+}
+
+func TestCredentialsWaitersHonorContext(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	var calls int32
+	release := make(chan struct{})
+	creds := api.CredentialsFunc(func(ctx context.Context, c *api.Client) (*api.Token, error) {
+		atomic.AddInt32(&calls, 1)
+		select {
+		case <-release:
+			return srv.IssueToken(time.Hour), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+	client, err := srv.NewClient(api.WithCredentials(creds))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := srv.Add("devices", map[string]interface{}{}, nil)
+
+	patient := make(chan error)
+	go func() {
+		_, err := client.Devices.GetById(id)
+		patient <- err
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.Devices.GetByIdContext(ctx, id); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("request waiting for token = %v, want deadline exceeded", err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("request gave up waiting after %s", d)
+	}
+	// stored token is available while new one is retrieved
+	done := make(chan struct{})
+	go func() {
+		client.GetToken()
+		client.IsAuthenticated()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("GetToken blocked by retrieval of token")
+	}
+
+	close(release)
+	if err := <-patient; err != nil {
+		t.Errorf("request waiting for token = %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("credentials called %d times, want once", n)
+	}
+}
+
+func TestCredentialsAbandonedRefresh(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	var calls int32
+	cancelled := make(chan struct{}, 1)
+	creds := api.CredentialsFunc(func(ctx context.Context, c *api.Client) (*api.Token, error) {
+		if atomic.AddInt32(&calls, 1) > 1 {
+			return srv.IssueToken(time.Hour), nil
+		}
+		<-ctx.Done()
+		cancelled <- struct{}{}
+		return nil, ctx.Err()
+	})
+	client, err := srv.NewClient(api.WithCredentials(creds))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := srv.Add("devices", map[string]interface{}{}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.Devices.GetByIdContext(ctx, id); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("request = %v, want deadline exceeded", err)
+	}
+	// retrieval nobody waits for is cancelled, the next request starts a new one
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("abandoned retrieval of token wasn't cancelled")
+	}
+	if _, err := client.Devices.GetById(id); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCredentialsMalformedToken(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	noIssuer, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, token := range []string{"not a token", noIssuer} {
+		creds := api.CredentialsFunc(func(ctx context.Context, c *api.Client) (*api.Token, error) {
+			return &api.Token{Token: token}, nil
+		})
+		client, err := srv.NewClient(api.WithCredentials(creds))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.Devices.GetById("1"); err == nil {
+			t.Errorf("request with token %q succeeded", token)
+		}
+		if err := client.SetCredentials(creds); err == nil {
+			t.Errorf("SetCredentials with token %q succeeded", token)
+		}
+		if client.GetToken() != nil {
+			t.Errorf("token %q was stored", token)
+		}
+	}
+}
+
+func TestApikeyCredentials(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	// tokens expiring within refresh leeway are replaced before every request
+	srv.TokenTTL = 10 * time.Second
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := srv.AddApikey("key-1", "apikey-secret")
+	device := srv.Add("devices", map[string]interface{}{}, nil)
+
+	if err := client.SetApikeyAuth("key-1", "apikey-secret", srv.ApplicationId()); err != nil {
+		t.Fatal(err)
+	}
+	claims := jwt.MapClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(client.GetToken().Token, claims); err != nil {
+		t.Fatal(err)
+	}
+	if claims["sub"] != srv.URL+"/api/v1/apikeys/"+key || claims["application"] != srv.URL+"/api/v1/applications/"+srv.ApplicationId() {
+		t.Errorf("token issued for %v scoped to %v, want apikey and default application", claims["sub"], claims["application"])
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Devices.GetById(device); err != nil {
+			t.Fatal(err)
+		}
+		reqs := srv.Requests()
+		if auth := reqs[len(reqs)-1].Header.Get("Authorization"); auth != "Bearer "+client.GetToken().Token {
+			t.Errorf("device requested with %q, want stored token", auth)
+		}
+	}
+	// the first token was retrieved by SetApikeyAuth and refreshed before both requests
+	var issued int
+	for _, r := range srv.Requests() {
+		if r.Method != "POST" || r.Path != "auth/token" {
+			continue
+		}
+		issued++
+		username, password, _ := (&http.Request{Header: r.Header}).BasicAuth()
+		if username != "key-1" || password != "apikey-secret" || r.Query.Get("application") != srv.ApplicationId() {
+			t.Errorf("token requested by %s:%s for application %q", username, password, r.Query.Get("application"))
+		}
+	}
+	if issued != 3 {
+		t.Errorf("%d tokens issued, want 3", issued)
+	}
+
+	// token of apikey without application isn't scoped
+	if err := client.SetCredentials(&api.ApikeyCredentials{Key: "key-1", Secret: "apikey-secret"}); err != nil {
+		t.Fatal(err)
+	}
+	claims = jwt.MapClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(client.GetToken().Token, claims); err != nil {
+		t.Fatal(err)
+	}
+	if app, ok := claims["application"]; ok {
+		t.Errorf("token scoped to %v", app)
+	}
+
+	if err := client.SetApikeyAuth("key-1", "wrong-secret", ""); !errors.Is(err, api.ErrUnauthorized) {
+		t.Errorf("wrong secret = %v, want ErrUnauthorized", err)
+	}
+	if err := client.SetApikeyAuth("key-1", "apikey-secret", "missing"); err == nil {
+		t.Error("token scoped to missing application")
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -13,22 +14,38 @@ import (
 // https://tenant-name.cloudthing.io/api/v1/devices
 type DevicesService interface {
 	GetById(string, ...interface{}) (*Device, error)
+	GetByIdContext(context.Context, string, ...interface{}) (*Device, error)
 	GetByLink(string, ...interface{}) (*Device, error)
+	GetByLinkContext(context.Context, string, ...interface{}) (*Device, error)
 	ListByLink(string, ...interface{}) ([]Device, *ListParams, error)
+	ListByLinkContext(context.Context, string, ...interface{}) ([]Device, *ListParams, error)
+	IterateByLink(context.Context, string, ...interface{}) *Iterator[Device]
 	ListByCluster(string, ...interface{}) ([]Device, *ListParams, error)
+	ListByClusterContext(context.Context, string, ...interface{}) ([]Device, *ListParams, error)
+	IterateByCluster(context.Context, string, ...interface{}) *Iterator[Device]
 	ListByApplication(string, ...interface{}) ([]Device, *ListParams, error)
+	ListByApplicationContext(context.Context, string, ...interface{}) ([]Device, *ListParams, error)
+	IterateByApplication(context.Context, string, ...interface{}) *Iterator[Device]
 	ListByGroup(string, ...interface{}) ([]Device, *ListParams, error)
+	ListByGroupContext(context.Context, string, ...interface{}) ([]Device, *ListParams, error)
+	IterateByGroup(context.Context, string, ...interface{}) *Iterator[Device]
 	ListByProduct(string, ...interface{}) ([]Device, *ListParams, error)
+	ListByProductContext(context.Context, string, ...interface{}) ([]Device, *ListParams, error)
+	IterateByProduct(context.Context, string, ...interface{}) *Iterator[Device]
 	CreateByLink(string, *DeviceRequestCreate) (*Device, error)
+	CreateByLinkContext(context.Context, string, *DeviceRequestCreate) (*Device, error)
 	CreateByProduct(string, *DeviceRequestCreate) (*Device, error)
+	CreateByProductContext(context.Context, string, *DeviceRequestCreate) (*Device, error)
 	UpdateById(string, *DeviceRequestUpdate) (*Device, error)
+	UpdateByIdContext(context.Context, string, *DeviceRequestUpdate) (*Device, error)
 	UpdateByLink(string, *DeviceRequestUpdate) (*Device, error)
+	UpdateByLinkContext(context.Context, string, *DeviceRequestUpdate) (*Device, error)
 	Delete(*Device) error
+	DeleteContext(context.Context, *Device) error
 	DeleteByLink(string) error
+	DeleteByLinkContext(context.Context, string) error
 	DeleteById(string) error
-
-	get(*DeviceResponse) (*Device, error)
-	getCollection(*DevicesResponse) ([]Device, *ListParams, error)
+	DeleteByIdContext(context.Context, string) error
 }
 
 // DevicesServiceOp handles communication with Devices related methods of API
@@ -218,15 +235,27 @@ func (t *Device) Delete() error {
 
 // GetById retrieves apikey by its ID
 func (s *DevicesServiceOp) GetById(id string, args ...interface{}) (*Device, error) {
+	return s.GetByIdContext(context.Background(), id, args...)
+}
+
+// GetByIdContext is like GetById but uses ctx for the underlying request.
+func (s *DevicesServiceOp) GetByIdContext(ctx context.Context, id string, args ...interface{}) (*Device, error) {
+	ctx = withOperation(ctx, "Devices", "GetById")
 	endpoint := "devices/"
 	endpoint = fmt.Sprintf("%s%s", endpoint, id)
 
-	return s.GetByLink(endpoint, args...)
+	return s.GetByLinkContext(ctx, endpoint, args...)
 }
 
 // GetById retrieves apikey by its full link
 func (s *DevicesServiceOp) GetByLink(endpoint string, args ...interface{}) (*Device, error) {
-	resp, err := s.client.request("GET", endpoint, nil, args...)
+	return s.GetByLinkContext(context.Background(), endpoint, args...)
+}
+
+// GetByLinkContext is like GetByLink but uses ctx for the underlying request.
+func (s *DevicesServiceOp) GetByLinkContext(ctx context.Context, endpoint string, args ...interface{}) (*Device, error) {
+	ctx = withOperation(ctx, "Devices", "GetByLink")
+	resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -234,7 +263,7 @@ func (s *DevicesServiceOp) GetByLink(endpoint string, args ...interface{}) (*Dev
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &DeviceResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -272,7 +301,7 @@ func (s *DevicesServiceOp) get(r *DeviceResponse) (*Device, error) {
 		}
 		ten := &TenantResponse{}
 		json.Unmarshal(bytes, ten)
-		t, err := s.client.Tenant.get(ten)
+		t, err := s.client.conv.tenant.get(ten)
 		if err != nil {
 			return nil, err
 		}
@@ -285,7 +314,7 @@ func (s *DevicesServiceOp) get(r *DeviceResponse) (*Device, error) {
 		}
 		ten := &ProductResponse{}
 		json.Unmarshal(bytes, ten)
-		t, err := s.client.Products.get(ten)
+		t, err := s.client.conv.products.get(ten)
 		if err != nil {
 			return nil, err
 		}
@@ -298,7 +327,7 @@ func (s *DevicesServiceOp) get(r *DeviceResponse) (*Device, error) {
 		}
 		ten := &ClustersResponse{}
 		json.Unmarshal(bytes, ten)
-		t, _, err := s.client.Clusters.getCollection(ten)
+		t, _, err := s.client.conv.clusters.getCollection(ten)
 		if err != nil {
 			return nil, err
 		}
@@ -311,7 +340,7 @@ func (s *DevicesServiceOp) get(r *DeviceResponse) (*Device, error) {
 		}
 		ten := &GroupsResponse{}
 		json.Unmarshal(bytes, ten)
-		t, _, err := s.client.Groups.getCollection(ten)
+		t, _, err := s.client.conv.groups.getCollection(ten)
 		if err != nil {
 			return nil, err
 		}
@@ -324,7 +353,7 @@ func (s *DevicesServiceOp) get(r *DeviceResponse) (*Device, error) {
 		}
 		ten := &ClusterMembershipsResponse{}
 		json.Unmarshal(bytes, ten)
-		t, _, err := s.client.ClusterMemberships.getCollection(ten)
+		t, _, err := s.client.conv.clusterMemberships.getCollection(ten)
 		if err != nil {
 			return nil, err
 		}
@@ -337,7 +366,7 @@ func (s *DevicesServiceOp) get(r *DeviceResponse) (*Device, error) {
 		}
 		ten := &GroupMembershipsResponse{}
 		json.Unmarshal(bytes, ten)
-		t, _, err := s.client.GroupMemberships.getCollection(ten)
+		t, _, err := s.client.conv.groupMemberships.getCollection(ten)
 		if err != nil {
 			return nil, err
 		}
@@ -371,31 +400,89 @@ func (s *DevicesServiceOp) getCollection(r *DevicesResponse) ([]Device, *ListPar
 
 // GetById retrieves collection of devices of current tenant
 func (s *DevicesServiceOp) ListByCluster(id string, args ...interface{}) ([]Device, *ListParams, error) {
+	return s.ListByClusterContext(context.Background(), id, args...)
+}
+
+// ListByClusterContext is like ListByCluster but uses ctx for the underlying request.
+func (s *DevicesServiceOp) ListByClusterContext(ctx context.Context, id string, args ...interface{}) ([]Device, *ListParams, error) {
+	ctx = withOperation(ctx, "Devices", "ListByCluster")
 	endpoint := fmt.Sprintf("clusters/%s/devices", id)
-	return s.ListByLink(endpoint, args...)
+	return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// IterateByCluster is like ListByCluster but returns iterator walking over all pages
+func (s *DevicesServiceOp) IterateByCluster(ctx context.Context, id string, args ...interface{}) *Iterator[Device] {
+	ctx = withOperation(ctx, "Devices", "IterateByCluster")
+	endpoint := fmt.Sprintf("clusters/%s/devices", id)
+	return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of devices of current tenant
 func (s *DevicesServiceOp) ListByApplication(id string, args ...interface{}) ([]Device, *ListParams, error) {
+	return s.ListByApplicationContext(context.Background(), id, args...)
+}
+
+// ListByApplicationContext is like ListByApplication but uses ctx for the underlying request.
+func (s *DevicesServiceOp) ListByApplicationContext(ctx context.Context, id string, args ...interface{}) ([]Device, *ListParams, error) {
+	ctx = withOperation(ctx, "Devices", "ListByApplication")
+	endpoint := fmt.Sprintf("applications/%s/devices", id)
+	return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// IterateByApplication is like ListByApplication but returns iterator walking over all pages
+func (s *DevicesServiceOp) IterateByApplication(ctx context.Context, id string, args ...interface{}) *Iterator[Device] {
+	ctx = withOperation(ctx, "Devices", "IterateByApplication")
 	endpoint := fmt.Sprintf("applications/%s/devices", id)
-	return s.ListByLink(endpoint, args...)
+	return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of devices of current tenant
 func (s *DevicesServiceOp) ListByGroup(id string, args ...interface{}) ([]Device, *ListParams, error) {
+	return s.ListByGroupContext(context.Background(), id, args...)
+}
+
+// ListByGroupContext is like ListByGroup but uses ctx for the underlying request.
+func (s *DevicesServiceOp) ListByGroupContext(ctx context.Context, id string, args ...interface{}) ([]Device, *ListParams, error) {
+	ctx = withOperation(ctx, "Devices", "ListByGroup")
 	endpoint := fmt.Sprintf("groups/%s/devices", id)
-	return s.ListByLink(endpoint, args...)
+	return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// IterateByGroup is like ListByGroup but returns iterator walking over all pages
+func (s *DevicesServiceOp) IterateByGroup(ctx context.Context, id string, args ...interface{}) *Iterator[Device] {
+	ctx = withOperation(ctx, "Devices", "IterateByGroup")
+	endpoint := fmt.Sprintf("groups/%s/devices", id)
+	return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of devices of current tenant
 func (s *DevicesServiceOp) ListByProduct(id string, args ...interface{}) ([]Device, *ListParams, error) {
+	return s.ListByProductContext(context.Background(), id, args...)
+}
+
+// ListByProductContext is like ListByProduct but uses ctx for the underlying request.
+func (s *DevicesServiceOp) ListByProductContext(ctx context.Context, id string, args ...interface{}) ([]Device, *ListParams, error) {
+	ctx = withOperation(ctx, "Devices", "ListByProduct")
 	endpoint := fmt.Sprintf("products/%s/devices", id)
-	return s.ListByLink(endpoint, args...)
+	return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// IterateByProduct is like ListByProduct but returns iterator walking over all pages
+func (s *DevicesServiceOp) IterateByProduct(ctx context.Context, id string, args ...interface{}) *Iterator[Device] {
+	ctx = withOperation(ctx, "Devices", "IterateByProduct")
+	endpoint := fmt.Sprintf("products/%s/devices", id)
+	return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of devices by link
 func (s *DevicesServiceOp) ListByLink(endpoint string, args ...interface{}) ([]Device, *ListParams, error) {
-	resp, err := s.client.request("GET", endpoint, nil, args...)
+	return s.ListByLinkContext(context.Background(), endpoint, args...)
+}
+
+// ListByLinkContext is like ListByLink but uses ctx for the underlying request.
+func (s *DevicesServiceOp) ListByLinkContext(ctx context.Context, endpoint string, args ...interface{}) ([]Device, *ListParams, error) {
+	ctx = withOperation(ctx, "Devices", "ListByLink")
+	resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -403,7 +490,7 @@ func (s *DevicesServiceOp) ListByLink(endpoint string, args ...interface{}) ([]D
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &DevicesResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -412,14 +499,32 @@ func (s *DevicesServiceOp) ListByLink(endpoint string, args ...interface{}) ([]D
 	return s.getCollection(obj)
 }
 
+// IterateByLink is like ListByLink but returns iterator walking over all pages
+func (s *DevicesServiceOp) IterateByLink(ctx context.Context, endpoint string, args ...interface{}) *Iterator[Device] {
+	ctx = withOperation(ctx, "Devices", "IterateByLink")
+	return newIterator(ctx, endpoint, s.ListByLinkContext, args...)
+}
+
 // GetById updates apikey with specified ID
 func (s *DevicesServiceOp) UpdateById(id string, t *DeviceRequestUpdate) (*Device, error) {
+	return s.UpdateByIdContext(context.Background(), id, t)
+}
+
+// UpdateByIdContext is like UpdateById but uses ctx for the underlying request.
+func (s *DevicesServiceOp) UpdateByIdContext(ctx context.Context, id string, t *DeviceRequestUpdate) (*Device, error) {
+	ctx = withOperation(ctx, "Devices", "UpdateById")
 	endpoint := fmt.Sprintf("devices/%s", id)
-	return s.UpdateByLink(endpoint, t)
+	return s.UpdateByLinkContext(ctx, endpoint, t)
 }
 
 // GetById updates apikey specified by link
 func (s *DevicesServiceOp) UpdateByLink(endpoint string, t *DeviceRequestUpdate) (*Device, error) {
+	return s.UpdateByLinkContext(context.Background(), endpoint, t)
+}
+
+// UpdateByLinkContext is like UpdateByLink but uses ctx for the underlying request.
+func (s *DevicesServiceOp) UpdateByLinkContext(ctx context.Context, endpoint string, t *DeviceRequestUpdate) (*Device, error) {
+	ctx = withOperation(ctx, "Devices", "UpdateByLink")
 	enc, err := json.Marshal(t)
 	if err != nil {
 		return nil, err
@@ -427,7 +532,7 @@ func (s *DevicesServiceOp) UpdateByLink(endpoint string, t *DeviceRequestUpdate)
 
 	buf := bytes.NewBuffer(enc)
 
-	resp, err := s.client.request("POST", endpoint, buf)
+	resp, err := s.client.request(ctx, "POST", endpoint, buf)
 	if err != nil {
 		return nil, err
 	}
@@ -435,7 +540,7 @@ func (s *DevicesServiceOp) UpdateByLink(endpoint string, t *DeviceRequestUpdate)
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &DeviceResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -444,12 +549,24 @@ func (s *DevicesServiceOp) UpdateByLink(endpoint string, t *DeviceRequestUpdate)
 }
 
 func (s *DevicesServiceOp) CreateByProduct(id string, dir *DeviceRequestCreate) (*Device, error) {
+	return s.CreateByProductContext(context.Background(), id, dir)
+}
+
+// CreateByProductContext is like CreateByProduct but uses ctx for the underlying request.
+func (s *DevicesServiceOp) CreateByProductContext(ctx context.Context, id string, dir *DeviceRequestCreate) (*Device, error) {
+	ctx = withOperation(ctx, "Devices", "CreateByProduct")
 	endpoint := fmt.Sprintf("products/%s/devices", id)
-	return s.CreateByLink(endpoint, dir)
+	return s.CreateByLinkContext(ctx, endpoint, dir)
 }
 
 // Create creates new apikey within tenant
 func (s *DevicesServiceOp) CreateByLink(endpoint string, dir *DeviceRequestCreate) (*Device, error) {
+	return s.CreateByLinkContext(context.Background(), endpoint, dir)
+}
+
+// CreateByLinkContext is like CreateByLink but uses ctx for the underlying request.
+func (s *DevicesServiceOp) CreateByLinkContext(ctx context.Context, endpoint string, dir *DeviceRequestCreate) (*Device, error) {
+	ctx = withOperation(ctx, "Devices", "CreateByLink")
 	enc, err := json.Marshal(dir)
 	if err != nil {
 		return nil, err
@@ -457,7 +574,7 @@ func (s *DevicesServiceOp) CreateByLink(endpoint string, dir *DeviceRequestCreat
 
 	buf := bytes.NewBuffer(enc)
 
-	resp, err := s.client.request("POST", endpoint, buf)
+	resp, err := s.client.request(ctx, "POST", endpoint, buf)
 	if err != nil {
 		return nil, err
 	}
@@ -465,7 +582,7 @@ func (s *DevicesServiceOp) CreateByLink(endpoint string, dir *DeviceRequestCreat
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &DeviceResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -475,18 +592,36 @@ func (s *DevicesServiceOp) CreateByLink(endpoint string, dir *DeviceRequestCreat
 
 // Delete removes apikey
 func (s *DevicesServiceOp) Delete(t *Device) error {
-	return s.DeleteByLink(t.Href)
+	return s.DeleteContext(context.Background(), t)
+}
+
+// DeleteContext is like Delete but uses ctx for the underlying request.
+func (s *DevicesServiceOp) DeleteContext(ctx context.Context, t *Device) error {
+	ctx = withOperation(ctx, "Devices", "Delete")
+	return s.DeleteByLinkContext(ctx, t.Href)
 }
 
 // Delete removes apikey by ID
 func (s *DevicesServiceOp) DeleteById(id string) error {
+	return s.DeleteByIdContext(context.Background(), id)
+}
+
+// DeleteByIdContext is like DeleteById but uses ctx for the underlying request.
+func (s *DevicesServiceOp) DeleteByIdContext(ctx context.Context, id string) error {
+	ctx = withOperation(ctx, "Devices", "DeleteById")
 	endpoint := fmt.Sprintf("devices/%s", id)
-	return s.DeleteByLink(endpoint)
+	return s.DeleteByLinkContext(ctx, endpoint)
 }
 
 // Delete removes apikey by link
 func (s *DevicesServiceOp) DeleteByLink(endpoint string) error {
-	resp, err := s.client.request("DELETE", endpoint, nil)
+	return s.DeleteByLinkContext(context.Background(), endpoint)
+}
+
+// DeleteByLinkContext is like DeleteByLink but uses ctx for the underlying request.
+func (s *DevicesServiceOp) DeleteByLinkContext(ctx context.Context, endpoint string) error {
+	ctx = withOperation(ctx, "Devices", "DeleteByLink")
+	resp, err := s.client.request(ctx, "DELETE", endpoint, nil)
 	if err != nil {
 		return err
 	}
@@ -494,7 +629,7 @@ func (s *DevicesServiceOp) DeleteByLink(endpoint string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent {
-		return ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return newApiError(resp, "non-ok status returned")
 	}
 	return nil
 }
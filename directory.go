@@ -2,6 +2,7 @@ package api
 
 import (
     "bytes"
+    "context"
     "encoding/json" 
     "fmt"   
     "net/http"
@@ -13,18 +14,27 @@ import (
 // https://tenant-name.cloudthing.io/api/v1/directories
 type DirectoriesService interface {
     GetById(string, ...interface{}) (*Directory, error)
+    GetByIdContext(context.Context, string, ...interface{}) (*Directory, error)
     GetByLink(string, ...interface{}) (*Directory, error)
+    GetByLinkContext(context.Context, string, ...interface{}) (*Directory, error)
     List(...interface{}) ([]Directory, *ListParams, error)
+    ListContext(context.Context, ...interface{}) ([]Directory, *ListParams, error)
+    Iterate(context.Context, ...interface{}) *Iterator[Directory]
     ListByLink(string, ...interface{}) ([]Directory, *ListParams, error)
+    ListByLinkContext(context.Context, string, ...interface{}) ([]Directory, *ListParams, error)
+    IterateByLink(context.Context, string, ...interface{}) *Iterator[Directory]
     Create(*DirectoryRequestCreate) (*Directory, error)
+    CreateContext(context.Context, *DirectoryRequestCreate) (*Directory, error)
     UpdateById(string, *DirectoryRequestUpdate) (*Directory, error)
+    UpdateByIdContext(context.Context, string, *DirectoryRequestUpdate) (*Directory, error)
     UpdateByLink(string, *DirectoryRequestUpdate) (*Directory, error)
+    UpdateByLinkContext(context.Context, string, *DirectoryRequestUpdate) (*Directory, error)
     Delete(*Directory) (error)
+    DeleteContext(context.Context, *Directory) (error)
     DeleteByLink(string) (error)
+    DeleteByLinkContext(context.Context, string) (error)
     DeleteById(string) (error)
-
-    get(*DirectoryResponse) (*Directory, error)
-    getCollection(*DirectoriesResponse) ([]Directory, *ListParams, error)
+    DeleteByIdContext(context.Context, string) (error)
 }
 
 // DirectoriesServiceOp handles communication with Directories related methods of API
@@ -49,8 +59,9 @@ type Directory struct {
     Tenant          *Tenant
     // Points to Applications if expansion was requested, otherwise nil
     Applications    []Application
-    // Points to Tenant if expansion was requested, otherwise nil
-    Users           *User
+    // Points to Users if expansion was requested, otherwise nil. It used to be
+    // *User, which was never filled, and is a collection like Applications.
+    Users           []User
     // Points to Applications if expansion was requested, otherwise nil
     Usergroups      []Usergroup
 
@@ -120,6 +131,12 @@ func (d *Directory) UsersLink() (bool, string) {
 }
 
 func (d *Directory) UserCreate(dir *User) (*User, error) {
+    return d.UserCreateContext(context.Background(), dir)
+}
+
+// UserCreateContext is like UserCreate but uses ctx for the underlying request.
+func (d *Directory) UserCreateContext(ctx context.Context, dir *User) (*User, error) {
+    ctx = withOperation(ctx, "Directories", "UserCreate")
     endpoint := fmt.Sprintf("%s/users", d.Href)
 
     dir.CreatedAt = nil
@@ -133,7 +150,7 @@ func (d *Directory) UserCreate(dir *User) (*User, error) {
 
     buf := bytes.NewBuffer(enc)
 
-    resp, err := d.service.client.request("POST", endpoint, buf)
+    resp, err := d.service.client.request(ctx, "POST", endpoint, buf)
     if err != nil {
         return nil, err
     }
@@ -141,12 +158,12 @@ func (d *Directory) UserCreate(dir *User) (*User, error) {
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusCreated {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &User{}
     dec := json.NewDecoder(resp.Body)
     dec.Decode(obj)
-    obj.service = d.service.client.Users.(*UsersServiceOp)
+    obj.service = d.service.client.conv.users
     return obj, nil
 }
 
@@ -194,15 +211,27 @@ func (t *Directory) Delete() error {
 
 // GetById retrieves apikey by its ID
 func (s *DirectoriesServiceOp) GetById(id string, args ...interface{}) (*Directory, error) {
+    return s.GetByIdContext(context.Background(), id, args...)
+}
+
+// GetByIdContext is like GetById but uses ctx for the underlying request.
+func (s *DirectoriesServiceOp) GetByIdContext(ctx context.Context, id string, args ...interface{}) (*Directory, error) {
+    ctx = withOperation(ctx, "Directories", "GetById")
     endpoint := "directories/"
     endpoint = fmt.Sprintf("%s%s", endpoint, id)
 
-    return s.GetByLink(endpoint, args...)
+    return s.GetByLinkContext(ctx, endpoint, args...)
 }
 
 // GetById retrieves apikey by its full link
 func (s *DirectoriesServiceOp) GetByLink(endpoint string, args ...interface{}) (*Directory, error) {
-    resp, err := s.client.request("GET", endpoint, nil, args...)
+    return s.GetByLinkContext(context.Background(), endpoint, args...)
+}
+
+// GetByLinkContext is like GetByLink but uses ctx for the underlying request.
+func (s *DirectoriesServiceOp) GetByLinkContext(ctx context.Context, endpoint string, args ...interface{}) (*Directory, error) {
+    ctx = withOperation(ctx, "Directories", "GetByLink")
+    resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
     if err != nil {
         return nil, err
     }
@@ -210,7 +239,7 @@ func (s *DirectoriesServiceOp) GetByLink(endpoint string, args ...interface{}) (
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &DirectoryResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -242,7 +271,7 @@ func (s *DirectoriesServiceOp) get(r *DirectoryResponse) (*Directory, error) {
         }
         ten := &TenantResponse{}
         json.Unmarshal(bytes, ten)
-        t, err := s.client.Tenant.get(ten)
+        t, err := s.client.conv.tenant.get(ten)
         if err != nil {
             return nil, err
         }
@@ -255,12 +284,38 @@ func (s *DirectoriesServiceOp) get(r *DirectoryResponse) (*Directory, error) {
         }
         ten := &ApplicationsResponse{}
         json.Unmarshal(bytes, ten)
-        t, _, err := s.client.Applications.getCollection(ten)
+        t, _, err := s.client.conv.applications.getCollection(ten)
         if err != nil {
             return nil, err
         }
         obj.Applications = t
     }
+    if len(r.Users) > 1 {
+        bytes, err := json.Marshal(r.Users)
+        if err != nil {
+            return nil, err
+        }
+        ten := &UsersResponse{}
+        json.Unmarshal(bytes, ten)
+        t, _, err := s.client.conv.users.getCollection(ten)
+        if err != nil {
+            return nil, err
+        }
+        obj.Users = t
+    }
+    if len(r.Usergroups) > 1 {
+        bytes, err := json.Marshal(r.Usergroups)
+        if err != nil {
+            return nil, err
+        }
+        ten := &UsergroupsResponse{}
+        json.Unmarshal(bytes, ten)
+        t, _, err := s.client.conv.usergroups.getCollection(ten)
+        if err != nil {
+            return nil, err
+        }
+        obj.Usergroups = t
+    }
     obj.service = s
     return obj, nil
 }
@@ -289,13 +344,32 @@ func (s *DirectoriesServiceOp) getCollection(r *DirectoriesResponse) ([]Director
 
 // GetById retrieves collection of directories of current tenant
 func (s *DirectoriesServiceOp) List(args ...interface{}) ([]Directory, *ListParams, error) {
-    endpoint := fmt.Sprintf("tenants/%s/directories", s.client.tenantId)
-    return s.ListByLink(endpoint, args...)
+    return s.ListContext(context.Background(), args...)
+}
+
+// ListContext is like List but uses ctx for the underlying request.
+func (s *DirectoriesServiceOp) ListContext(ctx context.Context, args ...interface{}) ([]Directory, *ListParams, error) {
+    ctx = withOperation(ctx, "Directories", "List")
+    endpoint := fmt.Sprintf("tenants/%s/directories", s.client.currentTenantId(ctx))
+    return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// Iterate is like List but returns iterator walking over all pages
+func (s *DirectoriesServiceOp) Iterate(ctx context.Context, args ...interface{}) *Iterator[Directory] {
+    ctx = withOperation(ctx, "Directories", "Iterate")
+    endpoint := fmt.Sprintf("tenants/%s/directories", s.client.currentTenantId(ctx))
+    return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of directories by link
 func (s *DirectoriesServiceOp) ListByLink(endpoint string, args ...interface{}) ([]Directory, *ListParams, error) {
-    resp, err := s.client.request("GET", endpoint, nil, args...)
+    return s.ListByLinkContext(context.Background(), endpoint, args...)
+}
+
+// ListByLinkContext is like ListByLink but uses ctx for the underlying request.
+func (s *DirectoriesServiceOp) ListByLinkContext(ctx context.Context, endpoint string, args ...interface{}) ([]Directory, *ListParams, error) {
+    ctx = withOperation(ctx, "Directories", "ListByLink")
+    resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
     if err != nil {
         return nil, nil, err
     }
@@ -303,7 +377,7 @@ func (s *DirectoriesServiceOp) ListByLink(endpoint string, args ...interface{})
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, nil, newApiError(resp, "non-ok status returned")
     }
     obj := &DirectoriesResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -312,14 +386,32 @@ func (s *DirectoriesServiceOp) ListByLink(endpoint string, args ...interface{})
     return s.getCollection(obj)
 }
 
+// IterateByLink is like ListByLink but returns iterator walking over all pages
+func (s *DirectoriesServiceOp) IterateByLink(ctx context.Context, endpoint string, args ...interface{}) *Iterator[Directory] {
+    ctx = withOperation(ctx, "Directories", "IterateByLink")
+    return newIterator(ctx, endpoint, s.ListByLinkContext, args...)
+}
+
 // GetById updates apikey with specified ID
 func (s *DirectoriesServiceOp) UpdateById(id string, t *DirectoryRequestUpdate) (*Directory, error) {
+    return s.UpdateByIdContext(context.Background(), id, t)
+}
+
+// UpdateByIdContext is like UpdateById but uses ctx for the underlying request.
+func (s *DirectoriesServiceOp) UpdateByIdContext(ctx context.Context, id string, t *DirectoryRequestUpdate) (*Directory, error) {
+    ctx = withOperation(ctx, "Directories", "UpdateById")
     endpoint := fmt.Sprintf("directories/%s", id)
-    return s.UpdateByLink(endpoint, t)
+    return s.UpdateByLinkContext(ctx, endpoint, t)
 }
 
 // GetById updates apikey specified by link
 func (s *DirectoriesServiceOp) UpdateByLink(endpoint string, t *DirectoryRequestUpdate) (*Directory, error) {
+    return s.UpdateByLinkContext(context.Background(), endpoint, t)
+}
+
+// UpdateByLinkContext is like UpdateByLink but uses ctx for the underlying request.
+func (s *DirectoriesServiceOp) UpdateByLinkContext(ctx context.Context, endpoint string, t *DirectoryRequestUpdate) (*Directory, error) {
+    ctx = withOperation(ctx, "Directories", "UpdateByLink")
     enc, err := json.Marshal(t)
     if err != nil {
         return nil, err
@@ -327,7 +419,7 @@ func (s *DirectoriesServiceOp) UpdateByLink(endpoint string, t *DirectoryRequest
 
     buf := bytes.NewBuffer(enc)
 
-    resp, err := s.client.request("POST", endpoint, buf)
+    resp, err := s.client.request(ctx, "POST", endpoint, buf)
     if err != nil {
         return nil, err
     }
@@ -335,7 +427,7 @@ func (s *DirectoriesServiceOp) UpdateByLink(endpoint string, t *DirectoryRequest
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &DirectoryResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -345,7 +437,13 @@ func (s *DirectoriesServiceOp) UpdateByLink(endpoint string, t *DirectoryRequest
 
 // Create creates new apikey within tenant
 func (s *DirectoriesServiceOp) Create(dir *DirectoryRequestCreate) (*Directory, error) {
-    endpoint := fmt.Sprintf("tenants/%s/directories", s.client.tenantId)
+    return s.CreateContext(context.Background(), dir)
+}
+
+// CreateContext is like Create but uses ctx for the underlying request.
+func (s *DirectoriesServiceOp) CreateContext(ctx context.Context, dir *DirectoryRequestCreate) (*Directory, error) {
+    ctx = withOperation(ctx, "Directories", "Create")
+    endpoint := fmt.Sprintf("tenants/%s/directories", s.client.currentTenantId(ctx))
 
     enc, err := json.Marshal(dir)
     if err != nil {
@@ -354,7 +452,7 @@ func (s *DirectoriesServiceOp) Create(dir *DirectoryRequestCreate) (*Directory,
 
     buf := bytes.NewBuffer(enc)
 
-    resp, err := s.client.request("POST", endpoint, buf)
+    resp, err := s.client.request(ctx, "POST", endpoint, buf)
     if err != nil {
         return nil, err
     }
@@ -362,7 +460,7 @@ func (s *DirectoriesServiceOp) Create(dir *DirectoryRequestCreate) (*Directory,
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusCreated {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &DirectoryResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -372,18 +470,36 @@ func (s *DirectoriesServiceOp) Create(dir *DirectoryRequestCreate) (*Directory,
 
 // Delete removes apikey
 func (s *DirectoriesServiceOp) Delete(t *Directory) (error) {
-    return s.DeleteByLink(t.Href)
+    return s.DeleteContext(context.Background(), t)
+}
+
+// DeleteContext is like Delete but uses ctx for the underlying request.
+func (s *DirectoriesServiceOp) DeleteContext(ctx context.Context, t *Directory) (error) {
+    ctx = withOperation(ctx, "Directories", "Delete")
+    return s.DeleteByLinkContext(ctx, t.Href)
 }
 
 // Delete removes apikey by ID
 func (s *DirectoriesServiceOp) DeleteById(id string) (error) {
+    return s.DeleteByIdContext(context.Background(), id)
+}
+
+// DeleteByIdContext is like DeleteById but uses ctx for the underlying request.
+func (s *DirectoriesServiceOp) DeleteByIdContext(ctx context.Context, id string) (error) {
+    ctx = withOperation(ctx, "Directories", "DeleteById")
     endpoint := fmt.Sprintf("directories/%s", id)
-    return s.DeleteByLink(endpoint)
+    return s.DeleteByLinkContext(ctx, endpoint)
 }
 
 // Delete removes apikey by link
 func (s *DirectoriesServiceOp) DeleteByLink(endpoint string) (error) {
-    resp, err := s.client.request("DELETE", endpoint, nil)
+    return s.DeleteByLinkContext(context.Background(), endpoint)
+}
+
+// DeleteByLinkContext is like DeleteByLink but uses ctx for the underlying request.
+func (s *DirectoriesServiceOp) DeleteByLinkContext(ctx context.Context, endpoint string) (error) {
+    ctx = withOperation(ctx, "Directories", "DeleteByLink")
+    resp, err := s.client.request(ctx, "DELETE", endpoint, nil)
     if err != nil {
         return err
     }
@@ -391,7 +507,7 @@ func (s *DirectoriesServiceOp) DeleteByLink(endpoint string) (error) {
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusNoContent {
-        return ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return newApiError(resp, "non-ok status returned")
     }
     return nil
 }
@@ -0,0 +1,398 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DispatchOptions configures CommandDispatcher, zero values are replaced by defaults
+type DispatchOptions struct {
+	// Time to wait for acknowledgement of a single attempt, 30s by default
+	Timeout time.Duration
+	// Number of attempts to deliver command to a device, 3 by default
+	MaxAttempts int
+	// Field of payloads of commands and acknowledgements holding correlation ID,
+	// "correlationId" by default
+	CorrelationField string
+	// Key of acknowledgement events, any event with matching correlation ID
+	// acknowledges command if empty
+	AckKey string
+	// Number of devices command is delivered to at once, each of them with its own
+	// subscription to events, 8 by default. Other devices wait in pending state.
+	Concurrency int
+}
+
+func (o DispatchOptions) withDefaults() DispatchOptions {
+	if o.Timeout <= 0 {
+		o.Timeout = 30 * time.Second
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 3
+	}
+	if o.CorrelationField == "" {
+		o.CorrelationField = "correlationId"
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = 8
+	}
+	return o
+}
+
+// DeliveryStatus is a state of command sent to a single device
+type DeliveryStatus int
+
+const (
+	// Command was sent, or is about to be, and no acknowledgement arrived yet
+	DeliveryPending DeliveryStatus = iota
+	// Device acknowledged command
+	DeliveryAcked
+	// Device didn't acknowledge any attempt in time
+	DeliveryTimedOut
+	// Command couldn't be sent or acknowledgements couldn't be watched
+	DeliveryFailed
+)
+
+func (s DeliveryStatus) String() string {
+	switch s {
+	case DeliveryPending:
+		return "pending"
+	case DeliveryAcked:
+		return "acked"
+	case DeliveryTimedOut:
+		return "timed out"
+	case DeliveryFailed:
+		return "failed"
+	}
+	return fmt.Sprintf("DeliveryStatus(%d)", int(s))
+}
+
+// Delivery is a state of command sent to a single device
+type Delivery struct {
+	DeviceId string
+	Status   DeliveryStatus
+	// Number of times command was sent
+	Attempts int
+	// Time of the last attempt
+	SentAt time.Time
+	// Acknowledgement event and time it was received, set when acked
+	Ack     *EventPoint
+	AckedAt time.Time
+	// Reason of failure
+	Err error
+}
+
+// CommandDispatcher sends commands to devices and tracks their acknowledgements.
+// Every dispatched command gets a correlation ID, placed into its payload, and
+// devices acknowledge it by writing an event whose payload holds the same ID:
+//
+//	{"key": "ack", "payload": {"correlationId": "5f0c..."}}
+//
+// Commands which aren't acknowledged in time are sent again, with the same ID,
+// until attempts run out. Commands sent to clusters and groups are sent to each
+// of their devices separately, so they are tracked per device. Device listed more
+// than once, e.g. when members change while they are listed, gets command once.
+//
+// With schema validation enabled, products have to declare the correlation
+// payload of commands.
+//
+//	d := api.NewCommandDispatcher(client.Resources, client.Devices, api.DispatchOptions{AckKey: "ack"})
+//	dispatch, err := d.SendToGroup(ctx, groupId, api.CommandPoint{Key: "reboot"})
+//	deliveries, err := dispatch.Wait(ctx)
+type CommandDispatcher struct {
+	resources ResourcesService
+	devices   DevicesService
+	opts      DispatchOptions
+}
+
+// NewCommandDispatcher returns dispatcher sending commands with resources and listing
+// devices of clusters and groups with devices
+func NewCommandDispatcher(resources ResourcesService, devices DevicesService, opts DispatchOptions) *CommandDispatcher {
+	return &CommandDispatcher{resources: resources, devices: devices, opts: opts.withDefaults()}
+}
+
+// Dispatch is a command sent to one or more devices
+type Dispatch struct {
+	CorrelationId string
+	// Command as sent, including correlation ID
+	Command CommandPoint
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu         sync.Mutex
+	deliveries map[string]*Delivery
+}
+
+// SendToDevice sends command to device. Acknowledgements are watched until all
+// deliveries finish or ctx is done.
+func (d *CommandDispatcher) SendToDevice(ctx context.Context, deviceID string, command CommandPoint) (*Dispatch, error) {
+	return d.dispatch(ctx, []string{deviceID}, command)
+}
+
+// SendToCluster sends command to every device of cluster, see SendToDevice
+func (d *CommandDispatcher) SendToCluster(ctx context.Context, clusterID string, command CommandPoint) (*Dispatch, error) {
+	ids, err := deviceIds(d.devices.IterateByCluster(withOperation(ctx, "Devices", "IterateByCluster"), clusterID))
+	if err != nil {
+		return nil, err
+	}
+	return d.dispatch(ctx, ids, command)
+}
+
+// SendToGroup sends command to every device of group, see SendToDevice
+func (d *CommandDispatcher) SendToGroup(ctx context.Context, groupID string, command CommandPoint) (*Dispatch, error) {
+	ids, err := deviceIds(d.devices.IterateByGroup(withOperation(ctx, "Devices", "IterateByGroup"), groupID))
+	if err != nil {
+		return nil, err
+	}
+	return d.dispatch(ctx, ids, command)
+}
+
+// deviceIds collects IDs of devices of iterator
+func deviceIds(it *Iterator[Device]) ([]string, error) {
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Value().GetId())
+	}
+	return ids, it.Err()
+}
+
+// dispatch starts delivering command to devices
+func (d *CommandDispatcher) dispatch(ctx context.Context, deviceIds []string, command CommandPoint) (*Dispatch, error) {
+	id, err := newCorrelationId()
+	if err != nil {
+		return nil, err
+	}
+	fields, err := payloadFields(command.Payload)
+	if err != nil {
+		return nil, err
+	}
+	payload := make(map[string]interface{}, len(fields)+1)
+	for k, v := range fields {
+		payload[k] = v
+	}
+	payload[d.opts.CorrelationField] = id
+	command.Payload = payload
+
+	ctx, cancel := context.WithCancel(ctx)
+	dispatch := &Dispatch{
+		CorrelationId: id,
+		Command:       command,
+		cancel:        cancel,
+		done:          make(chan struct{}),
+		deliveries:    make(map[string]*Delivery, len(deviceIds)),
+	}
+	// every device has a single delivery, duplicate IDs are skipped
+	ids := make([]string, 0, len(deviceIds))
+	for _, deviceId := range deviceIds {
+		if _, ok := dispatch.deliveries[deviceId]; ok {
+			continue
+		}
+		dispatch.deliveries[deviceId] = &Delivery{DeviceId: deviceId}
+		ids = append(ids, deviceId)
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, d.opts.Concurrency)
+	for _, deviceId := range ids {
+		wg.Add(1)
+		go func(deviceId string) {
+			defer wg.Done()
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				dispatch.finish(deviceId, DeliveryFailed, nil, ctx.Err())
+				return
+			}
+			defer func() { <-slots }()
+			d.deliver(ctx, dispatch, deviceId)
+		}(deviceId)
+	}
+	go func() {
+		wg.Wait()
+		cancel()
+		close(dispatch.done)
+	}()
+	return dispatch, nil
+}
+
+// deliver sends command to device until it is acknowledged or attempts run out
+func (d *CommandDispatcher) deliver(ctx context.Context, dispatch *Dispatch, deviceId string) {
+	// watching starts before sending, so early acknowledgements aren't missed
+	sub, err := d.resources.Subscribe(ctx, fmt.Sprintf("devices/%s/resources", deviceId), ResourceEvents)
+	if err == nil && sub == nil {
+		err = fmt.Errorf("no subscription to events of device %s", deviceId)
+	}
+	if err != nil {
+		dispatch.finish(deviceId, DeliveryFailed, nil, err)
+		return
+	}
+	defer sub.Close()
+
+	for attempt := 1; attempt <= d.opts.MaxAttempts; attempt++ {
+		dispatch.update(deviceId, func(del *Delivery) {
+			del.Attempts = attempt
+			del.SentAt = time.Now()
+		})
+		// command carries correlation ID, so it is safe to send it again
+		if _, err := d.resources.WriteCommandsForDeviceIDContext(WithRetry(ctx), deviceId, []CommandPoint{dispatch.Command}); err != nil {
+			dispatch.finish(deviceId, DeliveryFailed, nil, err)
+			return
+		}
+
+		ack, err := d.awaitAck(ctx, sub, dispatch.CorrelationId)
+		switch {
+		case ack != nil:
+			dispatch.finish(deviceId, DeliveryAcked, ack, nil)
+			return
+		case err != nil:
+			dispatch.finish(deviceId, DeliveryFailed, nil, err)
+			return
+		}
+	}
+	dispatch.finish(deviceId, DeliveryTimedOut, nil, nil)
+}
+
+// awaitAck waits for acknowledgement of command with correlation ID. Nil event and
+// error are returned when timeout passes.
+func (d *CommandDispatcher) awaitAck(ctx context.Context, sub *Subscription, id string) (*EventPoint, error) {
+	timer := time.NewTimer(d.opts.Timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case u, ok := <-sub.Updates():
+			if !ok {
+				if err := sub.Err(); err != nil {
+					return nil, err
+				}
+				return nil, ctx.Err()
+			}
+			if u.Event != nil && d.acknowledges(*u.Event, id) {
+				return u.Event, nil
+			}
+		case <-timer.C:
+			return nil, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// acknowledges checks whether event acknowledges command with correlation ID
+func (d *CommandDispatcher) acknowledges(event EventPoint, id string) bool {
+	if d.opts.AckKey != "" && event.Key != d.opts.AckKey {
+		return false
+	}
+	fields, err := payloadFields(event.Payload)
+	if err != nil {
+		return false
+	}
+	v, ok := fields[d.opts.CorrelationField]
+	return ok && fmt.Sprint(v) == id
+}
+
+// newCorrelationId returns random ID of dispatched command
+func newCorrelationId() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (d *Dispatch) update(deviceId string, f func(*Delivery)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	f(d.deliveries[deviceId])
+}
+
+func (d *Dispatch) finish(deviceId string, status DeliveryStatus, ack *EventPoint, err error) {
+	d.update(deviceId, func(del *Delivery) {
+		del.Status, del.Err = status, err
+		if ack != nil {
+			del.Ack, del.AckedAt = ack, time.Now()
+		}
+	})
+}
+
+// Deliveries returns current state of command for every device, ordered by device ID
+func (d *Dispatch) Deliveries() []Delivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	res := make([]Delivery, 0, len(d.deliveries))
+	for _, del := range d.deliveries {
+		res = append(res, *del)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].DeviceId < res[j].DeviceId })
+	return res
+}
+
+// Delivery returns current state of command sent to device
+func (d *Dispatch) Delivery(deviceID string) (Delivery, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	del, ok := d.deliveries[deviceID]
+	if !ok {
+		return Delivery{}, false
+	}
+	return *del, true
+}
+
+// Pending returns number of devices which haven't acknowledged command yet and still may
+func (d *Dispatch) Pending() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := 0
+	for _, del := range d.deliveries {
+		if del.Status == DeliveryPending {
+			n++
+		}
+	}
+	return n
+}
+
+// Done returns channel closed when all deliveries finish
+func (d *Dispatch) Done() <-chan struct{} {
+	return d.done
+}
+
+// Wait waits until all deliveries finish and returns them. If ctx is done first,
+// current deliveries are returned with its error, tracking goes on.
+func (d *Dispatch) Wait(ctx context.Context) ([]Delivery, error) {
+	select {
+	case <-d.done:
+		return d.Deliveries(), nil
+	case <-ctx.Done():
+		return d.Deliveries(), ctx.Err()
+	}
+}
+
+// Cancel stops tracking, deliveries which didn't finish fail with context.Canceled
+func (d *Dispatch) Cancel() {
+	d.cancel()
+	<-d.done
+}
+
+// ErrNotAcknowledged is returned by Dispatch.Err when some device didn't acknowledge command
+var ErrNotAcknowledged = errors.New("command not acknowledged")
+
+// Err returns nil if all devices acknowledged command, otherwise error matching
+// ErrNotAcknowledged which lists devices which didn't
+func (d *Dispatch) Err() error {
+	deliveries := d.Deliveries()
+	var failed []string
+	for _, del := range deliveries {
+		if del.Status != DeliveryAcked {
+			failed = append(failed, fmt.Sprintf("%s (%s)", del.DeviceId, del.Status))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w by %d of %d devices: %v", ErrNotAcknowledged, len(failed), len(deliveries), failed)
+}
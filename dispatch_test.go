@@ -0,0 +1,261 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+	"github.com/cloudthing-io/go-client-api/mocks"
+)
+
+// ackDevice acknowledges commands of device, ignoring the first skip of them
+func ackDevice(t *testing.T, ctx context.Context, client *api.Client, deviceId string, skip int) {
+	sub, err := client.Resources.Subscribe(ctx, "devices/"+deviceId+"/resources", api.ResourceCommands)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	go func() {
+		n := 0
+		for u := range sub.Updates() {
+			if n++; n <= skip {
+				continue
+			}
+			payload := u.Command.Payload.(map[string]interface{})
+			ack := api.EventPoint{Key: "ack", Payload: map[string]interface{}{"correlationId": payload["correlationId"]}}
+			if _, err := client.Resources.WriteEventsForDeviceID(deviceId, []api.EventPoint{ack}); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+}
+
+func TestDispatcherTracksAcknowledgements(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	group := srv.Add("groups", map[string]interface{}{"name": "lights"}, nil)
+	var devices []string
+	for i := 0; i < 3; i++ {
+		id := srv.Add("devices", map[string]interface{}{}, nil)
+		srv.Add("groupMemberships", map[string]interface{}{}, map[string]string{"device": "devices/" + id, "group": "groups/" + group})
+		devices = append(devices, id)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ackDevice(t, ctx, client, devices[0], 0)
+	ackDevice(t, ctx, client, devices[1], 1)
+	// subscriptions of devices start before commands are sent
+	time.Sleep(100 * time.Millisecond)
+
+	d := api.NewCommandDispatcher(client.Resources, client.Devices, api.DispatchOptions{
+		Timeout:     500 * time.Millisecond,
+		MaxAttempts: 2,
+		AckKey:      "ack",
+	})
+	dispatch, err := d.SendToGroup(ctx, group, api.CommandPoint{Key: "switch", Payload: map[string]interface{}{"on": true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dispatch.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]struct {
+		status   api.DeliveryStatus
+		attempts int
+	}{
+		devices[0]: {api.DeliveryAcked, 1},
+		devices[1]: {api.DeliveryAcked, 2},
+		devices[2]: {api.DeliveryTimedOut, 2},
+	}
+	for id, w := range want {
+		del, ok := dispatch.Delivery(id)
+		if !ok {
+			t.Fatalf("no delivery to %s", id)
+		}
+		if del.Status != w.status || del.Attempts != w.attempts {
+			t.Errorf("delivery to %s is %s after %d attempts, want %s after %d", id, del.Status, del.Attempts, w.status, w.attempts)
+		}
+		if del.Status == api.DeliveryAcked && del.Ack == nil {
+			t.Errorf("acked delivery to %s has no ack", id)
+		}
+	}
+	if err := dispatch.Err(); err == nil {
+		t.Error("Err() = nil, want error for timed out device")
+	}
+}
+
+func TestDispatcherNilSubscription(t *testing.T) {
+	resources := &mocks.ResourcesService{}
+	d := api.NewCommandDispatcher(resources, &mocks.DevicesService{}, api.DispatchOptions{})
+	dispatch, err := d.SendToDevice(context.Background(), "1", api.CommandPoint{Key: "reboot"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-dispatch.Done()
+	del, _ := dispatch.Delivery("1")
+	if del.Status != api.DeliveryFailed || del.Err == nil {
+		t.Errorf("delivery = %s, %v, want failed with error", del.Status, del.Err)
+	}
+	if n := resources.Called("WriteCommandsForDeviceID"); n != 0 {
+		t.Errorf("command sent %d times without subscription", n)
+	}
+}
+
+func TestDispatcherBoundsConcurrency(t *testing.T) {
+	var subscribed int32
+	release := make(chan struct{})
+	resources := &mocks.ResourcesService{
+		SubscribeFunc: func(ctx context.Context, link string, kinds ...api.ResourceKind) (*api.Subscription, error) {
+			atomic.AddInt32(&subscribed, 1)
+			return api.NewSubscriptionFromChannel(make(chan api.Update)), nil
+		},
+		// deliveries hold their subscriptions until released
+		WriteCommandsForDeviceIDFunc: func(ctx context.Context, id string, points []api.CommandPoint) ([]api.CommandPoint, error) {
+			<-release
+			return points, nil
+		},
+	}
+	devices := &mocks.DevicesService{
+		ListByGroupFunc: func(ctx context.Context, id string, args ...interface{}) ([]api.Device, *api.ListParams, error) {
+			var res []api.Device
+			for i := 0; i < 10; i++ {
+				res = append(res, api.Device{ModelBase: api.ModelBase{Href: fmt.Sprintf("devices/%d", i)}})
+			}
+			return res, nil, nil
+		},
+	}
+	d := api.NewCommandDispatcher(resources, devices, api.DispatchOptions{Timeout: 10 * time.Millisecond, MaxAttempts: 1, Concurrency: 3})
+	dispatch, err := d.SendToGroup(context.Background(), "g", api.CommandPoint{Key: "reboot"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for deadline := time.Now().Add(time.Second); atomic.LoadInt32(&subscribed) < 3 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if n := atomic.LoadInt32(&subscribed); n != 3 {
+		t.Errorf("%d subscriptions open at once, want 3", n)
+	}
+	close(release)
+
+	deliveries, err := dispatch.Wait(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 10 {
+		t.Fatalf("got %d deliveries, want 10", len(deliveries))
+	}
+	for _, del := range deliveries {
+		if del.Status != api.DeliveryTimedOut {
+			t.Errorf("delivery to %s is %s, want timed out", del.DeviceId, del.Status)
+		}
+	}
+	if n := atomic.LoadInt32(&subscribed); n != 10 {
+		t.Errorf("%d subscriptions opened, want 10", n)
+	}
+}
+
+// silentResources returns fake of resources whose subscriptions never receive acknowledgements
+func silentResources() *mocks.ResourcesService {
+	return &mocks.ResourcesService{
+		SubscribeFunc: func(ctx context.Context, link string, kinds ...api.ResourceKind) (*api.Subscription, error) {
+			return api.NewSubscriptionFromChannel(make(chan api.Update)), nil
+		},
+	}
+}
+
+func TestDispatcherCancel(t *testing.T) {
+	d := api.NewCommandDispatcher(silentResources(), &mocks.DevicesService{}, api.DispatchOptions{Timeout: time.Minute})
+	dispatch, err := d.SendToDevice(context.Background(), "1", api.CommandPoint{Key: "reboot"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dispatch.Cancel()
+	select {
+	case <-dispatch.Done():
+	default:
+		t.Fatal("Cancel returned before deliveries finished")
+	}
+	del, _ := dispatch.Delivery("1")
+	if del.Status != api.DeliveryFailed || !errors.Is(del.Err, context.Canceled) {
+		t.Errorf("delivery = %s, %v, want failed with context canceled", del.Status, del.Err)
+	}
+	if err := dispatch.Err(); !errors.Is(err, api.ErrNotAcknowledged) {
+		t.Errorf("Err() = %v, want ErrNotAcknowledged", err)
+	}
+}
+
+func TestDispatchWaitTimeout(t *testing.T) {
+	d := api.NewCommandDispatcher(silentResources(), &mocks.DevicesService{}, api.DispatchOptions{Timeout: time.Minute})
+	dispatch, err := d.SendToDevice(context.Background(), "1", api.CommandPoint{Key: "reboot"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dispatch.Cancel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	deliveries, err := dispatch.Wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait = %v, want deadline exceeded", err)
+	}
+	if len(deliveries) != 1 || deliveries[0].Status != api.DeliveryPending {
+		t.Errorf("deliveries = %+v, want pending one", deliveries)
+	}
+	// tracking goes on after Wait gives up
+	select {
+	case <-dispatch.Done():
+		t.Error("dispatch finished by Wait")
+	default:
+	}
+	if n := dispatch.Pending(); n != 1 {
+		t.Errorf("%d deliveries pending, want 1", n)
+	}
+}
+
+func TestDispatcherTimesOutAfterMaxAttempts(t *testing.T) {
+	resources := silentResources()
+	devices := &mocks.DevicesService{}
+	// device listed twice gets a single delivery
+	devices.Return("ListByGroup", []api.Device{
+		{ModelBase: api.ModelBase{Href: "devices/1"}},
+		{ModelBase: api.ModelBase{Href: "devices/2"}},
+		{ModelBase: api.ModelBase{Href: "devices/1"}},
+	}, nil, nil)
+	d := api.NewCommandDispatcher(resources, devices, api.DispatchOptions{Timeout: 10 * time.Millisecond, MaxAttempts: 3})
+	dispatch, err := d.SendToGroup(context.Background(), "g", api.CommandPoint{Key: "reboot"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deliveries, err := dispatch.Wait(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 2 {
+		t.Fatalf("got %d deliveries, want one per device", len(deliveries))
+	}
+	for _, del := range deliveries {
+		if del.Status != api.DeliveryTimedOut || del.Attempts != 3 || del.Err != nil {
+			t.Errorf("delivery to %s is %s after %d attempts, %v, want timed out after 3", del.DeviceId, del.Status, del.Attempts, del.Err)
+		}
+	}
+	if n := resources.Called("WriteCommandsForDeviceID"); n != 6 {
+		t.Errorf("command sent %d times, want 3 times to each device", n)
+	}
+	err = dispatch.Err()
+	if !errors.Is(err, api.ErrNotAcknowledged) || !strings.Contains(err.Error(), "by 2 of 2 devices") {
+		t.Errorf("Err() = %v, want ErrNotAcknowledged by 2 of 2 devices", err)
+	}
+}
@@ -0,0 +1,246 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// relations describes which relations of each resource type may be expanded
+// and what type of resource they point to. Empty type disables validation of
+// nested expansions.
+var relations = map[string]map[string]string{
+	"tenants": {
+		"directories":  "directories",
+		"applications": "applications",
+		"products":     "products",
+	},
+	"applications": {
+		"tenant":    "tenants",
+		"directory": "directories",
+		"devices":   "devices",
+		"clusters":  "clusters",
+	},
+	"apikeys": {
+		"tenant":       "tenants",
+		"applications": "applications",
+	},
+	"clusters": {
+		"tenant":      "tenants",
+		"application": "applications",
+		"groups":      "groups",
+		"devices":     "devices",
+		"memberships": "clusterMemberships",
+	},
+	"clusterMemberships": {
+		"device":      "devices",
+		"cluster":     "clusters",
+		"application": "applications",
+	},
+	"devices": {
+		"tenant":             "tenants",
+		"product":            "products",
+		"clusters":           "clusters",
+		"groups":             "groups",
+		"clusterMemberships": "clusterMemberships",
+		"groupMemberships":   "groupMemberships",
+	},
+	"directories": {
+		"tenant":       "tenants",
+		"applications": "applications",
+		"users":        "users",
+		"usergroups":   "usergroups",
+	},
+	"exports": {
+		"tenantExp":   "",
+		"tenantImp":   "",
+		"application": "applications",
+	},
+	"groups": {
+		"tenant":      "tenants",
+		"application": "applications",
+		"cluster":     "clusters",
+		"devices":     "devices",
+		"memberships": "groupMemberships",
+	},
+	"groupMemberships": {
+		"device": "devices",
+		"group":  "groups",
+	},
+	"memberships": {
+		"user":      "users",
+		"usergroup": "usergroups",
+	},
+	"products": {
+		"tenant":  "tenants",
+		"devices": "devices",
+	},
+	"usergroups": {
+		"tenant":      "tenants",
+		"directory":   "directories",
+		"users":       "users",
+		"memberships": "memberships",
+	},
+	"users": {
+		"tenant":       "tenants",
+		"applications": "applications",
+		"directory":    "directories",
+		"usergroups":   "usergroups",
+		"memberships":  "memberships",
+	},
+}
+
+// Expansion describes related resources which should be embedded in response.
+// Relations are rendered in order they were added, nested expansions are supported:
+//
+//	api.Expand("clusters", api.Page(2)).Expand("product", api.Expand("tenant"))
+type Expansion struct {
+	items []expandItem
+}
+
+type expandItem struct {
+	name   string
+	limit  int
+	page   int
+	nested *Expansion
+}
+
+// ExpandOption configures single expanded relation
+type ExpandOption interface {
+	applyExpand(*expandItem)
+}
+
+type limitOption int
+
+func (l limitOption) applyExpand(i *expandItem) {
+	i.limit = int(l)
+}
+
+type pageOption int
+
+func (p pageOption) applyExpand(i *expandItem) {
+	i.page = int(p)
+}
+
+// Limit sets number of items of expanded collection
+func Limit(limit int) ExpandOption {
+	return limitOption(limit)
+}
+
+// Page sets page of expanded collection
+func Page(page int) ExpandOption {
+	return pageOption(page)
+}
+
+func (e *Expansion) applyExpand(i *expandItem) {
+	if i.nested == nil {
+		i.nested = &Expansion{}
+	}
+	i.nested.items = append(i.nested.items, e.items...)
+}
+
+// Expand creates expansion of relation name
+func Expand(name string, opts ...ExpandOption) *Expansion {
+	return (&Expansion{}).Expand(name, opts...)
+}
+
+// Expand adds relation name to expansion
+func (e *Expansion) Expand(name string, opts ...ExpandOption) *Expansion {
+	item := expandItem{name: name}
+	for _, o := range opts {
+		o.applyExpand(&item)
+	}
+	e.items = append(e.items, item)
+	return e
+}
+
+// Validate checks whether all relations, including nested ones, exist for resource type,
+// e.g. "devices". Unknown resource types are not validated.
+func (e *Expansion) Validate(resource string) error {
+	rels, ok := relations[resource]
+	if !ok {
+		return nil
+	}
+	for _, i := range e.items {
+		target, ok := rels[i.name]
+		if !ok {
+			return fmt.Errorf("%s can't be expanded on %s", i.name, resource)
+		}
+		if i.limit < 0 || i.page < 0 {
+			return fmt.Errorf("invalid pagination of expanded %s", i.name)
+		}
+		if i.nested != nil && target != "" {
+			if err := i.nested.Validate(target); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// supported returns expansion limited to relations valid for resource type,
+// nil if there are none
+func (e *Expansion) supported(resource string) *Expansion {
+	if _, ok := relations[resource]; !ok {
+		return e
+	}
+	exp := &Expansion{}
+	for _, i := range e.items {
+		item := &Expansion{items: []expandItem{i}}
+		if item.Validate(resource) == nil {
+			exp.items = append(exp.items, i)
+		}
+	}
+	if len(exp.items) == 0 {
+		return nil
+	}
+	return exp
+}
+
+func (e Expansion) String() string {
+	return "expand=" + e.render()
+}
+
+// render renders list of relations without 'expand=' prefix
+func (e *Expansion) render() string {
+	parts := make([]string, len(e.items))
+	for ind, i := range e.items {
+		opts := make([]string, 0, 3)
+		if i.limit > 0 {
+			opts = append(opts, fmt.Sprintf("limit:%d", i.limit))
+		}
+		if i.page > 0 {
+			opts = append(opts, fmt.Sprintf("page:%d", i.page))
+		}
+		if i.nested != nil && len(i.nested.items) > 0 {
+			opts = append(opts, fmt.Sprintf("expand:(%s)", i.nested.render()))
+		}
+		parts[ind] = i.name
+		if len(opts) > 0 {
+			parts[ind] = fmt.Sprintf("%s(%s)", i.name, strings.Join(opts, ","))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// resourceType guesses type of resource endpoint points to, e.g. "products/1/devices"
+// points to devices. Nested collections are resolved with relations of their parent,
+// so "clusters/1/memberships" points to clusterMemberships. Empty string is returned
+// if type is not known.
+func resourceType(endpoint string) string {
+	path := endpoint
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if i >= 2 {
+			if t := relations[segments[i-2]][segments[i]]; t != "" {
+				return t
+			}
+		}
+		if _, ok := relations[segments[i]]; ok {
+			return segments[i]
+		}
+	}
+	return ""
+}
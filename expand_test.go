@@ -0,0 +1,43 @@
+package api
+
+import "testing"
+
+func TestResourceType(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{"devices", "devices"},
+		{"devices/1", "devices"},
+		{"products/1/devices", "devices"},
+		{"devices/1/clusterMemberships", "clusterMemberships"},
+		{"clusters/1/memberships", "clusterMemberships"},
+		{"clusters/1/memberships?limit=25", "clusterMemberships"},
+		{"groups/1/memberships", "groupMemberships"},
+		{"users/1/memberships", "memberships"},
+		{"memberships/1", "memberships"},
+		{"https://test.cloudthing.io/api/v1/clusters/1/memberships", "clusterMemberships"},
+		{"devices/1/resources/data", "devices"},
+		{"unknown/1", ""},
+	}
+	for _, tt := range tests {
+		if got := resourceType(tt.endpoint); got != tt.want {
+			t.Errorf("resourceType(%q) = %q, want %q", tt.endpoint, got, tt.want)
+		}
+	}
+}
+
+func TestExpansionValidateNestedMemberships(t *testing.T) {
+	resource := resourceType("clusters/1/memberships")
+	for _, name := range []string{"device", "cluster", "application"} {
+		if err := Expand(name).Validate(resource); err != nil {
+			t.Errorf("Expand(%q) on cluster memberships: %v", name, err)
+		}
+	}
+	if err := Expand("user").Validate(resource); err == nil {
+		t.Error("Expand(\"user\") on cluster memberships: expected error")
+	}
+	if exp := Expand("device").Expand("user").supported(resource); exp == nil || exp.String() != "expand=device" {
+		t.Errorf("supported default expansion = %v, want expand=device", exp)
+	}
+}
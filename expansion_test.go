@@ -0,0 +1,66 @@
+package api_test
+
+import (
+	"testing"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+)
+
+func TestNestedExpansions(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := srv.ApplicationId()
+	dir := "directories/" + srv.DirectoryId()
+	product := srv.Add("products", map[string]interface{}{"name": "Lamp"}, nil)
+	device := srv.Add("devices", map[string]interface{}{}, map[string]string{"product": "products/" + product})
+	cluster := srv.Add("clusters", map[string]interface{}{"name": "Hall"}, map[string]string{"application": "applications/" + app})
+	srv.Add("clusterMemberships", map[string]interface{}{}, map[string]string{"device": "devices/" + device, "cluster": "clusters/" + cluster})
+	user := srv.AddUser("jane", "secret")
+	group := srv.Add("usergroups", map[string]interface{}{"name": "Staff"}, map[string]string{"directory": dir})
+	srv.Add("memberships", map[string]interface{}{}, map[string]string{"user": "users/" + user, "usergroup": "usergroups/" + group})
+
+	a, err := client.Applications.GetById(app, api.Expand("directory", api.Expand("users", api.Expand("memberships"))).
+		Expand("devices", api.Expand("product")).
+		Expand("clusters", api.Expand("devices")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Directory == nil || len(a.Directory.Users) != 2 {
+		t.Fatalf("directory = %+v, want expanded with 2 users", a.Directory)
+	}
+	for _, u := range a.Directory.Users {
+		if u.GetId() == user && len(u.Memberships) != 1 {
+			t.Errorf("user has %d memberships, want 1", len(u.Memberships))
+		}
+	}
+	if len(a.Devices) != 1 || a.Devices[0].Product == nil || a.Devices[0].Product.Name != "Lamp" {
+		t.Errorf("devices = %+v, want device with expanded product", a.Devices)
+	}
+	if len(a.Clusters) != 1 || len(a.Clusters[0].Devices) != 1 {
+		t.Errorf("clusters = %+v, want cluster with expanded device", a.Clusters)
+	}
+
+	p, err := client.Products.GetById(product, api.Expand("devices", api.Expand("clusters")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Devices) != 1 || len(p.Devices[0].Clusters) != 1 || p.Devices[0].Clusters[0].GetId() != cluster {
+		t.Errorf("product devices = %+v, want device with expanded cluster", p.Devices)
+	}
+
+	g, err := client.Usergroups.GetById(group, api.Expand("memberships", api.Expand("user")).Expand("directory", api.Expand("usergroups")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Memberships) != 1 || g.Memberships[0].User == nil || g.Memberships[0].User.Username != "jane" {
+		t.Errorf("memberships = %+v, want membership with expanded user", g.Memberships)
+	}
+	if g.Directory == nil || len(g.Directory.Usergroups) != 1 {
+		t.Errorf("directory = %+v, want expanded with 1 usergroup", g.Directory)
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
     "bytes"
+    "context"
     "encoding/json" 
     "fmt"   
     "net/http"    
@@ -12,20 +13,32 @@ import (
 // https://tenant-name.cloudthing.io/api/v1/exports
 type ExportsService interface {
     GetById(string, ...interface{}) (*Export, error)
+    GetByIdContext(context.Context, string, ...interface{}) (*Export, error)
     GetByLink(string, ...interface{}) (*Export, error)
+    GetByLinkContext(context.Context, string, ...interface{}) (*Export, error)
     ListByLink(string, ...interface{}) ([]Export, *ListParams, error)
+    ListByLinkContext(context.Context, string, ...interface{}) ([]Export, *ListParams, error)
+    IterateByLink(context.Context, string, ...interface{}) *Iterator[Export]
     ListByApplication(string, ...interface{}) ([]Export, *ListParams, error)
+    ListByApplicationContext(context.Context, string, ...interface{}) ([]Export, *ListParams, error)
+    IterateByApplication(context.Context, string, ...interface{}) *Iterator[Export]
     List(...interface{}) ([]Export, *ListParams, error)
+    ListContext(context.Context, ...interface{}) ([]Export, *ListParams, error)
+    Iterate(context.Context, ...interface{}) *Iterator[Export]
     CreateByLink(string, *ExportRequestCreate) (*Export, error)
+    CreateByLinkContext(context.Context, string, *ExportRequestCreate) (*Export, error)
     CreateByApplication(string, *ExportRequestCreate) (*Export, error)
+    CreateByApplicationContext(context.Context, string, *ExportRequestCreate) (*Export, error)
     UpdateById(string, *ExportRequestUpdate) (*Export, error)
+    UpdateByIdContext(context.Context, string, *ExportRequestUpdate) (*Export, error)
     UpdateByLink(string, *ExportRequestUpdate) (*Export, error)
+    UpdateByLinkContext(context.Context, string, *ExportRequestUpdate) (*Export, error)
     Delete(*Export) (error)
+    DeleteContext(context.Context, *Export) (error)
     DeleteByLink(string) (error)
+    DeleteByLinkContext(context.Context, string) (error)
     DeleteById(string) (error)
-
-    get(*ExportResponse) (*Export, error)
-    getCollection(*ExportsResponse) ([]Export, *ListParams, error)
+    DeleteByIdContext(context.Context, string) (error)
 }
 
 // ExportsServiceOp handles communication with Exports related methods of API
@@ -210,15 +223,27 @@ func (t *Export) Delete() error {
 
 // GetById retrieves apikey by its ID
 func (s *ExportsServiceOp) GetById(id string, args ...interface{}) (*Export, error) {
+    return s.GetByIdContext(context.Background(), id, args...)
+}
+
+// GetByIdContext is like GetById but uses ctx for the underlying request.
+func (s *ExportsServiceOp) GetByIdContext(ctx context.Context, id string, args ...interface{}) (*Export, error) {
+    ctx = withOperation(ctx, "Exports", "GetById")
     endpoint := "exports/"
     endpoint = fmt.Sprintf("%s%s", endpoint, id)
 
-    return s.GetByLink(endpoint, args...)
+    return s.GetByLinkContext(ctx, endpoint, args...)
 }
 
 // GetById retrieves apikey by its full link
 func (s *ExportsServiceOp) GetByLink(endpoint string, args ...interface{}) (*Export, error) {
-    resp, err := s.client.request("GET", endpoint, nil, args...)
+    return s.GetByLinkContext(context.Background(), endpoint, args...)
+}
+
+// GetByLinkContext is like GetByLink but uses ctx for the underlying request.
+func (s *ExportsServiceOp) GetByLinkContext(ctx context.Context, endpoint string, args ...interface{}) (*Export, error) {
+    ctx = withOperation(ctx, "Exports", "GetByLink")
+    resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
     if err != nil {
         return nil, err
     }
@@ -226,7 +251,7 @@ func (s *ExportsServiceOp) GetByLink(endpoint string, args ...interface{}) (*Exp
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &ExportResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -270,7 +295,7 @@ func (s *ExportsServiceOp) get(r *ExportResponse) (*Export, error) {
         }
         ten := &TenantResponse{}
         json.Unmarshal(bytes, ten)
-        t, err := s.client.Tenant.get(ten)
+        t, err := s.client.conv.tenant.get(ten)
         if err != nil {
             return nil, err
         }
@@ -284,7 +309,7 @@ func (s *ExportsServiceOp) get(r *ExportResponse) (*Export, error) {
             }
             ten := &ProductResponse{}
             json.Unmarshal(bytes, ten)
-            t, err := s.client.Products.get(ten)
+            t, err := s.client.conv.products.get(ten)
             if err != nil {
                 return nil, err
             }
@@ -298,7 +323,7 @@ func (s *ExportsServiceOp) get(r *ExportResponse) (*Export, error) {
         }
         ten := &ProductResponse{}
         json.Unmarshal(bytes, ten)
-        t, err := s.client.Products.get(ten)
+        t, err := s.client.conv.products.get(ten)
         if err != nil {
             return nil, err
         }
@@ -311,7 +336,7 @@ func (s *ExportsServiceOp) get(r *ExportResponse) (*Export, error) {
         }
         ten := &ApplicationResponse{}
         json.Unmarshal(bytes, ten)
-        t, err := s.client.Applications.get(ten)
+        t, err := s.client.conv.applications.get(ten)
         if err != nil {
             return nil, err
         }
@@ -345,21 +370,53 @@ func (s *ExportsServiceOp) getCollection(r *ExportsResponse) ([]Export, *ListPar
 
 // GetById retrieves collection of exports of current tenant
 func (s *ExportsServiceOp) ListByApplication(id string, args ...interface{}) ([]Export, *ListParams, error) {
+    return s.ListByApplicationContext(context.Background(), id, args...)
+}
+
+// ListByApplicationContext is like ListByApplication but uses ctx for the underlying request.
+func (s *ExportsServiceOp) ListByApplicationContext(ctx context.Context, id string, args ...interface{}) ([]Export, *ListParams, error) {
+    ctx = withOperation(ctx, "Exports", "ListByApplication")
+    endpoint := fmt.Sprintf("applications/%s/exports", id)
+    return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// IterateByApplication is like ListByApplication but returns iterator walking over all pages
+func (s *ExportsServiceOp) IterateByApplication(ctx context.Context, id string, args ...interface{}) *Iterator[Export] {
+    ctx = withOperation(ctx, "Exports", "IterateByApplication")
     endpoint := fmt.Sprintf("applications/%s/exports", id)
-    return s.ListByLink(endpoint, args...)
+    return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of exports of current tenant
 func (s *ExportsServiceOp) List(args ...interface{}) ([]Export, *ListParams, error) {
-    endpoint := fmt.Sprintf("tenants/%s/exports", s.client.tenantId)
-    return s.ListByLink(endpoint, args...)
+    return s.ListContext(context.Background(), args...)
+}
+
+// ListContext is like List but uses ctx for the underlying request.
+func (s *ExportsServiceOp) ListContext(ctx context.Context, args ...interface{}) ([]Export, *ListParams, error) {
+    ctx = withOperation(ctx, "Exports", "List")
+    endpoint := fmt.Sprintf("tenants/%s/exports", s.client.currentTenantId(ctx))
+    return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// Iterate is like List but returns iterator walking over all pages
+func (s *ExportsServiceOp) Iterate(ctx context.Context, args ...interface{}) *Iterator[Export] {
+    ctx = withOperation(ctx, "Exports", "Iterate")
+    endpoint := fmt.Sprintf("tenants/%s/exports", s.client.currentTenantId(ctx))
+    return s.IterateByLink(ctx, endpoint, args...)
 }
 
 
 
 // GetById retrieves collection of exports by link
 func (s *ExportsServiceOp) ListByLink(endpoint string, args ...interface{}) ([]Export, *ListParams, error) {
-    resp, err := s.client.request("GET", endpoint, nil, args...)
+    return s.ListByLinkContext(context.Background(), endpoint, args...)
+}
+
+// ListByLinkContext is like ListByLink but uses ctx for the underlying request.
+func (s *ExportsServiceOp) ListByLinkContext(ctx context.Context, endpoint string, args ...interface{}) ([]Export, *ListParams, error) {
+    ctx = withOperation(ctx, "Exports", "ListByLink")
+    resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
     if err != nil {
         return nil, nil, err
     }
@@ -367,7 +424,7 @@ func (s *ExportsServiceOp) ListByLink(endpoint string, args ...interface{}) ([]E
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, nil, newApiError(resp, "non-ok status returned")
     }
     obj := &ExportsResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -376,14 +433,32 @@ func (s *ExportsServiceOp) ListByLink(endpoint string, args ...interface{}) ([]E
     return s.getCollection(obj)
 }
 
+// IterateByLink is like ListByLink but returns iterator walking over all pages
+func (s *ExportsServiceOp) IterateByLink(ctx context.Context, endpoint string, args ...interface{}) *Iterator[Export] {
+    ctx = withOperation(ctx, "Exports", "IterateByLink")
+    return newIterator(ctx, endpoint, s.ListByLinkContext, args...)
+}
+
 // GetById updates apikey with specified ID
 func (s *ExportsServiceOp) UpdateById(id string, t *ExportRequestUpdate) (*Export, error) {
+    return s.UpdateByIdContext(context.Background(), id, t)
+}
+
+// UpdateByIdContext is like UpdateById but uses ctx for the underlying request.
+func (s *ExportsServiceOp) UpdateByIdContext(ctx context.Context, id string, t *ExportRequestUpdate) (*Export, error) {
+    ctx = withOperation(ctx, "Exports", "UpdateById")
     endpoint := fmt.Sprintf("exports/%s", id)
-    return s.UpdateByLink(endpoint, t)
+    return s.UpdateByLinkContext(ctx, endpoint, t)
 }
 
 // GetById updates apikey specified by link
 func (s *ExportsServiceOp) UpdateByLink(endpoint string, t *ExportRequestUpdate) (*Export, error) {
+    return s.UpdateByLinkContext(context.Background(), endpoint, t)
+}
+
+// UpdateByLinkContext is like UpdateByLink but uses ctx for the underlying request.
+func (s *ExportsServiceOp) UpdateByLinkContext(ctx context.Context, endpoint string, t *ExportRequestUpdate) (*Export, error) {
+    ctx = withOperation(ctx, "Exports", "UpdateByLink")
     enc, err := json.Marshal(t)
     if err != nil {
         return nil, err
@@ -391,7 +466,7 @@ func (s *ExportsServiceOp) UpdateByLink(endpoint string, t *ExportRequestUpdate)
 
     buf := bytes.NewBuffer(enc)
 
-    resp, err := s.client.request("POST", endpoint, buf)
+    resp, err := s.client.request(ctx, "POST", endpoint, buf)
     if err != nil {
         return nil, err
     }
@@ -399,7 +474,7 @@ func (s *ExportsServiceOp) UpdateByLink(endpoint string, t *ExportRequestUpdate)
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &ExportResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -408,12 +483,24 @@ func (s *ExportsServiceOp) UpdateByLink(endpoint string, t *ExportRequestUpdate)
 }
 
 func (s *ExportsServiceOp) CreateByApplication(id string, dir *ExportRequestCreate) (*Export, error) {
+    return s.CreateByApplicationContext(context.Background(), id, dir)
+}
+
+// CreateByApplicationContext is like CreateByApplication but uses ctx for the underlying request.
+func (s *ExportsServiceOp) CreateByApplicationContext(ctx context.Context, id string, dir *ExportRequestCreate) (*Export, error) {
+    ctx = withOperation(ctx, "Exports", "CreateByApplication")
     endpoint := fmt.Sprintf("applications/%s/exports", id)
-    return s.CreateByLink(endpoint, dir)
+    return s.CreateByLinkContext(ctx, endpoint, dir)
 }
 
 // Create creates new apikey within tenant
 func (s *ExportsServiceOp) CreateByLink(endpoint string, dir *ExportRequestCreate) (*Export, error) {
+    return s.CreateByLinkContext(context.Background(), endpoint, dir)
+}
+
+// CreateByLinkContext is like CreateByLink but uses ctx for the underlying request.
+func (s *ExportsServiceOp) CreateByLinkContext(ctx context.Context, endpoint string, dir *ExportRequestCreate) (*Export, error) {
+    ctx = withOperation(ctx, "Exports", "CreateByLink")
     enc, err := json.Marshal(dir)
     if err != nil {
         return nil, err
@@ -421,7 +508,7 @@ func (s *ExportsServiceOp) CreateByLink(endpoint string, dir *ExportRequestCreat
 
     buf := bytes.NewBuffer(enc)
 
-    resp, err := s.client.request("POST", endpoint, buf)
+    resp, err := s.client.request(ctx, "POST", endpoint, buf)
     if err != nil {
         return nil, err
     }
@@ -429,7 +516,7 @@ func (s *ExportsServiceOp) CreateByLink(endpoint string, dir *ExportRequestCreat
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusCreated {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &ExportResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -439,18 +526,36 @@ func (s *ExportsServiceOp) CreateByLink(endpoint string, dir *ExportRequestCreat
 
 // Delete removes apikey
 func (s *ExportsServiceOp) Delete(t *Export) (error) {
-    return s.DeleteByLink(t.Href)
+    return s.DeleteContext(context.Background(), t)
+}
+
+// DeleteContext is like Delete but uses ctx for the underlying request.
+func (s *ExportsServiceOp) DeleteContext(ctx context.Context, t *Export) (error) {
+    ctx = withOperation(ctx, "Exports", "Delete")
+    return s.DeleteByLinkContext(ctx, t.Href)
 }
 
 // Delete removes apikey by ID
 func (s *ExportsServiceOp) DeleteById(id string) (error) {
+    return s.DeleteByIdContext(context.Background(), id)
+}
+
+// DeleteByIdContext is like DeleteById but uses ctx for the underlying request.
+func (s *ExportsServiceOp) DeleteByIdContext(ctx context.Context, id string) (error) {
+    ctx = withOperation(ctx, "Exports", "DeleteById")
     endpoint := fmt.Sprintf("exports/%s", id)
-    return s.DeleteByLink(endpoint)
+    return s.DeleteByLinkContext(ctx, endpoint)
 }
 
 // Delete removes apikey by link
 func (s *ExportsServiceOp) DeleteByLink(endpoint string) (error) {
-    resp, err := s.client.request("DELETE", endpoint, nil)
+    return s.DeleteByLinkContext(context.Background(), endpoint)
+}
+
+// DeleteByLinkContext is like DeleteByLink but uses ctx for the underlying request.
+func (s *ExportsServiceOp) DeleteByLinkContext(ctx context.Context, endpoint string) (error) {
+    ctx = withOperation(ctx, "Exports", "DeleteByLink")
+    resp, err := s.client.request(ctx, "DELETE", endpoint, nil)
     if err != nil {
         return err
     }
@@ -458,7 +563,7 @@ func (s *ExportsServiceOp) DeleteByLink(endpoint string) (error) {
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusNoContent {
-        return ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return newApiError(resp, "non-ok status returned")
     }
     return nil
 }
@@ -2,6 +2,7 @@ package api
 
 import (
     "bytes"
+    "context"
     "encoding/json" 
     "fmt"   
     "net/http"    
@@ -12,20 +13,32 @@ import (
 // https://tenant-name.cloudthing.io/api/v1/groups
 type GroupsService interface {
     GetById(string, ...interface{}) (*Group, error)
+    GetByIdContext(context.Context, string, ...interface{}) (*Group, error)
     GetByLink(string, ...interface{}) (*Group, error)
+    GetByLinkContext(context.Context, string, ...interface{}) (*Group, error)
     ListByLink(string, ...interface{}) ([]Group, *ListParams, error)
+    ListByLinkContext(context.Context, string, ...interface{}) ([]Group, *ListParams, error)
+    IterateByLink(context.Context, string, ...interface{}) *Iterator[Group]
     ListByCluster(string, ...interface{}) ([]Group, *ListParams, error)
+    ListByClusterContext(context.Context, string, ...interface{}) ([]Group, *ListParams, error)
+    IterateByCluster(context.Context, string, ...interface{}) *Iterator[Group]
     ListByDevice(string, ...interface{}) ([]Group, *ListParams, error)
+    ListByDeviceContext(context.Context, string, ...interface{}) ([]Group, *ListParams, error)
+    IterateByDevice(context.Context, string, ...interface{}) *Iterator[Group]
     CreateByLink(string, *GroupRequestCreate) (*Group, error)
+    CreateByLinkContext(context.Context, string, *GroupRequestCreate) (*Group, error)
     CreateByCluster(string, *GroupRequestCreate) (*Group, error)
+    CreateByClusterContext(context.Context, string, *GroupRequestCreate) (*Group, error)
     UpdateById(string, *GroupRequestUpdate) (*Group, error)
+    UpdateByIdContext(context.Context, string, *GroupRequestUpdate) (*Group, error)
     UpdateByLink(string, *GroupRequestUpdate) (*Group, error)
+    UpdateByLinkContext(context.Context, string, *GroupRequestUpdate) (*Group, error)
     Delete(*Group) (error)
+    DeleteContext(context.Context, *Group) (error)
     DeleteByLink(string) (error)
+    DeleteByLinkContext(context.Context, string) (error)
     DeleteById(string) (error)
-
-    get(*GroupResponse) (*Group, error)
-    getCollection(*GroupsResponse) ([]Group, *ListParams, error)
+    DeleteByIdContext(context.Context, string) (error)
 }
 
 // GroupsServiceOp handles communication with Groups related methods of API
@@ -169,15 +182,27 @@ func (t *Group) Delete() error {
 
 // GetById retrieves apikey by its ID
 func (s *GroupsServiceOp) GetById(id string, args ...interface{}) (*Group, error) {
+    return s.GetByIdContext(context.Background(), id, args...)
+}
+
+// GetByIdContext is like GetById but uses ctx for the underlying request.
+func (s *GroupsServiceOp) GetByIdContext(ctx context.Context, id string, args ...interface{}) (*Group, error) {
+    ctx = withOperation(ctx, "Groups", "GetById")
     endpoint := "groups/"
     endpoint = fmt.Sprintf("%s%s", endpoint, id)
 
-    return s.GetByLink(endpoint, args...)
+    return s.GetByLinkContext(ctx, endpoint, args...)
 }
 
 // GetById retrieves apikey by its full link
 func (s *GroupsServiceOp) GetByLink(endpoint string, args ...interface{}) (*Group, error) {
-    resp, err := s.client.request("GET", endpoint, nil, args...)
+    return s.GetByLinkContext(context.Background(), endpoint, args...)
+}
+
+// GetByLinkContext is like GetByLink but uses ctx for the underlying request.
+func (s *GroupsServiceOp) GetByLinkContext(ctx context.Context, endpoint string, args ...interface{}) (*Group, error) {
+    ctx = withOperation(ctx, "Groups", "GetByLink")
+    resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
     if err != nil {
         return nil, err
     }
@@ -185,7 +210,7 @@ func (s *GroupsServiceOp) GetByLink(endpoint string, args ...interface{}) (*Grou
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &GroupResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -220,7 +245,7 @@ func (s *GroupsServiceOp) get(r *GroupResponse) (*Group, error) {
         }
         ten := &TenantResponse{}
         json.Unmarshal(bytes, ten)
-        t, err := s.client.Tenant.get(ten)
+        t, err := s.client.conv.tenant.get(ten)
         if err != nil {
             return nil, err
         }
@@ -233,7 +258,7 @@ func (s *GroupsServiceOp) get(r *GroupResponse) (*Group, error) {
         }
         ten := &ApplicationResponse{}
         json.Unmarshal(bytes, ten)
-        t, err := s.client.Applications.get(ten)
+        t, err := s.client.conv.applications.get(ten)
         if err != nil {
             return nil, err
         }
@@ -246,7 +271,7 @@ func (s *GroupsServiceOp) get(r *GroupResponse) (*Group, error) {
         }
         ten := &DevicesResponse{}
         json.Unmarshal(bytes, ten)
-        t, _, err := s.client.Devices.getCollection(ten)
+        t, _, err := s.client.conv.devices.getCollection(ten)
         if err != nil {
             return nil, err
         }
@@ -259,7 +284,7 @@ func (s *GroupsServiceOp) get(r *GroupResponse) (*Group, error) {
         }
         ten := &GroupMembershipsResponse{}
         json.Unmarshal(bytes, ten)
-        t, _, err := s.client.GroupMemberships.getCollection(ten)
+        t, _, err := s.client.conv.groupMemberships.getCollection(ten)
         if err != nil {
             return nil, err
         }
@@ -272,7 +297,7 @@ func (s *GroupsServiceOp) get(r *GroupResponse) (*Group, error) {
         }
         ten := &ClusterResponse{}
         json.Unmarshal(bytes, ten)
-        t, err := s.client.Clusters.get(ten)
+        t, err := s.client.conv.clusters.get(ten)
         if err != nil {
             return nil, err
         }
@@ -306,19 +331,51 @@ func (s *GroupsServiceOp) getCollection(r *GroupsResponse) ([]Group, *ListParams
 
 // GetById retrieves collection of groups of current tenant
 func (s *GroupsServiceOp) ListByCluster(id string, args ...interface{}) ([]Group, *ListParams, error) {
+    return s.ListByClusterContext(context.Background(), id, args...)
+}
+
+// ListByClusterContext is like ListByCluster but uses ctx for the underlying request.
+func (s *GroupsServiceOp) ListByClusterContext(ctx context.Context, id string, args ...interface{}) ([]Group, *ListParams, error) {
+    ctx = withOperation(ctx, "Groups", "ListByCluster")
     endpoint := fmt.Sprintf("clusters/%s/groups", id)
-    return s.ListByLink(endpoint, args...)
+    return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// IterateByCluster is like ListByCluster but returns iterator walking over all pages
+func (s *GroupsServiceOp) IterateByCluster(ctx context.Context, id string, args ...interface{}) *Iterator[Group] {
+    ctx = withOperation(ctx, "Groups", "IterateByCluster")
+    endpoint := fmt.Sprintf("clusters/%s/groups", id)
+    return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of groups of current tenant
 func (s *GroupsServiceOp) ListByDevice(id string, args ...interface{}) ([]Group, *ListParams, error) {
+    return s.ListByDeviceContext(context.Background(), id, args...)
+}
+
+// ListByDeviceContext is like ListByDevice but uses ctx for the underlying request.
+func (s *GroupsServiceOp) ListByDeviceContext(ctx context.Context, id string, args ...interface{}) ([]Group, *ListParams, error) {
+    ctx = withOperation(ctx, "Groups", "ListByDevice")
+    endpoint := fmt.Sprintf("devices/%s/groups", id)
+    return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// IterateByDevice is like ListByDevice but returns iterator walking over all pages
+func (s *GroupsServiceOp) IterateByDevice(ctx context.Context, id string, args ...interface{}) *Iterator[Group] {
+    ctx = withOperation(ctx, "Groups", "IterateByDevice")
     endpoint := fmt.Sprintf("devices/%s/groups", id)
-    return s.ListByLink(endpoint, args...)
+    return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of groups by link
 func (s *GroupsServiceOp) ListByLink(endpoint string, args ...interface{}) ([]Group, *ListParams, error) {
-    resp, err := s.client.request("GET", endpoint, nil, args...)
+    return s.ListByLinkContext(context.Background(), endpoint, args...)
+}
+
+// ListByLinkContext is like ListByLink but uses ctx for the underlying request.
+func (s *GroupsServiceOp) ListByLinkContext(ctx context.Context, endpoint string, args ...interface{}) ([]Group, *ListParams, error) {
+    ctx = withOperation(ctx, "Groups", "ListByLink")
+    resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
     if err != nil {
         return nil, nil, err
     }
@@ -326,7 +383,7 @@ func (s *GroupsServiceOp) ListByLink(endpoint string, args ...interface{}) ([]Gr
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, nil, newApiError(resp, "non-ok status returned")
     }
     obj := &GroupsResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -335,14 +392,32 @@ func (s *GroupsServiceOp) ListByLink(endpoint string, args ...interface{}) ([]Gr
     return s.getCollection(obj)
 }
 
+// IterateByLink is like ListByLink but returns iterator walking over all pages
+func (s *GroupsServiceOp) IterateByLink(ctx context.Context, endpoint string, args ...interface{}) *Iterator[Group] {
+    ctx = withOperation(ctx, "Groups", "IterateByLink")
+    return newIterator(ctx, endpoint, s.ListByLinkContext, args...)
+}
+
 // GetById updates apikey with specified ID
 func (s *GroupsServiceOp) UpdateById(id string, t *GroupRequestUpdate) (*Group, error) {
+    return s.UpdateByIdContext(context.Background(), id, t)
+}
+
+// UpdateByIdContext is like UpdateById but uses ctx for the underlying request.
+func (s *GroupsServiceOp) UpdateByIdContext(ctx context.Context, id string, t *GroupRequestUpdate) (*Group, error) {
+    ctx = withOperation(ctx, "Groups", "UpdateById")
     endpoint := fmt.Sprintf("groups/%s", id)
-    return s.UpdateByLink(endpoint, t)
+    return s.UpdateByLinkContext(ctx, endpoint, t)
 }
 
 // GetById updates apikey specified by link
 func (s *GroupsServiceOp) UpdateByLink(endpoint string, t *GroupRequestUpdate) (*Group, error) {
+    return s.UpdateByLinkContext(context.Background(), endpoint, t)
+}
+
+// UpdateByLinkContext is like UpdateByLink but uses ctx for the underlying request.
+func (s *GroupsServiceOp) UpdateByLinkContext(ctx context.Context, endpoint string, t *GroupRequestUpdate) (*Group, error) {
+    ctx = withOperation(ctx, "Groups", "UpdateByLink")
     enc, err := json.Marshal(t)
     if err != nil {
         return nil, err
@@ -350,7 +425,7 @@ func (s *GroupsServiceOp) UpdateByLink(endpoint string, t *GroupRequestUpdate) (
 
     buf := bytes.NewBuffer(enc)
 
-    resp, err := s.client.request("POST", endpoint, buf)
+    resp, err := s.client.request(ctx, "POST", endpoint, buf)
     if err != nil {
         return nil, err
     }
@@ -358,7 +433,7 @@ func (s *GroupsServiceOp) UpdateByLink(endpoint string, t *GroupRequestUpdate) (
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &GroupResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -367,12 +442,24 @@ func (s *GroupsServiceOp) UpdateByLink(endpoint string, t *GroupRequestUpdate) (
 }
 
 func (s *GroupsServiceOp) CreateByCluster(id string, dir *GroupRequestCreate) (*Group, error) {
+    return s.CreateByClusterContext(context.Background(), id, dir)
+}
+
+// CreateByClusterContext is like CreateByCluster but uses ctx for the underlying request.
+func (s *GroupsServiceOp) CreateByClusterContext(ctx context.Context, id string, dir *GroupRequestCreate) (*Group, error) {
+    ctx = withOperation(ctx, "Groups", "CreateByCluster")
     endpoint := fmt.Sprintf("clusters/%s/groups", id)
-    return s.CreateByLink(endpoint, dir)
+    return s.CreateByLinkContext(ctx, endpoint, dir)
 }
 
 // Create creates new apikey within tenant
 func (s *GroupsServiceOp) CreateByLink(endpoint string, dir *GroupRequestCreate) (*Group, error) {
+    return s.CreateByLinkContext(context.Background(), endpoint, dir)
+}
+
+// CreateByLinkContext is like CreateByLink but uses ctx for the underlying request.
+func (s *GroupsServiceOp) CreateByLinkContext(ctx context.Context, endpoint string, dir *GroupRequestCreate) (*Group, error) {
+    ctx = withOperation(ctx, "Groups", "CreateByLink")
     enc, err := json.Marshal(dir)
     if err != nil {
         return nil, err
@@ -380,7 +467,7 @@ func (s *GroupsServiceOp) CreateByLink(endpoint string, dir *GroupRequestCreate)
 
     buf := bytes.NewBuffer(enc)
 
-    resp, err := s.client.request("POST", endpoint, buf)
+    resp, err := s.client.request(ctx, "POST", endpoint, buf)
     if err != nil {
         return nil, err
     }
@@ -388,7 +475,7 @@ func (s *GroupsServiceOp) CreateByLink(endpoint string, dir *GroupRequestCreate)
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusCreated {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &GroupResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -398,18 +485,36 @@ func (s *GroupsServiceOp) CreateByLink(endpoint string, dir *GroupRequestCreate)
 
 // Delete removes apikey
 func (s *GroupsServiceOp) Delete(t *Group) (error) {
-    return s.DeleteByLink(t.Href)
+    return s.DeleteContext(context.Background(), t)
+}
+
+// DeleteContext is like Delete but uses ctx for the underlying request.
+func (s *GroupsServiceOp) DeleteContext(ctx context.Context, t *Group) (error) {
+    ctx = withOperation(ctx, "Groups", "Delete")
+    return s.DeleteByLinkContext(ctx, t.Href)
 }
 
 // Delete removes apikey by ID
 func (s *GroupsServiceOp) DeleteById(id string) (error) {
+    return s.DeleteByIdContext(context.Background(), id)
+}
+
+// DeleteByIdContext is like DeleteById but uses ctx for the underlying request.
+func (s *GroupsServiceOp) DeleteByIdContext(ctx context.Context, id string) (error) {
+    ctx = withOperation(ctx, "Groups", "DeleteById")
     endpoint := fmt.Sprintf("groups/%s", id)
-    return s.DeleteByLink(endpoint)
+    return s.DeleteByLinkContext(ctx, endpoint)
 }
 
 // Delete removes apikey by link
 func (s *GroupsServiceOp) DeleteByLink(endpoint string) (error) {
-    resp, err := s.client.request("DELETE", endpoint, nil)
+    return s.DeleteByLinkContext(context.Background(), endpoint)
+}
+
+// DeleteByLinkContext is like DeleteByLink but uses ctx for the underlying request.
+func (s *GroupsServiceOp) DeleteByLinkContext(ctx context.Context, endpoint string) (error) {
+    ctx = withOperation(ctx, "Groups", "DeleteByLink")
+    resp, err := s.client.request(ctx, "DELETE", endpoint, nil)
     if err != nil {
         return err
     }
@@ -417,7 +522,7 @@ func (s *GroupsServiceOp) DeleteByLink(endpoint string) (error) {
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusNoContent {
-        return ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return newApiError(resp, "non-ok status returned")
     }
     return nil
 }
@@ -2,6 +2,7 @@ package api
 
 import (
     "bytes"
+    "context"
     "encoding/json" 
     "fmt"   
     "net/http"
@@ -13,19 +14,30 @@ import (
 // https://tenant-name.cloudthing.io/api/v1/groupMemberships
 type GroupMembershipsService interface {
     GetById(string, ...interface{}) (*GroupMembership, error)
+    GetByIdContext(context.Context, string, ...interface{}) (*GroupMembership, error)
     GetByLink(string, ...interface{}) (*GroupMembership, error)
+    GetByLinkContext(context.Context, string, ...interface{}) (*GroupMembership, error)
     ListByLink(string, ...interface{}) ([]GroupMembership, *ListParams, error)
+    ListByLinkContext(context.Context, string, ...interface{}) ([]GroupMembership, *ListParams, error)
+    IterateByLink(context.Context, string, ...interface{}) *Iterator[GroupMembership]
     ListByDevice(string, ...interface{}) ([]GroupMembership, *ListParams, error)
+    ListByDeviceContext(context.Context, string, ...interface{}) ([]GroupMembership, *ListParams, error)
+    IterateByDevice(context.Context, string, ...interface{}) *Iterator[GroupMembership]
     ListByGroup(string, ...interface{}) ([]GroupMembership, *ListParams, error)
+    ListByGroupContext(context.Context, string, ...interface{}) ([]GroupMembership, *ListParams, error)
+    IterateByGroup(context.Context, string, ...interface{}) *Iterator[GroupMembership]
     CreateByLink(string, *GroupMembershipRequestCreate) (*GroupMembership, error)
+    CreateByLinkContext(context.Context, string, *GroupMembershipRequestCreate) (*GroupMembership, error)
     CreateByDevice(string, *GroupMembershipRequestCreate) (*GroupMembership, error)
+    CreateByDeviceContext(context.Context, string, *GroupMembershipRequestCreate) (*GroupMembership, error)
     CreateByGroup(string, *GroupMembershipRequestCreate) (*GroupMembership, error)
+    CreateByGroupContext(context.Context, string, *GroupMembershipRequestCreate) (*GroupMembership, error)
     Delete(*GroupMembership) (error)
+    DeleteContext(context.Context, *GroupMembership) (error)
     DeleteByLink(string) (error)
+    DeleteByLinkContext(context.Context, string) (error)
     DeleteById(string) (error)
-
-    get(*GroupMembershipResponse) (*GroupMembership, error)
-    getCollection(*GroupMembershipsResponse) ([]GroupMembership, *ListParams, error)
+    DeleteByIdContext(context.Context, string) (error)
 }
 
 // GroupMembershipsServiceOp handles communication with GroupMemberships related methods of API
@@ -96,15 +108,27 @@ func (t *GroupMembership) Delete() error {
 
 // GetById retrieves product by its ID
 func (s *GroupMembershipsServiceOp) GetById(id string, args ...interface{}) (*GroupMembership, error) {
+    return s.GetByIdContext(context.Background(), id, args...)
+}
+
+// GetByIdContext is like GetById but uses ctx for the underlying request.
+func (s *GroupMembershipsServiceOp) GetByIdContext(ctx context.Context, id string, args ...interface{}) (*GroupMembership, error) {
+    ctx = withOperation(ctx, "GroupMemberships", "GetById")
     endpoint := "groupMemberships/"
     endpoint = fmt.Sprintf("%s%s", endpoint, id)
 
-    return s.GetByLink(endpoint, args...)
+    return s.GetByLinkContext(ctx, endpoint, args...)
 }
 
 // GetById retrieves product by its full link
 func (s *GroupMembershipsServiceOp) GetByLink(endpoint string, args ...interface{}) (*GroupMembership, error) {
-    resp, err := s.client.request("GET", endpoint, nil, args...)
+    return s.GetByLinkContext(context.Background(), endpoint, args...)
+}
+
+// GetByLinkContext is like GetByLink but uses ctx for the underlying request.
+func (s *GroupMembershipsServiceOp) GetByLinkContext(ctx context.Context, endpoint string, args ...interface{}) (*GroupMembership, error) {
+    ctx = withOperation(ctx, "GroupMemberships", "GetByLink")
+    resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
     if err != nil {
         return nil, err
     }
@@ -112,7 +136,7 @@ func (s *GroupMembershipsServiceOp) GetByLink(endpoint string, args ...interface
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &GroupMembershipResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -138,7 +162,7 @@ func (s *GroupMembershipsServiceOp) get(r *GroupMembershipResponse) (*GroupMembe
         }
         ten := &DeviceResponse{}
         json.Unmarshal(bytes, ten)
-        t, err := s.client.Devices.get(ten)
+        t, err := s.client.conv.devices.get(ten)
         if err != nil {
             return nil, err
         }
@@ -151,7 +175,7 @@ func (s *GroupMembershipsServiceOp) get(r *GroupMembershipResponse) (*GroupMembe
         }
         ten := &GroupResponse{}
         json.Unmarshal(bytes, ten)
-        t, err := s.client.Groups.get(ten)
+        t, err := s.client.conv.groups.get(ten)
         if err != nil {
             return nil, err
         }
@@ -185,19 +209,51 @@ func (s *GroupMembershipsServiceOp) getCollection(r *GroupMembershipsResponse) (
 
 // GetById retrieves collection of groupMemberships of current tenant
 func (s *GroupMembershipsServiceOp) ListByDevice(id string, args ...interface{}) ([]GroupMembership, *ListParams, error) {
+    return s.ListByDeviceContext(context.Background(), id, args...)
+}
+
+// ListByDeviceContext is like ListByDevice but uses ctx for the underlying request.
+func (s *GroupMembershipsServiceOp) ListByDeviceContext(ctx context.Context, id string, args ...interface{}) ([]GroupMembership, *ListParams, error) {
+    ctx = withOperation(ctx, "GroupMemberships", "ListByDevice")
+    endpoint := fmt.Sprintf("devices/%s/groupMemberships", id)
+    return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// IterateByDevice is like ListByDevice but returns iterator walking over all pages
+func (s *GroupMembershipsServiceOp) IterateByDevice(ctx context.Context, id string, args ...interface{}) *Iterator[GroupMembership] {
+    ctx = withOperation(ctx, "GroupMemberships", "IterateByDevice")
     endpoint := fmt.Sprintf("devices/%s/groupMemberships", id)
-    return s.ListByLink(endpoint, args...)
+    return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of groupMemberships of current tenant
 func (s *GroupMembershipsServiceOp) ListByGroup(id string, args ...interface{}) ([]GroupMembership, *ListParams, error) {
+    return s.ListByGroupContext(context.Background(), id, args...)
+}
+
+// ListByGroupContext is like ListByGroup but uses ctx for the underlying request.
+func (s *GroupMembershipsServiceOp) ListByGroupContext(ctx context.Context, id string, args ...interface{}) ([]GroupMembership, *ListParams, error) {
+    ctx = withOperation(ctx, "GroupMemberships", "ListByGroup")
     endpoint := fmt.Sprintf("groups/%s/groupMemberships", id)
-    return s.ListByLink(endpoint, args...)
+    return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// IterateByGroup is like ListByGroup but returns iterator walking over all pages
+func (s *GroupMembershipsServiceOp) IterateByGroup(ctx context.Context, id string, args ...interface{}) *Iterator[GroupMembership] {
+    ctx = withOperation(ctx, "GroupMemberships", "IterateByGroup")
+    endpoint := fmt.Sprintf("groups/%s/groupMemberships", id)
+    return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of groupMemberships by link
 func (s *GroupMembershipsServiceOp) ListByLink(endpoint string, args ...interface{}) ([]GroupMembership, *ListParams, error) {
-    resp, err := s.client.request("GET", endpoint, nil, args...)
+    return s.ListByLinkContext(context.Background(), endpoint, args...)
+}
+
+// ListByLinkContext is like ListByLink but uses ctx for the underlying request.
+func (s *GroupMembershipsServiceOp) ListByLinkContext(ctx context.Context, endpoint string, args ...interface{}) ([]GroupMembership, *ListParams, error) {
+    ctx = withOperation(ctx, "GroupMemberships", "ListByLink")
+    resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
     if err != nil {
         return nil, nil, err
     }
@@ -205,7 +261,7 @@ func (s *GroupMembershipsServiceOp) ListByLink(endpoint string, args ...interfac
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, nil, newApiError(resp, "non-ok status returned")
     }
     obj := &GroupMembershipsResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -214,18 +270,42 @@ func (s *GroupMembershipsServiceOp) ListByLink(endpoint string, args ...interfac
     return s.getCollection(obj)
 }
 
+// IterateByLink is like ListByLink but returns iterator walking over all pages
+func (s *GroupMembershipsServiceOp) IterateByLink(ctx context.Context, endpoint string, args ...interface{}) *Iterator[GroupMembership] {
+    ctx = withOperation(ctx, "GroupMemberships", "IterateByLink")
+    return newIterator(ctx, endpoint, s.ListByLinkContext, args...)
+}
+
 func (s *GroupMembershipsServiceOp) CreateByDevice(id string, dir *GroupMembershipRequestCreate) (*GroupMembership, error) {
+    return s.CreateByDeviceContext(context.Background(), id, dir)
+}
+
+// CreateByDeviceContext is like CreateByDevice but uses ctx for the underlying request.
+func (s *GroupMembershipsServiceOp) CreateByDeviceContext(ctx context.Context, id string, dir *GroupMembershipRequestCreate) (*GroupMembership, error) {
+    ctx = withOperation(ctx, "GroupMemberships", "CreateByDevice")
     endpoint := fmt.Sprintf("devices/%s/groupMemberships", id)
-    return s.CreateByLink(endpoint, dir)
+    return s.CreateByLinkContext(ctx, endpoint, dir)
 }
 
 func (s *GroupMembershipsServiceOp) CreateByGroup(id string, dir *GroupMembershipRequestCreate) (*GroupMembership, error) {
+    return s.CreateByGroupContext(context.Background(), id, dir)
+}
+
+// CreateByGroupContext is like CreateByGroup but uses ctx for the underlying request.
+func (s *GroupMembershipsServiceOp) CreateByGroupContext(ctx context.Context, id string, dir *GroupMembershipRequestCreate) (*GroupMembership, error) {
+    ctx = withOperation(ctx, "GroupMemberships", "CreateByGroup")
     endpoint := fmt.Sprintf("groups/%s/groupMemberships", id)
-    return s.CreateByLink(endpoint, dir)
+    return s.CreateByLinkContext(ctx, endpoint, dir)
 }
 
 // Create creates new product within tenant
 func (s *GroupMembershipsServiceOp) CreateByLink(endpoint string, dir *GroupMembershipRequestCreate) (*GroupMembership, error) {
+    return s.CreateByLinkContext(context.Background(), endpoint, dir)
+}
+
+// CreateByLinkContext is like CreateByLink but uses ctx for the underlying request.
+func (s *GroupMembershipsServiceOp) CreateByLinkContext(ctx context.Context, endpoint string, dir *GroupMembershipRequestCreate) (*GroupMembership, error) {
+    ctx = withOperation(ctx, "GroupMemberships", "CreateByLink")
     enc, err := json.Marshal(dir)
     if err != nil {
         return nil, err
@@ -233,7 +313,7 @@ func (s *GroupMembershipsServiceOp) CreateByLink(endpoint string, dir *GroupMemb
 
     buf := bytes.NewBuffer(enc)
 
-    resp, err := s.client.request("POST", endpoint, buf)
+    resp, err := s.client.request(ctx, "POST", endpoint, buf)
     if err != nil {
         return nil, err
     }
@@ -241,7 +321,7 @@ func (s *GroupMembershipsServiceOp) CreateByLink(endpoint string, dir *GroupMemb
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusCreated {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &GroupMembershipResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -251,18 +331,36 @@ func (s *GroupMembershipsServiceOp) CreateByLink(endpoint string, dir *GroupMemb
 
 // Delete removes product
 func (s *GroupMembershipsServiceOp) Delete(t *GroupMembership) (error) {
-    return s.DeleteByLink(t.Href)
+    return s.DeleteContext(context.Background(), t)
+}
+
+// DeleteContext is like Delete but uses ctx for the underlying request.
+func (s *GroupMembershipsServiceOp) DeleteContext(ctx context.Context, t *GroupMembership) (error) {
+    ctx = withOperation(ctx, "GroupMemberships", "Delete")
+    return s.DeleteByLinkContext(ctx, t.Href)
 }
 
 // Delete removes product by ID
 func (s *GroupMembershipsServiceOp) DeleteById(id string) (error) {
+    return s.DeleteByIdContext(context.Background(), id)
+}
+
+// DeleteByIdContext is like DeleteById but uses ctx for the underlying request.
+func (s *GroupMembershipsServiceOp) DeleteByIdContext(ctx context.Context, id string) (error) {
+    ctx = withOperation(ctx, "GroupMemberships", "DeleteById")
     endpoint := fmt.Sprintf("groupMemberships/%s", id)
-    return s.DeleteByLink(endpoint)
+    return s.DeleteByLinkContext(ctx, endpoint)
 }
 
 // Delete removes product by link
 func (s *GroupMembershipsServiceOp) DeleteByLink(endpoint string) (error) {
-    resp, err := s.client.request("DELETE", endpoint, nil)
+    return s.DeleteByLinkContext(context.Background(), endpoint)
+}
+
+// DeleteByLinkContext is like DeleteByLink but uses ctx for the underlying request.
+func (s *GroupMembershipsServiceOp) DeleteByLinkContext(ctx context.Context, endpoint string) (error) {
+    ctx = withOperation(ctx, "GroupMemberships", "DeleteByLink")
+    resp, err := s.client.request(ctx, "DELETE", endpoint, nil)
     if err != nil {
         return err
     }
@@ -270,7 +368,7 @@ func (s *GroupMembershipsServiceOp) DeleteByLink(endpoint string) (error) {
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusNoContent {
-        return ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return newApiError(resp, "non-ok status returned")
     }
     return nil
 }
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"iter"
+)
+
+// Prefetch is an option for Iterate* methods. When passed, next page is fetched
+// concurrently while items of current page are consumed.
+var Prefetch = prefetchOption{}
+
+type prefetchOption struct{}
+
+// pageFunc retrieves single page of collection by link
+type pageFunc[T any] func(context.Context, string, ...interface{}) ([]T, *ListParams, error)
+
+type page[T any] struct {
+	items  []T
+	params *ListParams
+	err    error
+}
+
+// Iterator walks over all items of paginated collection, following Next links
+// of ListParams lazily. Iteration stops on first error which is then available via Err.
+//
+//	it := client.Devices.IterateByProduct(ctx, productId)
+//	for it.Next() {
+//		device := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type Iterator[T any] struct {
+	ctx      context.Context
+	fetch    pageFunc[T]
+	args     []interface{}
+	prefetch bool
+	// whether only the first page is requested, e.g. for the latest point
+	single bool
+	// operation named by Iterate* method, every page is requested as its own call of it
+	op *Operation
+
+	// link to page which is fetched next, empty if there are no more pages
+	link    string
+	pending chan page[T]
+	items   []T
+	current T
+	params  *ListParams
+	err     error
+}
+
+// newIterator creates iterator starting at link. Options rendered into query are
+// used only for the first page, as Next links already contain them, other args are
+// passed with every request. Relative TimeParams are resolved once, so all pages
+// share the same range.
+func newIterator[T any](ctx context.Context, link string, fetch pageFunc[T], args ...interface{}) *Iterator[T] {
+	it := &Iterator[T]{ctx: ctx, fetch: fetch, link: link, single: latestRequested(args)}
+	if op, ok := OperationFromContext(ctx); ok {
+		it.op = &op
+	}
+	for _, a := range resolveTimeParams(args) {
+		if _, ok := a.(prefetchOption); ok {
+			it.prefetch = true
+			continue
+		}
+		it.args = append(it.args, a)
+	}
+	return it
+}
+
+// NewIterator creates iterator starting at link, which retrieves pages with fetch.
+// It allows implementing Iterate* methods of services outside of this package.
+func NewIterator[T any](ctx context.Context, link string, fetch func(context.Context, string, ...interface{}) ([]T, *ListParams, error), args ...interface{}) *Iterator[T] {
+	return newIterator(ctx, link, fetch, args...)
+}
+
+// Next advances iterator to the next item. It returns false when there are
+// no more items or an error occured.
+func (it *Iterator[T]) Next() bool {
+	for len(it.items) == 0 {
+		if it.err != nil || (it.link == "" && it.pending == nil) {
+			return false
+		}
+		p := it.nextPage()
+		if p.err != nil {
+			it.err = p.err
+			return false
+		}
+		it.items = p.items
+		it.params = p.params
+		it.link = ""
+		if p.params != nil && p.params.Next != nil && p.params.Next.Href != "" && len(p.items) > 0 && !it.single {
+			it.link = p.params.Next.Href
+		}
+		if it.prefetch && it.link != "" {
+			it.pending = make(chan page[T], 1)
+			go func(link string, args []interface{}, ch chan page[T]) {
+				ch <- it.get(link, args)
+			}(it.link, it.pageArgs(), it.pending)
+			it.link = ""
+		}
+	}
+	it.current = it.items[0]
+	it.items = it.items[1:]
+	return true
+}
+
+// nextPage returns prefetched page or fetches next one
+func (it *Iterator[T]) nextPage() page[T] {
+	if it.pending != nil {
+		var p page[T]
+		select {
+		case p = <-it.pending:
+		case <-it.ctx.Done():
+			p = page[T]{err: it.ctx.Err()}
+		}
+		it.pending = nil
+		return p
+	}
+	return it.get(it.link, it.pageArgs())
+}
+
+// pageArgs returns args for next page, only the first page uses options rendered
+// into query as Next links already contain them
+func (it *Iterator[T]) pageArgs() []interface{} {
+	if it.params == nil {
+		return it.args
+	}
+	return followArgs(it.args)
+}
+
+// followArgs returns args without options rendered into query
+func followArgs(args []interface{}) []interface{} {
+	res := make([]interface{}, 0, len(args))
+	for _, a := range args {
+		switch a.(type) {
+		case nil, *ListOptions, *ExpandParams, *Expansion, *Query, *TimeParams, *PointsOptions:
+		default:
+			res = append(res, a)
+		}
+	}
+	return res
+}
+
+// get fetches page by link
+func (it *Iterator[T]) get(link string, args []interface{}) page[T] {
+	ctx := it.ctx
+	if it.op != nil {
+		ctx = newOperation(ctx, it.op.Service, it.op.Method)
+	}
+	items, params, err := it.fetch(ctx, link, args...)
+	return page[T]{items: items, params: params, err: err}
+}
+
+// Value returns current item
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Err returns error which stopped iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// ListParams returns pagination params of the most recently fetched page
+func (it *Iterator[T]) ListParams() *ListParams {
+	return it.params
+}
+
+// All returns iterator usable with range-over-func. Error stopping the iteration
+// is yielded as the last pair.
+func (it *Iterator[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for it.Next() {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+		if it.err != nil {
+			var zero T
+			yield(zero, it.err)
+		}
+	}
+}
+
+// Collect reads all remaining items into slice
+func (it *Iterator[T]) Collect() ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+	return items, it.err
+}
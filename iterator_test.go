@@ -0,0 +1,212 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+)
+
+// pages serves items split into pages of size, linked by "page/N"
+type pages struct {
+	mu    sync.Mutex
+	items []int
+	size  int
+	fail  string
+	calls []string
+	args  [][]interface{}
+}
+
+func (p *pages) fetch(ctx context.Context, link string, args ...interface{}) ([]int, *api.ListParams, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls = append(p.calls, link)
+	p.args = append(p.args, args)
+	if link == p.fail {
+		return nil, nil, errors.New("page failed")
+	}
+	var n int
+	fmt.Sscanf(link, "page/%d", &n)
+	start, end := (n-1)*p.size, n*p.size
+	if end > len(p.items) {
+		end = len(p.items)
+	}
+	params := &api.ListParams{Href: link, Page: n, Size: len(p.items), Limit: p.size}
+	if end < len(p.items) {
+		params.Next = &api.Link{Href: fmt.Sprintf("page/%d", n+1)}
+	}
+	return p.items[start:end], params, nil
+}
+
+func (p *pages) called() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.calls)
+}
+
+func TestIteratorFollowsNextLinks(t *testing.T) {
+	p := &pages{items: []int{1, 2, 3, 4, 5, 6, 7}, size: 3}
+	type marker struct{}
+	it := api.NewIterator(context.Background(), "page/1", p.fetch, &api.ListOptions{Limit: 3}, api.Expand("product"), marker{})
+
+	items, err := it.Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(items) != "[1 2 3 4 5 6 7]" {
+		t.Errorf("items = %v, want 1 to 7", items)
+	}
+	if fmt.Sprint(p.calls) != "[page/1 page/2 page/3]" {
+		t.Errorf("fetched %v, want 3 pages", p.calls)
+	}
+	if len(p.args[0]) != 3 {
+		t.Errorf("first page got args %v, want all 3", p.args[0])
+	}
+	// options rendered into query are already part of Next links
+	for i, args := range p.args[1:] {
+		if len(args) != 1 || args[0] != (marker{}) {
+			t.Errorf("page %d got args %v, want only marker", i+2, args)
+		}
+	}
+	if params := it.ListParams(); params == nil || params.Page != 3 {
+		t.Errorf("ListParams = %+v, want last page", params)
+	}
+}
+
+func TestIteratorStopsOnError(t *testing.T) {
+	p := &pages{items: []int{1, 2, 3, 4, 5}, size: 2, fail: "page/2"}
+	it := api.NewIterator(context.Background(), "page/1", p.fetch)
+
+	var items []int
+	for v, err := range it.All() {
+		if err != nil {
+			break
+		}
+		items = append(items, v)
+	}
+	if fmt.Sprint(items) != "[1 2]" {
+		t.Errorf("items = %v, want items of the first page", items)
+	}
+	if it.Err() == nil || it.Next() {
+		t.Error("iteration continued after failed page")
+	}
+	if p.called() != 2 {
+		t.Errorf("fetched %d pages, want 2", p.called())
+	}
+}
+
+func TestIteratorPrefetch(t *testing.T) {
+	p := &pages{items: []int{1, 2, 3, 4}, size: 2}
+	it := api.NewIterator(context.Background(), "page/1", p.fetch, api.Prefetch)
+
+	if !it.Next() {
+		t.Fatal(it.Err())
+	}
+	// the second page is requested while the first one is consumed
+	deadline := time.Now().Add(time.Second)
+	for p.called() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if p.called() != 2 {
+		t.Fatalf("fetched %d pages while consuming the first one, want 2", p.called())
+	}
+	items := []int{it.Value()}
+	rest, err := it.Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items = append(items, rest...); fmt.Sprint(items) != "[1 2 3 4]" {
+		t.Errorf("items = %v, want 1 to 4", items)
+	}
+}
+
+func TestIteratorPrefetchCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+	fetch := func(ctx context.Context, link string, args ...interface{}) ([]int, *api.ListParams, error) {
+		if link == "page/2" {
+			<-release
+		}
+		return []int{1}, &api.ListParams{Next: &api.Link{Href: "page/2"}}, nil
+	}
+	it := api.NewIterator(ctx, "page/1", fetch, api.Prefetch)
+	if !it.Next() {
+		t.Fatal(it.Err())
+	}
+	cancel()
+	if it.Next() {
+		t.Error("iteration continued after cancellation")
+	}
+	if !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("Err() = %v, want context canceled", it.Err())
+	}
+}
+
+func TestIterateByProduct(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	var mu sync.Mutex
+	var ops []string
+	client, err := srv.NewClient(api.WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+		return api.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if op, ok := api.OperationFromContext(req.Context()); ok {
+				mu.Lock()
+				ops = append(ops, op.Service+" "+op.Method)
+				mu.Unlock()
+			}
+			return next.RoundTrip(req)
+		})
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	product := srv.Add("products", map[string]interface{}{"name": "Lamp"}, nil)
+	for i := 0; i < 7; i++ {
+		srv.Add("devices", map[string]interface{}{}, map[string]string{"product": "products/" + product})
+	}
+
+	it := client.Devices.IterateByProduct(context.Background(), product, &api.ListOptions{Limit: 3}, api.Expand("product"), api.Prefetch)
+	devices, err := it.Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 7 {
+		t.Fatalf("got %d devices, want 7", len(devices))
+	}
+	for _, d := range devices {
+		if d.Product == nil || d.Product.Name != "Lamp" {
+			t.Errorf("device %s has product %+v, want expanded Lamp", d.GetId(), d.Product)
+		}
+	}
+
+	var lists int
+	for _, r := range srv.Requests() {
+		if r.Method != "GET" || r.Path != "products/"+product+"/devices" {
+			continue
+		}
+		lists++
+		if len(r.Query["limit"]) != 1 || len(r.Query["expand"]) != 1 {
+			t.Errorf("page requested with query %v, want single limit and expand", r.Query)
+		}
+	}
+	if lists != 3 {
+		t.Errorf("%d pages requested, want 3", lists)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(ops) < 3 {
+		t.Fatalf("operations %v, want at least 3", ops)
+	}
+	for _, op := range ops[len(ops)-3:] {
+		if op != "Devices IterateByProduct" {
+			t.Errorf("page requested as %q, want Devices IterateByProduct", op)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// LogLevel is severity of log message, values match levels of log/slog
+type LogLevel int
+
+// Levels of messages logged by Client
+const (
+	LevelDebug LogLevel = -4
+	LevelInfo  LogLevel = 0
+	LevelWarn  LogLevel = 4
+	LevelError LogLevel = 8
+)
+
+// Logger receives structured diagnostic messages of Client. Args alternate keys
+// and values, the same way as in log/slog.
+type Logger interface {
+	Enabled(ctx context.Context, level LogLevel) bool
+	Log(ctx context.Context, level LogLevel, msg string, args ...interface{})
+}
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// NewSlogLogger adapts l to Logger, nil l stands for slog.Default()
+func NewSlogLogger(l *slog.Logger) Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return slogLogger{l: l}
+}
+
+func (s slogLogger) Enabled(ctx context.Context, level LogLevel) bool {
+	return s.l.Enabled(ctx, slog.Level(level))
+}
+
+func (s slogLogger) Log(ctx context.Context, level LogLevel, msg string, args ...interface{}) {
+	s.l.Log(ctx, slog.Level(level), msg, args...)
+}
+
+// Maximum size of logged body
+const maxLoggedBody = 64 << 10
+
+// Placeholder of redacted values
+const redacted = "REDACTED"
+
+// Headers and JSON fields whose values are never logged
+var (
+	sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+	sensitiveFields  = map[string]bool{"password": true, "secret": true, "token": true}
+)
+
+// SetLogger sets logger for diagnostic messages, nil disables logging
+func (c *Client) SetLogger(l Logger) {
+	c.logger = l
+}
+
+// SetLogBodies switches logging of headers and bodies of requests and responses
+// at debug level. It may be called at any time, also while requests are in flight.
+// Credentials, passwords, secrets and tokens are redacted.
+func (c *Client) SetLogBodies(enabled bool) {
+	c.logBodies.Store(enabled)
+}
+
+// logEnabled checks whether messages of level are logged
+func (c *Client) logEnabled(ctx context.Context, level LogLevel) bool {
+	return c.logger != nil && c.logger.Enabled(ctx, level)
+}
+
+// log reports diagnostic message if logger is set
+func (c *Client) log(ctx context.Context, level LogLevel, msg string, args ...interface{}) {
+	if c.logEnabled(ctx, level) {
+		c.logger.Log(ctx, level, msg, args...)
+	}
+}
+
+// logTransport logs requests sent by rt
+func (c *Client) logTransport(rt http.RoundTripper) http.RoundTripper {
+	return RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		ctx := req.Context()
+		if !c.logEnabled(ctx, LevelDebug) {
+			return rt.RoundTrip(req)
+		}
+		op, _ := OperationFromContext(ctx)
+		dump := c.logBodies.Load()
+		if dump {
+			var body []byte
+			if req.GetBody != nil {
+				if r, err := req.GetBody(); err == nil {
+					body, _ = io.ReadAll(r)
+					r.Close()
+				}
+			}
+			c.log(ctx, LevelDebug, "sending request", "operation", op.String(), "method", req.Method,
+				"url", req.URL.String(), "header", redactHeader(req.Header), "body", redactBody(body))
+		}
+
+		start := time.Now()
+		resp, err := rt.RoundTrip(req)
+		elapsed := time.Since(start)
+		if err != nil {
+			c.log(ctx, LevelDebug, "request failed", "operation", op.String(), "method", req.Method,
+				"url", req.URL.String(), "duration", elapsed, "error", err)
+			return nil, err
+		}
+
+		args := []interface{}{"operation", op.String(), "method", req.Method, "url", req.URL.String(),
+			"status", resp.StatusCode, "duration", elapsed}
+		// streams are read as events arrive, so they can't be buffered
+		if dump && !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+			body, err := io.ReadAll(io.LimitReader(resp.Body, maxLoggedBody))
+			if err != nil {
+				resp.Body.Close()
+				return nil, err
+			}
+			// unread rest of body stays available to caller
+			resp.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+			args = append(args, "header", redactHeader(resp.Header), "body", redactBody(body))
+		}
+		c.log(ctx, LevelDebug, "received response", args...)
+		return resp, nil
+	})
+}
+
+// redactHeader returns copy of h without credentials
+func redactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	for _, k := range sensitiveHeaders {
+		if _, ok := h[k]; ok {
+			h.Set(k, redacted)
+		}
+	}
+	return h
+}
+
+// redactBody returns body for logging, with values of sensitive JSON fields replaced
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		// truncated JSON can't be redacted
+		if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+			return fmt.Sprintf("<%d bytes of unparsable JSON>", len(body))
+		}
+		return string(body)
+	}
+	redactValue(v)
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func redactValue(v interface{}) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, x := range t {
+			if sensitiveFields[strings.ToLower(k)] {
+				t[k] = redacted
+				continue
+			}
+			redactValue(x)
+		}
+	case []interface{}:
+		for _, x := range t {
+			redactValue(x)
+		}
+	}
+}
+
+// redactString hides non-empty secret
+func redactString(s string) string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+// LogValue implements slog.LogValuer hiding password
+func (r UserRequestCreate) LogValue() slog.Value {
+	type plain UserRequestCreate
+	r.Password = redactString(r.Password)
+	return slog.AnyValue(plain(r))
+}
+
+// LogValue implements slog.LogValuer hiding password
+func (r UserRequestUpdate) LogValue() slog.Value {
+	type plain UserRequestUpdate
+	r.Password = redactString(r.Password)
+	return slog.AnyValue(plain(r))
+}
+
+// LogValue implements slog.LogValuer hiding secret
+func (a Apikey) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("href", a.Href),
+		slog.String("name", a.Name),
+		slog.String("status", a.Status),
+		slog.String("key", a.Key),
+		slog.String("secret", redactString(a.Secret)),
+	)
+}
+
+// LogValue implements slog.LogValuer hiding password
+func (b BasicCredentials) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("username", b.Username),
+		slog.String("password", redactString(b.Password)),
+		slog.String("application", b.Application),
+	)
+}
+
+// LogValue implements slog.LogValuer hiding secret
+func (a ApikeyCredentials) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("key", a.Key),
+		slog.String("secret", redactString(a.Secret)),
+		slog.String("application", a.Application),
+	)
+}
@@ -0,0 +1,178 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+)
+
+// logBuffer collects JSON records of slog handler
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *logBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// records returns decoded records with message msg
+func (b *logBuffer) records(t *testing.T, msg string) []map[string]interface{} {
+	t.Helper()
+	var res []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(b.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var r map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("%v in record %s", err, line)
+		}
+		if r["msg"] == msg {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+func (b *logBuffer) reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf.Reset()
+}
+
+func newLogBuffer(level slog.Level) (*logBuffer, api.Logger) {
+	b := &logBuffer{}
+	return b, api.NewSlogLogger(slog.New(slog.NewJSONHandler(b, &slog.HandlerOptions{Level: level})))
+}
+
+func TestLogRedactsCredentials(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	logs, logger := newLogBuffer(slog.LevelDebug)
+	client, err := srv.NewClient(api.WithLogger(logger), api.WithLogBodies(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := srv.AddApikey("key-1", "apikey-secret")
+
+	// token is fetched with admin password before the first request
+	if _, err := client.Apikeys.GetById(key); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Users.CreateByDirectory(srv.DirectoryId(), &api.UserRequestCreate{Username: "jane", Password: "user-password"}); err != nil {
+		t.Fatal(err)
+	}
+
+	out := logs.String()
+	for _, secret := range []string{apitest.AdminPassword, "apikey-secret", "user-password", "Bearer "} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log contains %q", secret)
+		}
+	}
+	sent := logs.records(t, "sending request")
+	if len(sent) != 3 {
+		t.Fatalf("%d requests logged, want token, apikey and user", len(sent))
+	}
+	for _, r := range sent[1:] {
+		header, _ := r["header"].(map[string]interface{})
+		if auth, _ := header["Authorization"].([]interface{}); len(auth) != 1 || auth[0] != "REDACTED" {
+			t.Errorf("Authorization of %s logged as %v", r["url"], header["Authorization"])
+		}
+	}
+	if body, _ := sent[2]["body"].(string); !strings.Contains(body, `"password":"REDACTED"`) || !strings.Contains(body, `"username":"jane"`) {
+		t.Errorf("body of created user logged as %s", body)
+	}
+	received := logs.records(t, "received response")
+	if body, _ := received[0]["body"].(string); !strings.Contains(body, `"token":"REDACTED"`) {
+		t.Errorf("body of token logged as %s", body)
+	}
+	if body, _ := received[1]["body"].(string); !strings.Contains(body, `"secret":"REDACTED"`) || !strings.Contains(body, `"key":"key-1"`) {
+		t.Errorf("body of apikey logged as %s", body)
+	}
+}
+
+func TestSetLogBodies(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	logs, logger := newLogBuffer(slog.LevelDebug)
+	client, err := srv.NewClient(api.WithLogger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := srv.Add("devices", map[string]interface{}{}, nil)
+	// token is fetched by the first request
+	if _, err := client.Devices.GetById(id); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, enabled := range []bool{false, true, false} {
+		logs.reset()
+		client.SetLogBodies(enabled)
+		if _, err := client.Devices.GetById(id); err != nil {
+			t.Fatal(err)
+		}
+		if n := len(logs.records(t, "sending request")); (n == 1) != enabled {
+			t.Errorf("with bodies %v, %d requests logged before sending", enabled, n)
+		}
+		received := logs.records(t, "received response")
+		if len(received) != 1 {
+			t.Fatalf("%d responses logged, want 1", len(received))
+		}
+		if _, ok := received[0]["body"]; ok != enabled {
+			t.Errorf("with bodies %v, response logged as %v", enabled, received[0])
+		}
+		if received[0]["operation"] != "Devices.GetById" || received[0]["status"] != 200.0 {
+			t.Errorf("response logged as %v", received[0])
+		}
+	}
+
+	// nothing is logged above debug level
+	logs, logger = newLogBuffer(slog.LevelInfo)
+	client.SetLogger(logger)
+	client.SetLogBodies(true)
+	if _, err := client.Devices.GetById(id); err != nil {
+		t.Fatal(err)
+	}
+	if out := logs.String(); out != "" {
+		t.Errorf("logged at info level: %s", out)
+	}
+}
+
+func TestLogValues(t *testing.T) {
+	logs, _ := newLogBuffer(slog.LevelDebug)
+	logger := slog.New(slog.NewJSONHandler(logs, nil))
+	logger.Info("values",
+		"user", api.UserRequestCreate{Username: "jane", Password: "user-password"},
+		"update", api.UserRequestUpdate{Password: "new-password"},
+		"apikey", api.Apikey{Key: "key-1", Secret: "apikey-secret"},
+		"basic", api.BasicCredentials{Username: "admin", Password: "admin-password"},
+		"credentials", api.ApikeyCredentials{Key: "key-1", Secret: "credentials-secret"},
+	)
+	out := logs.String()
+	for _, secret := range []string{"user-password", "new-password", "apikey-secret", "admin-password", "credentials-secret"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log contains %q", secret)
+		}
+	}
+	r := logs.records(t, "values")[0]
+	user, _ := r["user"].(map[string]interface{})
+	apikey, _ := r["apikey"].(map[string]interface{})
+	if user["username"] != "jane" || user["password"] != "REDACTED" || apikey["key"] != "key-1" || apikey["secret"] != "REDACTED" {
+		t.Errorf("logged user %v and apikey %v", user, apikey)
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
     "bytes"
+    "context"
     "encoding/json" 
     "fmt"   
     "net/http"
@@ -13,19 +14,30 @@ import (
 // https://tenant-name.cloudthing.io/api/v1/memberships
 type MembershipsService interface {
     GetById(string, ...interface{}) (*Membership, error)
+    GetByIdContext(context.Context, string, ...interface{}) (*Membership, error)
     GetByLink(string, ...interface{}) (*Membership, error)
+    GetByLinkContext(context.Context, string, ...interface{}) (*Membership, error)
     ListByLink(string, ...interface{}) ([]Membership, *ListParams, error)
+    ListByLinkContext(context.Context, string, ...interface{}) ([]Membership, *ListParams, error)
+    IterateByLink(context.Context, string, ...interface{}) *Iterator[Membership]
     ListByUser(string, ...interface{}) ([]Membership, *ListParams, error)
+    ListByUserContext(context.Context, string, ...interface{}) ([]Membership, *ListParams, error)
+    IterateByUser(context.Context, string, ...interface{}) *Iterator[Membership]
     ListByUsergroup(string, ...interface{}) ([]Membership, *ListParams, error)
+    ListByUsergroupContext(context.Context, string, ...interface{}) ([]Membership, *ListParams, error)
+    IterateByUsergroup(context.Context, string, ...interface{}) *Iterator[Membership]
     CreateByLink(string, *MembershipRequestCreate) (*Membership, error)
+    CreateByLinkContext(context.Context, string, *MembershipRequestCreate) (*Membership, error)
     CreateByUser(string, *MembershipRequestCreate) (*Membership, error)
+    CreateByUserContext(context.Context, string, *MembershipRequestCreate) (*Membership, error)
     CreateByUsergroup(string, *MembershipRequestCreate) (*Membership, error)
+    CreateByUsergroupContext(context.Context, string, *MembershipRequestCreate) (*Membership, error)
     Delete(*Membership) (error)
+    DeleteContext(context.Context, *Membership) (error)
     DeleteByLink(string) (error)
+    DeleteByLinkContext(context.Context, string) (error)
     DeleteById(string) (error)
-
-    get(*MembershipResponse) (*Membership, error)
-    getCollection(*MembershipsResponse) ([]Membership, *ListParams, error)
+    DeleteByIdContext(context.Context, string) (error)
 }
 
 // MembershipsServiceOp handles communication with Memberships related methods of API
@@ -96,15 +108,27 @@ func (t *Membership) Delete() error {
 
 // GetById retrieves product by its ID
 func (s *MembershipsServiceOp) GetById(id string, args ...interface{}) (*Membership, error) {
+    return s.GetByIdContext(context.Background(), id, args...)
+}
+
+// GetByIdContext is like GetById but uses ctx for the underlying request.
+func (s *MembershipsServiceOp) GetByIdContext(ctx context.Context, id string, args ...interface{}) (*Membership, error) {
+    ctx = withOperation(ctx, "Memberships", "GetById")
     endpoint := "memberships/"
     endpoint = fmt.Sprintf("%s%s", endpoint, id)
 
-    return s.GetByLink(endpoint, args...)
+    return s.GetByLinkContext(ctx, endpoint, args...)
 }
 
 // GetById retrieves product by its full link
 func (s *MembershipsServiceOp) GetByLink(endpoint string, args ...interface{}) (*Membership, error) {
-    resp, err := s.client.request("GET", endpoint, nil, args...)
+    return s.GetByLinkContext(context.Background(), endpoint, args...)
+}
+
+// GetByLinkContext is like GetByLink but uses ctx for the underlying request.
+func (s *MembershipsServiceOp) GetByLinkContext(ctx context.Context, endpoint string, args ...interface{}) (*Membership, error) {
+    ctx = withOperation(ctx, "Memberships", "GetByLink")
+    resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
     if err != nil {
         return nil, err
     }
@@ -112,7 +136,7 @@ func (s *MembershipsServiceOp) GetByLink(endpoint string, args ...interface{}) (
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &MembershipResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -138,7 +162,7 @@ func (s *MembershipsServiceOp) get(r *MembershipResponse) (*Membership, error) {
         }
         ten := &UserResponse{}
         json.Unmarshal(bytes, ten)
-        t, err := s.client.Users.get(ten)
+        t, err := s.client.conv.users.get(ten)
         if err != nil {
             return nil, err
         }
@@ -151,7 +175,7 @@ func (s *MembershipsServiceOp) get(r *MembershipResponse) (*Membership, error) {
         }
         ten := &UsergroupResponse{}
         json.Unmarshal(bytes, ten)
-        t, err := s.client.Usergroups.get(ten)
+        t, err := s.client.conv.usergroups.get(ten)
         if err != nil {
             return nil, err
         }
@@ -185,19 +209,51 @@ func (s *MembershipsServiceOp) getCollection(r *MembershipsResponse) ([]Membersh
 
 // GetById retrieves collection of memberships of current tenant
 func (s *MembershipsServiceOp) ListByUser(id string, args ...interface{}) ([]Membership, *ListParams, error) {
+    return s.ListByUserContext(context.Background(), id, args...)
+}
+
+// ListByUserContext is like ListByUser but uses ctx for the underlying request.
+func (s *MembershipsServiceOp) ListByUserContext(ctx context.Context, id string, args ...interface{}) ([]Membership, *ListParams, error) {
+    ctx = withOperation(ctx, "Memberships", "ListByUser")
+    endpoint := fmt.Sprintf("users/%s/memberships", id)
+    return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// IterateByUser is like ListByUser but returns iterator walking over all pages
+func (s *MembershipsServiceOp) IterateByUser(ctx context.Context, id string, args ...interface{}) *Iterator[Membership] {
+    ctx = withOperation(ctx, "Memberships", "IterateByUser")
     endpoint := fmt.Sprintf("users/%s/memberships", id)
-    return s.ListByLink(endpoint, args...)
+    return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of memberships of current tenant
 func (s *MembershipsServiceOp) ListByUsergroup(id string, args ...interface{}) ([]Membership, *ListParams, error) {
+    return s.ListByUsergroupContext(context.Background(), id, args...)
+}
+
+// ListByUsergroupContext is like ListByUsergroup but uses ctx for the underlying request.
+func (s *MembershipsServiceOp) ListByUsergroupContext(ctx context.Context, id string, args ...interface{}) ([]Membership, *ListParams, error) {
+    ctx = withOperation(ctx, "Memberships", "ListByUsergroup")
     endpoint := fmt.Sprintf("usergroups/%s/memberships", id)
-    return s.ListByLink(endpoint, args...)
+    return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// IterateByUsergroup is like ListByUsergroup but returns iterator walking over all pages
+func (s *MembershipsServiceOp) IterateByUsergroup(ctx context.Context, id string, args ...interface{}) *Iterator[Membership] {
+    ctx = withOperation(ctx, "Memberships", "IterateByUsergroup")
+    endpoint := fmt.Sprintf("usergroups/%s/memberships", id)
+    return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of memberships by link
 func (s *MembershipsServiceOp) ListByLink(endpoint string, args ...interface{}) ([]Membership, *ListParams, error) {
-    resp, err := s.client.request("GET", endpoint, nil, args...)
+    return s.ListByLinkContext(context.Background(), endpoint, args...)
+}
+
+// ListByLinkContext is like ListByLink but uses ctx for the underlying request.
+func (s *MembershipsServiceOp) ListByLinkContext(ctx context.Context, endpoint string, args ...interface{}) ([]Membership, *ListParams, error) {
+    ctx = withOperation(ctx, "Memberships", "ListByLink")
+    resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
     if err != nil {
         return nil, nil, err
     }
@@ -205,7 +261,7 @@ func (s *MembershipsServiceOp) ListByLink(endpoint string, args ...interface{})
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, nil, newApiError(resp, "non-ok status returned")
     }
     obj := &MembershipsResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -214,18 +270,42 @@ func (s *MembershipsServiceOp) ListByLink(endpoint string, args ...interface{})
     return s.getCollection(obj)
 }
 
+// IterateByLink is like ListByLink but returns iterator walking over all pages
+func (s *MembershipsServiceOp) IterateByLink(ctx context.Context, endpoint string, args ...interface{}) *Iterator[Membership] {
+    ctx = withOperation(ctx, "Memberships", "IterateByLink")
+    return newIterator(ctx, endpoint, s.ListByLinkContext, args...)
+}
+
 func (s *MembershipsServiceOp) CreateByUser(id string, dir *MembershipRequestCreate) (*Membership, error) {
+    return s.CreateByUserContext(context.Background(), id, dir)
+}
+
+// CreateByUserContext is like CreateByUser but uses ctx for the underlying request.
+func (s *MembershipsServiceOp) CreateByUserContext(ctx context.Context, id string, dir *MembershipRequestCreate) (*Membership, error) {
+    ctx = withOperation(ctx, "Memberships", "CreateByUser")
     endpoint := fmt.Sprintf("users/%s/memberships", id)
-    return s.CreateByLink(endpoint, dir)
+    return s.CreateByLinkContext(ctx, endpoint, dir)
 }
 
 func (s *MembershipsServiceOp) CreateByUsergroup(id string, dir *MembershipRequestCreate) (*Membership, error) {
+    return s.CreateByUsergroupContext(context.Background(), id, dir)
+}
+
+// CreateByUsergroupContext is like CreateByUsergroup but uses ctx for the underlying request.
+func (s *MembershipsServiceOp) CreateByUsergroupContext(ctx context.Context, id string, dir *MembershipRequestCreate) (*Membership, error) {
+    ctx = withOperation(ctx, "Memberships", "CreateByUsergroup")
     endpoint := fmt.Sprintf("usergroups/%s/memberships", id)
-    return s.CreateByLink(endpoint, dir)
+    return s.CreateByLinkContext(ctx, endpoint, dir)
 }
 
 // Create creates new product within tenant
 func (s *MembershipsServiceOp) CreateByLink(endpoint string, dir *MembershipRequestCreate) (*Membership, error) {
+    return s.CreateByLinkContext(context.Background(), endpoint, dir)
+}
+
+// CreateByLinkContext is like CreateByLink but uses ctx for the underlying request.
+func (s *MembershipsServiceOp) CreateByLinkContext(ctx context.Context, endpoint string, dir *MembershipRequestCreate) (*Membership, error) {
+    ctx = withOperation(ctx, "Memberships", "CreateByLink")
     enc, err := json.Marshal(dir)
     if err != nil {
         return nil, err
@@ -233,7 +313,7 @@ func (s *MembershipsServiceOp) CreateByLink(endpoint string, dir *MembershipRequ
 
     buf := bytes.NewBuffer(enc)
 
-    resp, err := s.client.request("POST", endpoint, buf)
+    resp, err := s.client.request(ctx, "POST", endpoint, buf)
     if err != nil {
         return nil, err
     }
@@ -241,7 +321,7 @@ func (s *MembershipsServiceOp) CreateByLink(endpoint string, dir *MembershipRequ
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusCreated {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &MembershipResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -251,18 +331,36 @@ func (s *MembershipsServiceOp) CreateByLink(endpoint string, dir *MembershipRequ
 
 // Delete removes product
 func (s *MembershipsServiceOp) Delete(t *Membership) (error) {
-    return s.DeleteByLink(t.Href)
+    return s.DeleteContext(context.Background(), t)
+}
+
+// DeleteContext is like Delete but uses ctx for the underlying request.
+func (s *MembershipsServiceOp) DeleteContext(ctx context.Context, t *Membership) (error) {
+    ctx = withOperation(ctx, "Memberships", "Delete")
+    return s.DeleteByLinkContext(ctx, t.Href)
 }
 
 // Delete removes product by ID
 func (s *MembershipsServiceOp) DeleteById(id string) (error) {
+    return s.DeleteByIdContext(context.Background(), id)
+}
+
+// DeleteByIdContext is like DeleteById but uses ctx for the underlying request.
+func (s *MembershipsServiceOp) DeleteByIdContext(ctx context.Context, id string) (error) {
+    ctx = withOperation(ctx, "Memberships", "DeleteById")
     endpoint := fmt.Sprintf("memberships/%s", id)
-    return s.DeleteByLink(endpoint)
+    return s.DeleteByLinkContext(ctx, endpoint)
 }
 
 // Delete removes product by link
 func (s *MembershipsServiceOp) DeleteByLink(endpoint string) (error) {
-    resp, err := s.client.request("DELETE", endpoint, nil)
+    return s.DeleteByLinkContext(context.Background(), endpoint)
+}
+
+// DeleteByLinkContext is like DeleteByLink but uses ctx for the underlying request.
+func (s *MembershipsServiceOp) DeleteByLinkContext(ctx context.Context, endpoint string) (error) {
+    ctx = withOperation(ctx, "Memberships", "DeleteByLink")
+    resp, err := s.client.request(ctx, "DELETE", endpoint, nil)
     if err != nil {
         return err
     }
@@ -270,7 +368,7 @@ func (s *MembershipsServiceOp) DeleteByLink(endpoint string) (error) {
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusNoContent {
-        return ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return newApiError(resp, "non-ok status returned")
     }
     return nil
 }
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+)
+
+// Operation describes logical API call performed by request, e.g. Devices.UpdateByLink
+type Operation struct {
+	// Name of service, e.g. "Devices", or "Client" for authentication calls
+	Service string
+	// Name of method without Context suffix, e.g. "UpdateByLink"
+	Method string
+	// Absolute link of resource or collection, without query parameters
+	Href string
+	// ID of tenant from client's token, empty if not known yet
+	TenantId string
+}
+
+func (o Operation) String() string {
+	if o.Service == "" {
+		return o.Method
+	}
+	return o.Service + "." + o.Method
+}
+
+// RoundTripFunc is an adapter allowing ordinary functions to be used as http.RoundTripper
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req)
+func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps sending of requests to API. It gets next round tripper of the chain
+// and returns one calling it, e.g. after adding headers or before inspecting response.
+// Operation of request is available via OperationFromContext(req.Context()).
+//
+//	logging := func(next http.RoundTripper) http.RoundTripper {
+//		return api.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+//			op, _ := api.OperationFromContext(req.Context())
+//			resp, err := next.RoundTrip(req)
+//			log.Printf("%s %s %s", op, req.Method, req.URL)
+//			return resp, err
+//		})
+//	}
+//
+// Middleware is called for every attempt, so retried requests and requests sent again
+// with refreshed token pass it multiple times.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// Use appends middleware to chain of client, first added middleware is the outermost one.
+// It must not be called concurrently with requests.
+func (c *Client) Use(mw ...Middleware) {
+	c.middleware = append(c.middleware, mw...)
+}
+
+type operationKey struct{}
+
+// operationState is stored in context by service methods. Methods delegate to each other,
+// e.g. GetById to GetByLink, so the outermost one names operation until request is sent.
+type operationState struct {
+	op      Operation
+	started atomic.Bool
+}
+
+// OperationFromContext returns operation of request whose context is ctx
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	st, ok := ctx.Value(operationKey{}).(*operationState)
+	if !ok {
+		return Operation{}, false
+	}
+	return st.op, true
+}
+
+// withOperation names operation performed with ctx, unless outer method already did
+func withOperation(ctx context.Context, service, method string) context.Context {
+	if st, ok := ctx.Value(operationKey{}).(*operationState); ok && !st.started.Load() {
+		return ctx
+	}
+	return newOperation(ctx, service, method)
+}
+
+// newOperation names operation performed with ctx regardless of outer methods
+func newOperation(ctx context.Context, service, method string) context.Context {
+	return context.WithValue(ctx, operationKey{}, &operationState{op: Operation{Service: service, Method: method}})
+}
+
+// startOperation marks operation of ctx as sent and returns context carrying its href and tenant
+func startOperation(ctx context.Context, href, tenantId string) context.Context {
+	st, ok := ctx.Value(operationKey{}).(*operationState)
+	if !ok {
+		st = &operationState{}
+	}
+	st.started.Store(true)
+	started := &operationState{op: st.op}
+	started.op.Href = href
+	started.op.TenantId = tenantId
+	started.started.Store(true)
+	return context.WithValue(ctx, operationKey{}, started)
+}
+
+// roundTrip sends req through middleware chain of client
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	hc := c.client
+	if hc.Timeout != 0 && isStream(req.Context()) {
+		// streams last until their context is done, so the limit of single request doesn't apply
+		unlimited := *hc
+		unlimited.Timeout = 0
+		hc = &unlimited
+	}
+	rt := c.logTransport(RoundTripFunc(hc.Do))
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		rt = c.middleware[i](rt)
+	}
+	return rt.RoundTrip(req)
+}
@@ -0,0 +1,173 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+)
+
+// operations records operation of every request passing middleware
+type operations struct {
+	mu  sync.Mutex
+	ops []api.Operation
+}
+
+func (o *operations) middleware(next http.RoundTripper) http.RoundTripper {
+	return api.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		op, ok := api.OperationFromContext(req.Context())
+		if !ok {
+			op = api.Operation{Method: "unnamed " + req.URL.Path}
+		}
+		o.mu.Lock()
+		o.ops = append(o.ops, op)
+		o.mu.Unlock()
+		return next.RoundTrip(req)
+	})
+}
+
+// names returns names of recorded operations and forgets them
+func (o *operations) names() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var res []string
+	for _, op := range o.ops {
+		res = append(res, op.String())
+	}
+	o.ops = nil
+	return res
+}
+
+func (o *operations) last() api.Operation {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.ops[len(o.ops)-1]
+}
+
+func TestOperationOuterMethodWins(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	ops := &operations{}
+	client, err := srv.NewClient(api.WithMiddleware(ops.middleware))
+	if err != nil {
+		t.Fatal(err)
+	}
+	product := srv.Add("products", map[string]interface{}{"name": "Lamp"}, nil)
+	device := srv.Add("devices", map[string]interface{}{}, map[string]string{"product": "products/" + product})
+
+	// GetById delegates to GetByLink and ListByProduct to ListByLink
+	if _, err := client.Devices.GetById(device); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Devices.ListByProduct(product); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Devices.GetByLink("devices/" + device); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Client.GetAuthToken", "Devices.GetById", "Devices.ListByProduct", "Devices.GetByLink"}
+	if got := ops.names(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("operations = %v, want %v", got, want)
+	}
+
+	// calls made with context of request already sent are operations of their own
+	var nested string
+	lookup := func(next http.RoundTripper) http.RoundTripper {
+		return api.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if op, _ := api.OperationFromContext(req.Context()); op.String() == "Devices.GetById" {
+				if _, err := client.Products.GetByIdContext(req.Context(), product); err != nil {
+					return nil, err
+				}
+				op, _ := api.OperationFromContext(req.Context())
+				nested = op.String()
+			}
+			return next.RoundTrip(req)
+		})
+	}
+	client.Use(lookup)
+	if _, err := client.Devices.GetById(device); err != nil {
+		t.Fatal(err)
+	}
+	want = []string{"Devices.GetById", "Products.GetById"}
+	if got := ops.names(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("operations with nested call = %v, want %v", got, want)
+	}
+	if nested != "Devices.GetById" {
+		t.Errorf("operation of request after nested call = %s", nested)
+	}
+}
+
+func TestOperationOfIteratorPages(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	ops := &operations{}
+	client, err := srv.NewClient(api.WithMiddleware(ops.middleware))
+	if err != nil {
+		t.Fatal(err)
+	}
+	product := srv.Add("products", map[string]interface{}{"name": "Lamp"}, nil)
+	for i := 0; i < 3; i++ {
+		srv.Add("devices", map[string]interface{}{}, map[string]string{"product": "products/" + product})
+	}
+	// token is fetched before iterating
+	if _, err := client.Products.GetById(product); err != nil {
+		t.Fatal(err)
+	}
+	ops.names()
+
+	devices, err := client.Devices.IterateByProduct(context.Background(), product, &api.ListOptions{Limit: 1}).Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 3 {
+		t.Fatalf("iterated %d devices, want 3", len(devices))
+	}
+	// the first page is listed by product, following ones by link, all of them are pages of IterateByProduct
+	got := ops.names()
+	if len(got) != 3 {
+		t.Fatalf("operations = %v, want 3 pages", got)
+	}
+	for _, name := range got {
+		if name != "Devices.IterateByProduct" {
+			t.Errorf("operations = %v, want pages of Devices.IterateByProduct", got)
+			break
+		}
+	}
+}
+
+func TestOperationHrefAndTenant(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	ops := &operations{}
+	client, err := srv.NewClient(api.WithMiddleware(ops.middleware))
+	if err != nil {
+		t.Fatal(err)
+	}
+	device := srv.Add("devices", map[string]interface{}{}, nil)
+
+	if _, err := client.Devices.GetById(device, api.Expand("product")); err != nil {
+		t.Fatal(err)
+	}
+	ops.mu.Lock()
+	auth := ops.ops[0]
+	ops.mu.Unlock()
+	if auth.Href != srv.URL+"/api/v1/auth/token" || auth.TenantId != "" {
+		t.Errorf("token retrieved by %+v, want href of auth/token without tenant", auth)
+	}
+	// href is absolute and has no query parameters
+	op := ops.last()
+	if op.Href != srv.URL+"/api/v1/devices/"+device || op.TenantId != srv.TenantId() {
+		t.Errorf("device retrieved by %+v, want href of device and tenant %s", op, srv.TenantId())
+	}
+
+	if _, _, err := client.Apikeys.List(); err != nil {
+		t.Fatal(err)
+	}
+	if op := ops.last(); op.Href != srv.URL+"/api/v1/tenants/"+srv.TenantId()+"/apikeys" {
+		t.Errorf("apikeys listed by %+v", op)
+	}
+}
@@ -0,0 +1,283 @@
+// Command gen generates fakes of service interfaces of package api into services.go
+// of package mocks. It is run by go generate in mocks directory, optional argument
+// names another output file.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+// method is a method of service interface
+type method struct {
+	name    string
+	params  []string
+	results []string
+	// whether the last parameter is variadic
+	variadic bool
+}
+
+// implemented reports whether method is implemented by Func field of mock, i.e. it is
+// a Context variant or takes context without having one, e.g. Subscribe
+func (m *method) implemented() bool {
+	if strings.HasPrefix(m.name, "Iterate") || strings.HasPrefix(m.name, "Stream") {
+		return false
+	}
+	return strings.HasSuffix(m.name, "Context") || (len(m.params) > 0 && m.params[0] == "context.Context")
+}
+
+type service struct {
+	name    string
+	methods map[string]*method
+	order   []string
+}
+
+func main() {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, "..", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	pkg, ok := pkgs["api"]
+	if !ok {
+		log.Fatal("package api not found")
+	}
+
+	var services []*service
+	for _, f := range pkg.Files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				it, ok := ts.Type.(*ast.InterfaceType)
+				if !ok || !strings.HasSuffix(ts.Name.Name, "Service") {
+					continue
+				}
+				services = append(services, parseService(ts.Name.Name, it))
+			}
+		}
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].name < services[j].name })
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by mocks/gen; DO NOT EDIT.\n\npackage mocks\n\n")
+	buf.WriteString("import (\n\t\"context\"\n\n\tapi \"github.com/cloudthing-io/go-client-api\"\n)\n")
+	for _, s := range services {
+		writeService(&buf, s)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v", err)
+	}
+	out := "services.go"
+	if len(os.Args) > 1 {
+		out = os.Args[1]
+	}
+	if err := os.WriteFile(out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parseService(name string, it *ast.InterfaceType) *service {
+	s := &service{name: name, methods: make(map[string]*method)}
+	for _, field := range it.Methods.List {
+		ft, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) == 0 || !ast.IsExported(field.Names[0].Name) {
+			continue
+		}
+		m := &method{name: field.Names[0].Name}
+		for _, p := range ft.Params.List {
+			n := len(p.Names)
+			if n == 0 {
+				n = 1
+			}
+			for i := 0; i < n; i++ {
+				m.params = append(m.params, typeString(p.Type))
+			}
+			if _, ok := p.Type.(*ast.Ellipsis); ok {
+				m.variadic = true
+			}
+		}
+		if ft.Results != nil {
+			for _, r := range ft.Results.List {
+				m.results = append(m.results, typeString(r.Type))
+			}
+		}
+		s.methods[m.name] = m
+		s.order = append(s.order, m.name)
+	}
+	return s
+}
+
+// typeString renders type qualifying identifiers declared in package api
+func typeString(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.Ident:
+		if ast.IsExported(t.Name) {
+			return "api." + t.Name
+		}
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X)
+	case *ast.ArrayType:
+		return "[]" + typeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + typeString(t.Key) + "]" + typeString(t.Value)
+	case *ast.SelectorExpr:
+		return typeString(t.X) + "." + t.Sel.Name
+	case *ast.InterfaceType:
+		return "interface{}"
+	case *ast.Ellipsis:
+		return "..." + typeString(t.Elt)
+	case *ast.IndexExpr:
+		return typeString(t.X) + "[" + typeString(t.Index) + "]"
+	}
+	log.Fatalf("unsupported type %T", e)
+	return ""
+}
+
+// signature renders parameters named a0, a1... and results of method
+func (m *method) signature() (params string, args string, results string) {
+	var ps, as []string
+	for i, p := range m.params {
+		ps = append(ps, fmt.Sprintf("a%d %s", i, p))
+		a := fmt.Sprintf("a%d", i)
+		if strings.HasPrefix(p, "...") {
+			a += "..."
+		}
+		as = append(as, a)
+	}
+	results = strings.Join(m.results, ", ")
+	if len(m.results) > 1 {
+		results = "(" + results + ")"
+	}
+	return strings.Join(ps, ", "), strings.Join(as, ", "), results
+}
+
+func writeService(buf *bytes.Buffer, s *service) {
+	fmt.Fprintf(buf, "\n// %s is a fake of api.%s. Fields ending with Func implement methods\n", s.name, s.name)
+	fmt.Fprintf(buf, "// of the same name and their Context variants.\ntype %s struct {\n\tMock\n\n", s.name)
+	for _, name := range s.order {
+		m := s.methods[name]
+		if !m.implemented() {
+			continue
+		}
+		params, _, results := m.signature()
+		fmt.Fprintf(buf, "\t%sFunc func(%s) %s\n", strings.TrimSuffix(name, "Context"), params, results)
+	}
+	fmt.Fprintf(buf, "}\n\nvar _ api.%s = (*%s)(nil)\n", s.name, s.name)
+
+	for _, name := range s.order {
+		m := s.methods[name]
+		switch {
+		case strings.HasPrefix(name, "Iterate"):
+			writeIterate(buf, s, m)
+		case strings.HasPrefix(name, "Stream"):
+			writeStream(buf, s, m)
+		case m.implemented():
+			writeContext(buf, s, m)
+		default:
+			params, args, results := m.signature()
+			if args != "" {
+				args = ", " + args
+			}
+			fmt.Fprintf(buf, "\n// %s implements api.%s\n", name, s.name)
+			fmt.Fprintf(buf, "func (m *%s) %s(%s) %s {\n", s.name, name, params, results)
+			fmt.Fprintf(buf, "\treturn m.%sContext(context.Background()%s)\n}\n", name, args)
+		}
+	}
+}
+
+func writeContext(buf *bytes.Buffer, s *service, m *method) {
+	base := strings.TrimSuffix(m.name, "Context")
+	params, args, _ := m.signature()
+
+	var named, targets []string
+	for i, r := range m.results {
+		named = append(named, fmt.Sprintf("r%d %s", i, r))
+		targets = append(targets, fmt.Sprintf("&r%d", i))
+	}
+
+	// arguments following context, variadic ones flattened
+	var recorded string
+	plain := m.params[1:]
+	rest := fmt.Sprintf("a%d", len(m.params)-1)
+	if m.variadic && m.params[len(m.params)-1] != "...interface{}" {
+		rest = fmt.Sprintf("flatten(%s)", rest)
+	}
+	switch {
+	case len(plain) == 0:
+	case m.variadic && len(plain) == 1:
+		recorded = fmt.Sprintf(", %s...", rest)
+	case m.variadic:
+		var fixed []string
+		for i := 1; i < len(m.params)-1; i++ {
+			fixed = append(fixed, fmt.Sprintf("a%d", i))
+		}
+		recorded = fmt.Sprintf(", append([]interface{}{%s}, %s...)...", strings.Join(fixed, ", "), rest)
+	default:
+		var fixed []string
+		for i := 1; i < len(m.params); i++ {
+			fixed = append(fixed, fmt.Sprintf("a%d", i))
+		}
+		recorded = ", " + strings.Join(fixed, ", ")
+	}
+
+	fmt.Fprintf(buf, "\n// %s implements api.%s\n", m.name, s.name)
+	fmt.Fprintf(buf, "func (m *%s) %s(%s) (%s) {\n", s.name, m.name, params, strings.Join(named, ", "))
+	fmt.Fprintf(buf, "\tif res, ok := m.record(%q, %d, a0%s); ok {\n", base, len(m.results), recorded)
+	fmt.Fprintf(buf, "\t\tassign(%q, res, %s)\n\t\treturn\n\t}\n", base, strings.Join(targets, ", "))
+	fmt.Fprintf(buf, "\tif m.%sFunc != nil {\n\t\treturn m.%sFunc(%s)\n\t}\n\treturn\n}\n", base, base, args)
+}
+
+func writeIterate(buf *bytes.Buffer, s *service, m *method) {
+	list := "List" + strings.TrimPrefix(m.name, "Iterate")
+	params, _, results := m.signature()
+	elem := strings.TrimSuffix(strings.TrimPrefix(results, "*api.Iterator["), "]")
+
+	// fixed arguments are passed to List method, variadic ones to iterator
+	var fixed []string
+	for i := 1; i < len(m.params)-1; i++ {
+		fixed = append(fixed, fmt.Sprintf("a%d, ", i))
+	}
+
+	fmt.Fprintf(buf, "\n// %s implements api.%s\n", m.name, s.name)
+	fmt.Fprintf(buf, "func (m *%s) %s(%s) %s {\n", s.name, m.name, params, results)
+	fmt.Fprintf(buf, "\tfirst := true\n")
+	fmt.Fprintf(buf, "\treturn api.NewIterator(a0, %q, func(ctx context.Context, link string, args ...interface{}) ([]%s, *api.ListParams, error) {\n", m.name, elem)
+	fmt.Fprintf(buf, "\t\tif first {\n\t\t\tfirst = false\n\t\t\treturn m.%sContext(ctx, %sargs...)\n\t\t}\n", list, strings.Join(fixed, ""))
+	fmt.Fprintf(buf, "\t\treturn m.ListByLinkContext(ctx, link, args...)\n\t}, a%d...)\n}\n", len(m.params)-1)
+}
+
+func writeStream(buf *bytes.Buffer, s *service, m *method) {
+	// StreamDataByDeviceID reads first page with GetDataByDeviceID and following ones with GetDataByLink
+	get := "Get" + strings.TrimPrefix(m.name, "Stream")
+	byLink := get[:strings.Index(get, "By")] + "ByLink"
+	params, _, results := m.signature()
+	elem := strings.TrimSuffix(strings.TrimPrefix(results, "*api.Stream["), "]")
+
+	var fixed []string
+	for i := 1; i < len(m.params)-1; i++ {
+		fixed = append(fixed, fmt.Sprintf("a%d, ", i))
+	}
+
+	fmt.Fprintf(buf, "\n// %s implements api.%s\n", m.name, s.name)
+	fmt.Fprintf(buf, "func (m *%s) %s(%s) %s {\n", s.name, m.name, params, results)
+	fmt.Fprintf(buf, "\tfirst := true\n")
+	fmt.Fprintf(buf, "\treturn api.NewStreamFromPages(a0, %q, func(ctx context.Context, link string, args ...interface{}) ([]%s, *api.ListParams, error) {\n", m.name, elem)
+	fmt.Fprintf(buf, "\t\tif first {\n\t\t\tfirst = false\n\t\t\treturn m.%sContext(ctx, %sargs...)\n\t\t}\n", get, strings.Join(fixed, ""))
+	fmt.Fprintf(buf, "\t\treturn m.%sContext(ctx, link, args...)\n\t}, a%d...)\n}\n", byLink, len(m.params)-1)
+}
@@ -0,0 +1,149 @@
+// Package mocks provides programmable fakes of all services of api.Client, so code
+// depending on *api.Client can be unit tested without API:
+//
+//	devices := &mocks.DevicesService{}
+//	devices.Return("GetById", &api.Device{Token: "token"}, nil)
+//	devices.Fail("DeleteById", api.ErrForbidden)
+//	client.Devices = devices
+//
+// Method and its Context variant share a name, e.g. both GetById and GetByIdContext
+// are recorded and stubbed as "GetById". Result of a call is, in order of precedence,
+// error set by Fail, results set by Return, result of function field, e.g. GetByIdFunc,
+// or zero values. Iterate* methods walk pages returned by corresponding List* method
+// and ListByLink for following pages, Stream* methods do the same with Get* methods.
+package mocks
+
+//go:generate go run ./gen
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Call is a single recorded call of fake's method
+type Call struct {
+	// Name of method without Context suffix
+	Method string
+	Ctx    context.Context
+	// Arguments following context, variadic arguments are flattened
+	Args []interface{}
+}
+
+// Mock records calls and holds canned results, it is embedded in all fakes
+type Mock struct {
+	mu      sync.Mutex
+	calls   []Call
+	results map[string][][]interface{}
+	errs    map[string]error
+}
+
+// Return sets results of method. Results set by subsequent calls are returned
+// by subsequent calls of method, the last ones are repeated. Number and types
+// of results have to match the method, nil stands for zero value.
+func (m *Mock) Return(method string, results ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.results == nil {
+		m.results = make(map[string][][]interface{})
+	}
+	m.results[method] = append(m.results[method], results)
+}
+
+// Fail makes all following calls of method return err, nil err removes failure
+func (m *Mock) Fail(method string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.errs == nil {
+		m.errs = make(map[string]error)
+	}
+	if err == nil {
+		delete(m.errs, method)
+		return
+	}
+	m.errs[method] = err
+}
+
+// Calls returns all recorded calls
+func (m *Mock) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+// CallsTo returns recorded calls of method
+func (m *Mock) CallsTo(method string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var res []Call
+	for _, c := range m.calls {
+		if c.Method == method {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// Called returns number of recorded calls of method
+func (m *Mock) Called(method string) int {
+	return len(m.CallsTo(method))
+}
+
+// Reset removes recorded calls, canned results and failures
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+	m.results = nil
+	m.errs = nil
+}
+
+// record registers call of method and returns its canned results, false if there are none.
+// Injected error is returned as the last of n results.
+func (m *Mock) record(method string, n int, ctx context.Context, args ...interface{}) ([]interface{}, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Method: method, Ctx: ctx, Args: args})
+	if err, ok := m.errs[method]; ok {
+		res := make([]interface{}, n)
+		res[n-1] = err
+		return res, true
+	}
+	queue := m.results[method]
+	if len(queue) == 0 {
+		return nil, false
+	}
+	res := queue[0]
+	if len(queue) > 1 {
+		m.results[method] = queue[1:]
+	}
+	if len(res) != n {
+		panic(fmt.Sprintf("mocks: %s returns %d results, %d set", method, n, len(res)))
+	}
+	return res, true
+}
+
+// assign stores results into pointers to return values
+func assign(method string, results []interface{}, targets ...interface{}) {
+	for i, t := range targets {
+		if results[i] == nil {
+			continue
+		}
+		target := reflect.ValueOf(t).Elem()
+		v := reflect.ValueOf(results[i])
+		if !v.Type().AssignableTo(target.Type()) {
+			panic(fmt.Sprintf("mocks: result %d of %s has type %T, expected %s", i, method, results[i], target.Type()))
+		}
+		target.Set(v)
+	}
+}
+
+// flatten converts variadic arguments of other types than interface{} for recording
+func flatten[T any](args []T) []interface{} {
+	res := make([]interface{}, len(args))
+	for i, a := range args {
+		res[i] = a
+	}
+	return res
+}
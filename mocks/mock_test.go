@@ -0,0 +1,165 @@
+package mocks_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/mocks"
+)
+
+func TestMockReturn(t *testing.T) {
+	devices := &mocks.DevicesService{}
+	if d, err := devices.GetById("1"); d != nil || err != nil {
+		t.Errorf("GetById without results = %v, %v, want zero values", d, err)
+	}
+
+	devices.GetByIdFunc = func(_ context.Context, id string, _ ...interface{}) (*api.Device, error) {
+		return &api.Device{Token: "func-" + id}, nil
+	}
+	if d, _ := devices.GetById("1"); d == nil || d.Token != "func-1" {
+		t.Errorf("GetById = %+v, want result of GetByIdFunc", d)
+	}
+
+	// results are returned in order, the last ones repeatedly, and take precedence over Func
+	devices.Return("GetById", &api.Device{Token: "first"}, nil)
+	devices.Return("GetById", nil, api.ErrNotFound)
+	var got []string
+	for i := 0; i < 3; i++ {
+		d, err := devices.GetByIdContext(context.Background(), "1")
+		switch {
+		case d != nil && err == nil:
+			got = append(got, d.Token)
+		case d == nil && errors.Is(err, api.ErrNotFound):
+			got = append(got, "not found")
+		default:
+			t.Fatalf("GetById = %v, %v", d, err)
+		}
+	}
+	if len(got) != 3 || got[0] != "first" || got[1] != "not found" || got[2] != "not found" {
+		t.Errorf("results = %v, want first and then not found twice", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("results of wrong type accepted")
+		}
+	}()
+	devices.Return("GetByLink", &api.Product{}, nil)
+	devices.GetByLink("devices/1")
+}
+
+func TestMockFail(t *testing.T) {
+	devices := &mocks.DevicesService{}
+	devices.Return("GetById", &api.Device{Token: "token"}, nil)
+	devices.Fail("GetById", api.ErrForbidden)
+	devices.Fail("DeleteById", api.ErrForbidden)
+
+	if d, err := devices.GetById("1"); d != nil || !errors.Is(err, api.ErrForbidden) {
+		t.Errorf("GetById = %v, %v, want ErrForbidden", d, err)
+	}
+	if err := devices.DeleteById("1"); !errors.Is(err, api.ErrForbidden) {
+		t.Errorf("DeleteById = %v, want ErrForbidden", err)
+	}
+
+	devices.Fail("GetById", nil)
+	if d, err := devices.GetById("1"); err != nil || d.Token != "token" {
+		t.Errorf("GetById after removing failure = %v, %v", d, err)
+	}
+}
+
+func TestMockCalls(t *testing.T) {
+	devices := &mocks.DevicesService{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	devices.GetByIdContext(ctx, "1", api.Expand("product"))
+	devices.GetById("2")
+	devices.DeleteById("1")
+
+	calls := devices.Calls()
+	if len(calls) != 3 || calls[0].Method != "GetById" || calls[2].Method != "DeleteById" {
+		t.Fatalf("calls = %+v", calls)
+	}
+	if calls[0].Ctx != ctx || len(calls[0].Args) != 2 || calls[0].Args[0] != "1" {
+		t.Errorf("call of GetByIdContext recorded as %+v", calls[0])
+	}
+	if _, ok := calls[0].Args[1].(*api.Expansion); !ok {
+		t.Errorf("variadic argument recorded as %#v", calls[0].Args[1])
+	}
+	if calls[1].Ctx == nil || calls[1].Args[0] != "2" {
+		t.Errorf("call of GetById recorded as %+v", calls[1])
+	}
+	if byId := devices.CallsTo("GetById"); len(byId) != 2 || devices.Called("GetById") != 2 || devices.Called("List") != 0 {
+		t.Errorf("calls of GetById = %+v", byId)
+	}
+
+	devices.Return("GetById", &api.Device{}, nil)
+	devices.Fail("DeleteById", api.ErrForbidden)
+	devices.Reset()
+	if len(devices.Calls()) != 0 {
+		t.Error("calls recorded after Reset")
+	}
+	if d, err := devices.GetById("1"); d != nil || err != nil {
+		t.Errorf("GetById after Reset = %v, %v", d, err)
+	}
+	if err := devices.DeleteById("1"); err != nil {
+		t.Errorf("DeleteById after Reset = %v", err)
+	}
+}
+
+func TestMockIterate(t *testing.T) {
+	devices := &mocks.DevicesService{}
+	next := &api.ListParams{Page: 1, Next: &api.Link{Href: "devices?page=2"}}
+	devices.Return("ListByProduct", []api.Device{{Token: "a"}, {Token: "b"}}, next, nil)
+	devices.Return("ListByLink", []api.Device{{Token: "c"}}, &api.ListParams{Page: 2}, nil)
+
+	items, err := devices.IterateByProduct(context.Background(), "p").Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 || items[0].Token != "a" || items[2].Token != "c" {
+		t.Errorf("iterated %+v, want a, b and c", items)
+	}
+	first := devices.CallsTo("ListByProduct")
+	following := devices.CallsTo("ListByLink")
+	if len(first) != 1 || first[0].Args[0] != "p" || len(following) != 1 || following[0].Args[0] != "devices?page=2" {
+		t.Errorf("pages requested by %+v and %+v", first, following)
+	}
+
+	devices.Reset()
+	devices.Fail("ListByLink", api.ErrNotFound)
+	it := devices.IterateByLink(context.Background(), "devices")
+	if it.Next() || !errors.Is(it.Err(), api.ErrNotFound) {
+		t.Errorf("Err() = %v, want ErrNotFound", it.Err())
+	}
+}
+
+// TestGenerated checks that services.go is up to date with service interfaces
+func TestGenerated(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs generator")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found")
+	}
+	out := filepath.Join(t.TempDir(), "services.go")
+	if b, err := exec.Command("go", "run", "./gen", out).CombinedOutput(); err != nil {
+		t.Fatalf("generating fakes: %v\n%s", err, b)
+	}
+	want, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("services.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("services.go is out of date, run go generate")
+	}
+}
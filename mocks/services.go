@@ -0,0 +1,3973 @@
+// Code generated by mocks/gen; DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	api "github.com/cloudthing-io/go-client-api"
+)
+
+// ApikeysService is a fake of api.ApikeysService. Fields ending with Func implement methods
+// of the same name and their Context variants.
+type ApikeysService struct {
+	Mock
+
+	GetByIdFunc      func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Apikey, error)
+	GetByLinkFunc    func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Apikey, error)
+	ListFunc         func(a0 context.Context, a1 ...interface{}) ([]api.Apikey, *api.ListParams, error)
+	ListByLinkFunc   func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Apikey, *api.ListParams, error)
+	CreateFunc       func(a0 context.Context, a1 *api.ApikeyRequestCreate) (*api.Apikey, error)
+	UpdateByIdFunc   func(a0 context.Context, a1 string, a2 *api.ApikeyRequestUpdate) (*api.Apikey, error)
+	UpdateByLinkFunc func(a0 context.Context, a1 string, a2 *api.ApikeyRequestUpdate) (*api.Apikey, error)
+	DeleteFunc       func(a0 context.Context, a1 *api.Apikey) error
+	DeleteByLinkFunc func(a0 context.Context, a1 string) error
+	DeleteByIdFunc   func(a0 context.Context, a1 string) error
+}
+
+var _ api.ApikeysService = (*ApikeysService)(nil)
+
+// GetById implements api.ApikeysService
+func (m *ApikeysService) GetById(a0 string, a1 ...interface{}) (*api.Apikey, error) {
+	return m.GetByIdContext(context.Background(), a0, a1...)
+}
+
+// GetByIdContext implements api.ApikeysService
+func (m *ApikeysService) GetByIdContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Apikey, r1 error) {
+	if res, ok := m.record("GetById", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetById", res, &r0, &r1)
+		return
+	}
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// GetByLink implements api.ApikeysService
+func (m *ApikeysService) GetByLink(a0 string, a1 ...interface{}) (*api.Apikey, error) {
+	return m.GetByLinkContext(context.Background(), a0, a1...)
+}
+
+// GetByLinkContext implements api.ApikeysService
+func (m *ApikeysService) GetByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Apikey, r1 error) {
+	if res, ok := m.record("GetByLink", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetByLink", res, &r0, &r1)
+		return
+	}
+	if m.GetByLinkFunc != nil {
+		return m.GetByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// List implements api.ApikeysService
+func (m *ApikeysService) List(a0 ...interface{}) ([]api.Apikey, *api.ListParams, error) {
+	return m.ListContext(context.Background(), a0...)
+}
+
+// ListContext implements api.ApikeysService
+func (m *ApikeysService) ListContext(a0 context.Context, a1 ...interface{}) (r0 []api.Apikey, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("List", 3, a0, a1...); ok {
+		assign("List", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListFunc != nil {
+		return m.ListFunc(a0, a1...)
+	}
+	return
+}
+
+// Iterate implements api.ApikeysService
+func (m *ApikeysService) Iterate(a0 context.Context, a1 ...interface{}) *api.Iterator[api.Apikey] {
+	first := true
+	return api.NewIterator(a0, "Iterate", func(ctx context.Context, link string, args ...interface{}) ([]api.Apikey, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListContext(ctx, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a1...)
+}
+
+// ListByLink implements api.ApikeysService
+func (m *ApikeysService) ListByLink(a0 string, a1 ...interface{}) ([]api.Apikey, *api.ListParams, error) {
+	return m.ListByLinkContext(context.Background(), a0, a1...)
+}
+
+// ListByLinkContext implements api.ApikeysService
+func (m *ApikeysService) ListByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Apikey, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByLink", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByLink", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByLinkFunc != nil {
+		return m.ListByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByLink implements api.ApikeysService
+func (m *ApikeysService) IterateByLink(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Apikey] {
+	first := true
+	return api.NewIterator(a0, "IterateByLink", func(ctx context.Context, link string, args ...interface{}) ([]api.Apikey, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByLinkContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// Create implements api.ApikeysService
+func (m *ApikeysService) Create(a0 *api.ApikeyRequestCreate) (*api.Apikey, error) {
+	return m.CreateContext(context.Background(), a0)
+}
+
+// CreateContext implements api.ApikeysService
+func (m *ApikeysService) CreateContext(a0 context.Context, a1 *api.ApikeyRequestCreate) (r0 *api.Apikey, r1 error) {
+	if res, ok := m.record("Create", 2, a0, a1); ok {
+		assign("Create", res, &r0, &r1)
+		return
+	}
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0, a1)
+	}
+	return
+}
+
+// UpdateById implements api.ApikeysService
+func (m *ApikeysService) UpdateById(a0 string, a1 *api.ApikeyRequestUpdate) (*api.Apikey, error) {
+	return m.UpdateByIdContext(context.Background(), a0, a1)
+}
+
+// UpdateByIdContext implements api.ApikeysService
+func (m *ApikeysService) UpdateByIdContext(a0 context.Context, a1 string, a2 *api.ApikeyRequestUpdate) (r0 *api.Apikey, r1 error) {
+	if res, ok := m.record("UpdateById", 2, a0, a1, a2); ok {
+		assign("UpdateById", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByIdFunc != nil {
+		return m.UpdateByIdFunc(a0, a1, a2)
+	}
+	return
+}
+
+// UpdateByLink implements api.ApikeysService
+func (m *ApikeysService) UpdateByLink(a0 string, a1 *api.ApikeyRequestUpdate) (*api.Apikey, error) {
+	return m.UpdateByLinkContext(context.Background(), a0, a1)
+}
+
+// UpdateByLinkContext implements api.ApikeysService
+func (m *ApikeysService) UpdateByLinkContext(a0 context.Context, a1 string, a2 *api.ApikeyRequestUpdate) (r0 *api.Apikey, r1 error) {
+	if res, ok := m.record("UpdateByLink", 2, a0, a1, a2); ok {
+		assign("UpdateByLink", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByLinkFunc != nil {
+		return m.UpdateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// Delete implements api.ApikeysService
+func (m *ApikeysService) Delete(a0 *api.Apikey) error {
+	return m.DeleteContext(context.Background(), a0)
+}
+
+// DeleteContext implements api.ApikeysService
+func (m *ApikeysService) DeleteContext(a0 context.Context, a1 *api.Apikey) (r0 error) {
+	if res, ok := m.record("Delete", 1, a0, a1); ok {
+		assign("Delete", res, &r0)
+		return
+	}
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteByLink implements api.ApikeysService
+func (m *ApikeysService) DeleteByLink(a0 string) error {
+	return m.DeleteByLinkContext(context.Background(), a0)
+}
+
+// DeleteByLinkContext implements api.ApikeysService
+func (m *ApikeysService) DeleteByLinkContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteByLink", 1, a0, a1); ok {
+		assign("DeleteByLink", res, &r0)
+		return
+	}
+	if m.DeleteByLinkFunc != nil {
+		return m.DeleteByLinkFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteById implements api.ApikeysService
+func (m *ApikeysService) DeleteById(a0 string) error {
+	return m.DeleteByIdContext(context.Background(), a0)
+}
+
+// DeleteByIdContext implements api.ApikeysService
+func (m *ApikeysService) DeleteByIdContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteById", 1, a0, a1); ok {
+		assign("DeleteById", res, &r0)
+		return
+	}
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(a0, a1)
+	}
+	return
+}
+
+// ApplicationsService is a fake of api.ApplicationsService. Fields ending with Func implement methods
+// of the same name and their Context variants.
+type ApplicationsService struct {
+	Mock
+
+	GetByIdFunc      func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Application, error)
+	GetByLinkFunc    func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Application, error)
+	ListFunc         func(a0 context.Context, a1 ...interface{}) ([]api.Application, *api.ListParams, error)
+	ListByLinkFunc   func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Application, *api.ListParams, error)
+	CreateFunc       func(a0 context.Context, a1 *api.ApplicationRequestCreate) (*api.Application, error)
+	UpdateByIdFunc   func(a0 context.Context, a1 string, a2 *api.ApplicationRequestUpdate) (*api.Application, error)
+	UpdateByLinkFunc func(a0 context.Context, a1 string, a2 *api.ApplicationRequestUpdate) (*api.Application, error)
+	DeleteFunc       func(a0 context.Context, a1 *api.Application) error
+	DeleteByLinkFunc func(a0 context.Context, a1 string) error
+	DeleteByIdFunc   func(a0 context.Context, a1 string) error
+}
+
+var _ api.ApplicationsService = (*ApplicationsService)(nil)
+
+// GetById implements api.ApplicationsService
+func (m *ApplicationsService) GetById(a0 string, a1 ...interface{}) (*api.Application, error) {
+	return m.GetByIdContext(context.Background(), a0, a1...)
+}
+
+// GetByIdContext implements api.ApplicationsService
+func (m *ApplicationsService) GetByIdContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Application, r1 error) {
+	if res, ok := m.record("GetById", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetById", res, &r0, &r1)
+		return
+	}
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// GetByLink implements api.ApplicationsService
+func (m *ApplicationsService) GetByLink(a0 string, a1 ...interface{}) (*api.Application, error) {
+	return m.GetByLinkContext(context.Background(), a0, a1...)
+}
+
+// GetByLinkContext implements api.ApplicationsService
+func (m *ApplicationsService) GetByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Application, r1 error) {
+	if res, ok := m.record("GetByLink", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetByLink", res, &r0, &r1)
+		return
+	}
+	if m.GetByLinkFunc != nil {
+		return m.GetByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// List implements api.ApplicationsService
+func (m *ApplicationsService) List(a0 ...interface{}) ([]api.Application, *api.ListParams, error) {
+	return m.ListContext(context.Background(), a0...)
+}
+
+// ListContext implements api.ApplicationsService
+func (m *ApplicationsService) ListContext(a0 context.Context, a1 ...interface{}) (r0 []api.Application, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("List", 3, a0, a1...); ok {
+		assign("List", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListFunc != nil {
+		return m.ListFunc(a0, a1...)
+	}
+	return
+}
+
+// Iterate implements api.ApplicationsService
+func (m *ApplicationsService) Iterate(a0 context.Context, a1 ...interface{}) *api.Iterator[api.Application] {
+	first := true
+	return api.NewIterator(a0, "Iterate", func(ctx context.Context, link string, args ...interface{}) ([]api.Application, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListContext(ctx, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a1...)
+}
+
+// ListByLink implements api.ApplicationsService
+func (m *ApplicationsService) ListByLink(a0 string, a1 ...interface{}) ([]api.Application, *api.ListParams, error) {
+	return m.ListByLinkContext(context.Background(), a0, a1...)
+}
+
+// ListByLinkContext implements api.ApplicationsService
+func (m *ApplicationsService) ListByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Application, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByLink", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByLink", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByLinkFunc != nil {
+		return m.ListByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByLink implements api.ApplicationsService
+func (m *ApplicationsService) IterateByLink(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Application] {
+	first := true
+	return api.NewIterator(a0, "IterateByLink", func(ctx context.Context, link string, args ...interface{}) ([]api.Application, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByLinkContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// Create implements api.ApplicationsService
+func (m *ApplicationsService) Create(a0 *api.ApplicationRequestCreate) (*api.Application, error) {
+	return m.CreateContext(context.Background(), a0)
+}
+
+// CreateContext implements api.ApplicationsService
+func (m *ApplicationsService) CreateContext(a0 context.Context, a1 *api.ApplicationRequestCreate) (r0 *api.Application, r1 error) {
+	if res, ok := m.record("Create", 2, a0, a1); ok {
+		assign("Create", res, &r0, &r1)
+		return
+	}
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0, a1)
+	}
+	return
+}
+
+// UpdateById implements api.ApplicationsService
+func (m *ApplicationsService) UpdateById(a0 string, a1 *api.ApplicationRequestUpdate) (*api.Application, error) {
+	return m.UpdateByIdContext(context.Background(), a0, a1)
+}
+
+// UpdateByIdContext implements api.ApplicationsService
+func (m *ApplicationsService) UpdateByIdContext(a0 context.Context, a1 string, a2 *api.ApplicationRequestUpdate) (r0 *api.Application, r1 error) {
+	if res, ok := m.record("UpdateById", 2, a0, a1, a2); ok {
+		assign("UpdateById", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByIdFunc != nil {
+		return m.UpdateByIdFunc(a0, a1, a2)
+	}
+	return
+}
+
+// UpdateByLink implements api.ApplicationsService
+func (m *ApplicationsService) UpdateByLink(a0 string, a1 *api.ApplicationRequestUpdate) (*api.Application, error) {
+	return m.UpdateByLinkContext(context.Background(), a0, a1)
+}
+
+// UpdateByLinkContext implements api.ApplicationsService
+func (m *ApplicationsService) UpdateByLinkContext(a0 context.Context, a1 string, a2 *api.ApplicationRequestUpdate) (r0 *api.Application, r1 error) {
+	if res, ok := m.record("UpdateByLink", 2, a0, a1, a2); ok {
+		assign("UpdateByLink", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByLinkFunc != nil {
+		return m.UpdateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// Delete implements api.ApplicationsService
+func (m *ApplicationsService) Delete(a0 *api.Application) error {
+	return m.DeleteContext(context.Background(), a0)
+}
+
+// DeleteContext implements api.ApplicationsService
+func (m *ApplicationsService) DeleteContext(a0 context.Context, a1 *api.Application) (r0 error) {
+	if res, ok := m.record("Delete", 1, a0, a1); ok {
+		assign("Delete", res, &r0)
+		return
+	}
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteByLink implements api.ApplicationsService
+func (m *ApplicationsService) DeleteByLink(a0 string) error {
+	return m.DeleteByLinkContext(context.Background(), a0)
+}
+
+// DeleteByLinkContext implements api.ApplicationsService
+func (m *ApplicationsService) DeleteByLinkContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteByLink", 1, a0, a1); ok {
+		assign("DeleteByLink", res, &r0)
+		return
+	}
+	if m.DeleteByLinkFunc != nil {
+		return m.DeleteByLinkFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteById implements api.ApplicationsService
+func (m *ApplicationsService) DeleteById(a0 string) error {
+	return m.DeleteByIdContext(context.Background(), a0)
+}
+
+// DeleteByIdContext implements api.ApplicationsService
+func (m *ApplicationsService) DeleteByIdContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteById", 1, a0, a1); ok {
+		assign("DeleteById", res, &r0)
+		return
+	}
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(a0, a1)
+	}
+	return
+}
+
+// ClusterMembershipsService is a fake of api.ClusterMembershipsService. Fields ending with Func implement methods
+// of the same name and their Context variants.
+type ClusterMembershipsService struct {
+	Mock
+
+	GetByIdFunc         func(a0 context.Context, a1 string, a2 ...interface{}) (*api.ClusterMembership, error)
+	GetByLinkFunc       func(a0 context.Context, a1 string, a2 ...interface{}) (*api.ClusterMembership, error)
+	ListByLinkFunc      func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.ClusterMembership, *api.ListParams, error)
+	ListByDeviceFunc    func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.ClusterMembership, *api.ListParams, error)
+	ListByClusterFunc   func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.ClusterMembership, *api.ListParams, error)
+	CreateByLinkFunc    func(a0 context.Context, a1 string, a2 *api.ClusterMembershipRequestCreate) (*api.ClusterMembership, error)
+	CreateByDeviceFunc  func(a0 context.Context, a1 string, a2 *api.ClusterMembershipRequestCreate) (*api.ClusterMembership, error)
+	CreateByClusterFunc func(a0 context.Context, a1 string, a2 *api.ClusterMembershipRequestCreate) (*api.ClusterMembership, error)
+	DeleteFunc          func(a0 context.Context, a1 *api.ClusterMembership) error
+	DeleteByLinkFunc    func(a0 context.Context, a1 string) error
+	DeleteByIdFunc      func(a0 context.Context, a1 string) error
+}
+
+var _ api.ClusterMembershipsService = (*ClusterMembershipsService)(nil)
+
+// GetById implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) GetById(a0 string, a1 ...interface{}) (*api.ClusterMembership, error) {
+	return m.GetByIdContext(context.Background(), a0, a1...)
+}
+
+// GetByIdContext implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) GetByIdContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.ClusterMembership, r1 error) {
+	if res, ok := m.record("GetById", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetById", res, &r0, &r1)
+		return
+	}
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// GetByLink implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) GetByLink(a0 string, a1 ...interface{}) (*api.ClusterMembership, error) {
+	return m.GetByLinkContext(context.Background(), a0, a1...)
+}
+
+// GetByLinkContext implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) GetByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.ClusterMembership, r1 error) {
+	if res, ok := m.record("GetByLink", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetByLink", res, &r0, &r1)
+		return
+	}
+	if m.GetByLinkFunc != nil {
+		return m.GetByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// ListByLink implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) ListByLink(a0 string, a1 ...interface{}) ([]api.ClusterMembership, *api.ListParams, error) {
+	return m.ListByLinkContext(context.Background(), a0, a1...)
+}
+
+// ListByLinkContext implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) ListByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.ClusterMembership, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByLink", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByLink", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByLinkFunc != nil {
+		return m.ListByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByLink implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) IterateByLink(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.ClusterMembership] {
+	first := true
+	return api.NewIterator(a0, "IterateByLink", func(ctx context.Context, link string, args ...interface{}) ([]api.ClusterMembership, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByLinkContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// ListByDevice implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) ListByDevice(a0 string, a1 ...interface{}) ([]api.ClusterMembership, *api.ListParams, error) {
+	return m.ListByDeviceContext(context.Background(), a0, a1...)
+}
+
+// ListByDeviceContext implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) ListByDeviceContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.ClusterMembership, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByDevice", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByDevice", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByDeviceFunc != nil {
+		return m.ListByDeviceFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByDevice implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) IterateByDevice(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.ClusterMembership] {
+	first := true
+	return api.NewIterator(a0, "IterateByDevice", func(ctx context.Context, link string, args ...interface{}) ([]api.ClusterMembership, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByDeviceContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// ListByCluster implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) ListByCluster(a0 string, a1 ...interface{}) ([]api.ClusterMembership, *api.ListParams, error) {
+	return m.ListByClusterContext(context.Background(), a0, a1...)
+}
+
+// ListByClusterContext implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) ListByClusterContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.ClusterMembership, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByCluster", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByCluster", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByClusterFunc != nil {
+		return m.ListByClusterFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByCluster implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) IterateByCluster(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.ClusterMembership] {
+	first := true
+	return api.NewIterator(a0, "IterateByCluster", func(ctx context.Context, link string, args ...interface{}) ([]api.ClusterMembership, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByClusterContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// CreateByLink implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) CreateByLink(a0 string, a1 *api.ClusterMembershipRequestCreate) (*api.ClusterMembership, error) {
+	return m.CreateByLinkContext(context.Background(), a0, a1)
+}
+
+// CreateByLinkContext implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) CreateByLinkContext(a0 context.Context, a1 string, a2 *api.ClusterMembershipRequestCreate) (r0 *api.ClusterMembership, r1 error) {
+	if res, ok := m.record("CreateByLink", 2, a0, a1, a2); ok {
+		assign("CreateByLink", res, &r0, &r1)
+		return
+	}
+	if m.CreateByLinkFunc != nil {
+		return m.CreateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// CreateByDevice implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) CreateByDevice(a0 string, a1 *api.ClusterMembershipRequestCreate) (*api.ClusterMembership, error) {
+	return m.CreateByDeviceContext(context.Background(), a0, a1)
+}
+
+// CreateByDeviceContext implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) CreateByDeviceContext(a0 context.Context, a1 string, a2 *api.ClusterMembershipRequestCreate) (r0 *api.ClusterMembership, r1 error) {
+	if res, ok := m.record("CreateByDevice", 2, a0, a1, a2); ok {
+		assign("CreateByDevice", res, &r0, &r1)
+		return
+	}
+	if m.CreateByDeviceFunc != nil {
+		return m.CreateByDeviceFunc(a0, a1, a2)
+	}
+	return
+}
+
+// CreateByCluster implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) CreateByCluster(a0 string, a1 *api.ClusterMembershipRequestCreate) (*api.ClusterMembership, error) {
+	return m.CreateByClusterContext(context.Background(), a0, a1)
+}
+
+// CreateByClusterContext implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) CreateByClusterContext(a0 context.Context, a1 string, a2 *api.ClusterMembershipRequestCreate) (r0 *api.ClusterMembership, r1 error) {
+	if res, ok := m.record("CreateByCluster", 2, a0, a1, a2); ok {
+		assign("CreateByCluster", res, &r0, &r1)
+		return
+	}
+	if m.CreateByClusterFunc != nil {
+		return m.CreateByClusterFunc(a0, a1, a2)
+	}
+	return
+}
+
+// Delete implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) Delete(a0 *api.ClusterMembership) error {
+	return m.DeleteContext(context.Background(), a0)
+}
+
+// DeleteContext implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) DeleteContext(a0 context.Context, a1 *api.ClusterMembership) (r0 error) {
+	if res, ok := m.record("Delete", 1, a0, a1); ok {
+		assign("Delete", res, &r0)
+		return
+	}
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteByLink implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) DeleteByLink(a0 string) error {
+	return m.DeleteByLinkContext(context.Background(), a0)
+}
+
+// DeleteByLinkContext implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) DeleteByLinkContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteByLink", 1, a0, a1); ok {
+		assign("DeleteByLink", res, &r0)
+		return
+	}
+	if m.DeleteByLinkFunc != nil {
+		return m.DeleteByLinkFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteById implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) DeleteById(a0 string) error {
+	return m.DeleteByIdContext(context.Background(), a0)
+}
+
+// DeleteByIdContext implements api.ClusterMembershipsService
+func (m *ClusterMembershipsService) DeleteByIdContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteById", 1, a0, a1); ok {
+		assign("DeleteById", res, &r0)
+		return
+	}
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(a0, a1)
+	}
+	return
+}
+
+// ClustersService is a fake of api.ClustersService. Fields ending with Func implement methods
+// of the same name and their Context variants.
+type ClustersService struct {
+	Mock
+
+	GetByIdFunc             func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Cluster, error)
+	GetByLinkFunc           func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Cluster, error)
+	ListByLinkFunc          func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Cluster, *api.ListParams, error)
+	ListByApplicationFunc   func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Cluster, *api.ListParams, error)
+	ListByDeviceFunc        func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Cluster, *api.ListParams, error)
+	CreateByLinkFunc        func(a0 context.Context, a1 string, a2 *api.ClusterRequestCreate) (*api.Cluster, error)
+	CreateByApplicationFunc func(a0 context.Context, a1 string, a2 *api.ClusterRequestCreate) (*api.Cluster, error)
+	UpdateByIdFunc          func(a0 context.Context, a1 string, a2 *api.ClusterRequestUpdate) (*api.Cluster, error)
+	UpdateByLinkFunc        func(a0 context.Context, a1 string, a2 *api.ClusterRequestUpdate) (*api.Cluster, error)
+	DeleteFunc              func(a0 context.Context, a1 *api.Cluster) error
+	DeleteByLinkFunc        func(a0 context.Context, a1 string) error
+	DeleteByIdFunc          func(a0 context.Context, a1 string) error
+}
+
+var _ api.ClustersService = (*ClustersService)(nil)
+
+// GetById implements api.ClustersService
+func (m *ClustersService) GetById(a0 string, a1 ...interface{}) (*api.Cluster, error) {
+	return m.GetByIdContext(context.Background(), a0, a1...)
+}
+
+// GetByIdContext implements api.ClustersService
+func (m *ClustersService) GetByIdContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Cluster, r1 error) {
+	if res, ok := m.record("GetById", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetById", res, &r0, &r1)
+		return
+	}
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// GetByLink implements api.ClustersService
+func (m *ClustersService) GetByLink(a0 string, a1 ...interface{}) (*api.Cluster, error) {
+	return m.GetByLinkContext(context.Background(), a0, a1...)
+}
+
+// GetByLinkContext implements api.ClustersService
+func (m *ClustersService) GetByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Cluster, r1 error) {
+	if res, ok := m.record("GetByLink", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetByLink", res, &r0, &r1)
+		return
+	}
+	if m.GetByLinkFunc != nil {
+		return m.GetByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// ListByLink implements api.ClustersService
+func (m *ClustersService) ListByLink(a0 string, a1 ...interface{}) ([]api.Cluster, *api.ListParams, error) {
+	return m.ListByLinkContext(context.Background(), a0, a1...)
+}
+
+// ListByLinkContext implements api.ClustersService
+func (m *ClustersService) ListByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Cluster, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByLink", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByLink", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByLinkFunc != nil {
+		return m.ListByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByLink implements api.ClustersService
+func (m *ClustersService) IterateByLink(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Cluster] {
+	first := true
+	return api.NewIterator(a0, "IterateByLink", func(ctx context.Context, link string, args ...interface{}) ([]api.Cluster, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByLinkContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// ListByApplication implements api.ClustersService
+func (m *ClustersService) ListByApplication(a0 string, a1 ...interface{}) ([]api.Cluster, *api.ListParams, error) {
+	return m.ListByApplicationContext(context.Background(), a0, a1...)
+}
+
+// ListByApplicationContext implements api.ClustersService
+func (m *ClustersService) ListByApplicationContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Cluster, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByApplication", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByApplication", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByApplicationFunc != nil {
+		return m.ListByApplicationFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByApplication implements api.ClustersService
+func (m *ClustersService) IterateByApplication(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Cluster] {
+	first := true
+	return api.NewIterator(a0, "IterateByApplication", func(ctx context.Context, link string, args ...interface{}) ([]api.Cluster, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByApplicationContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// ListByDevice implements api.ClustersService
+func (m *ClustersService) ListByDevice(a0 string, a1 ...interface{}) ([]api.Cluster, *api.ListParams, error) {
+	return m.ListByDeviceContext(context.Background(), a0, a1...)
+}
+
+// ListByDeviceContext implements api.ClustersService
+func (m *ClustersService) ListByDeviceContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Cluster, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByDevice", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByDevice", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByDeviceFunc != nil {
+		return m.ListByDeviceFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByDevice implements api.ClustersService
+func (m *ClustersService) IterateByDevice(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Cluster] {
+	first := true
+	return api.NewIterator(a0, "IterateByDevice", func(ctx context.Context, link string, args ...interface{}) ([]api.Cluster, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByDeviceContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// CreateByLink implements api.ClustersService
+func (m *ClustersService) CreateByLink(a0 string, a1 *api.ClusterRequestCreate) (*api.Cluster, error) {
+	return m.CreateByLinkContext(context.Background(), a0, a1)
+}
+
+// CreateByLinkContext implements api.ClustersService
+func (m *ClustersService) CreateByLinkContext(a0 context.Context, a1 string, a2 *api.ClusterRequestCreate) (r0 *api.Cluster, r1 error) {
+	if res, ok := m.record("CreateByLink", 2, a0, a1, a2); ok {
+		assign("CreateByLink", res, &r0, &r1)
+		return
+	}
+	if m.CreateByLinkFunc != nil {
+		return m.CreateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// CreateByApplication implements api.ClustersService
+func (m *ClustersService) CreateByApplication(a0 string, a1 *api.ClusterRequestCreate) (*api.Cluster, error) {
+	return m.CreateByApplicationContext(context.Background(), a0, a1)
+}
+
+// CreateByApplicationContext implements api.ClustersService
+func (m *ClustersService) CreateByApplicationContext(a0 context.Context, a1 string, a2 *api.ClusterRequestCreate) (r0 *api.Cluster, r1 error) {
+	if res, ok := m.record("CreateByApplication", 2, a0, a1, a2); ok {
+		assign("CreateByApplication", res, &r0, &r1)
+		return
+	}
+	if m.CreateByApplicationFunc != nil {
+		return m.CreateByApplicationFunc(a0, a1, a2)
+	}
+	return
+}
+
+// UpdateById implements api.ClustersService
+func (m *ClustersService) UpdateById(a0 string, a1 *api.ClusterRequestUpdate) (*api.Cluster, error) {
+	return m.UpdateByIdContext(context.Background(), a0, a1)
+}
+
+// UpdateByIdContext implements api.ClustersService
+func (m *ClustersService) UpdateByIdContext(a0 context.Context, a1 string, a2 *api.ClusterRequestUpdate) (r0 *api.Cluster, r1 error) {
+	if res, ok := m.record("UpdateById", 2, a0, a1, a2); ok {
+		assign("UpdateById", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByIdFunc != nil {
+		return m.UpdateByIdFunc(a0, a1, a2)
+	}
+	return
+}
+
+// UpdateByLink implements api.ClustersService
+func (m *ClustersService) UpdateByLink(a0 string, a1 *api.ClusterRequestUpdate) (*api.Cluster, error) {
+	return m.UpdateByLinkContext(context.Background(), a0, a1)
+}
+
+// UpdateByLinkContext implements api.ClustersService
+func (m *ClustersService) UpdateByLinkContext(a0 context.Context, a1 string, a2 *api.ClusterRequestUpdate) (r0 *api.Cluster, r1 error) {
+	if res, ok := m.record("UpdateByLink", 2, a0, a1, a2); ok {
+		assign("UpdateByLink", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByLinkFunc != nil {
+		return m.UpdateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// Delete implements api.ClustersService
+func (m *ClustersService) Delete(a0 *api.Cluster) error {
+	return m.DeleteContext(context.Background(), a0)
+}
+
+// DeleteContext implements api.ClustersService
+func (m *ClustersService) DeleteContext(a0 context.Context, a1 *api.Cluster) (r0 error) {
+	if res, ok := m.record("Delete", 1, a0, a1); ok {
+		assign("Delete", res, &r0)
+		return
+	}
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteByLink implements api.ClustersService
+func (m *ClustersService) DeleteByLink(a0 string) error {
+	return m.DeleteByLinkContext(context.Background(), a0)
+}
+
+// DeleteByLinkContext implements api.ClustersService
+func (m *ClustersService) DeleteByLinkContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteByLink", 1, a0, a1); ok {
+		assign("DeleteByLink", res, &r0)
+		return
+	}
+	if m.DeleteByLinkFunc != nil {
+		return m.DeleteByLinkFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteById implements api.ClustersService
+func (m *ClustersService) DeleteById(a0 string) error {
+	return m.DeleteByIdContext(context.Background(), a0)
+}
+
+// DeleteByIdContext implements api.ClustersService
+func (m *ClustersService) DeleteByIdContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteById", 1, a0, a1); ok {
+		assign("DeleteById", res, &r0)
+		return
+	}
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(a0, a1)
+	}
+	return
+}
+
+// DevicesService is a fake of api.DevicesService. Fields ending with Func implement methods
+// of the same name and their Context variants.
+type DevicesService struct {
+	Mock
+
+	GetByIdFunc           func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Device, error)
+	GetByLinkFunc         func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Device, error)
+	ListByLinkFunc        func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Device, *api.ListParams, error)
+	ListByClusterFunc     func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Device, *api.ListParams, error)
+	ListByApplicationFunc func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Device, *api.ListParams, error)
+	ListByGroupFunc       func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Device, *api.ListParams, error)
+	ListByProductFunc     func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Device, *api.ListParams, error)
+	CreateByLinkFunc      func(a0 context.Context, a1 string, a2 *api.DeviceRequestCreate) (*api.Device, error)
+	CreateByProductFunc   func(a0 context.Context, a1 string, a2 *api.DeviceRequestCreate) (*api.Device, error)
+	UpdateByIdFunc        func(a0 context.Context, a1 string, a2 *api.DeviceRequestUpdate) (*api.Device, error)
+	UpdateByLinkFunc      func(a0 context.Context, a1 string, a2 *api.DeviceRequestUpdate) (*api.Device, error)
+	DeleteFunc            func(a0 context.Context, a1 *api.Device) error
+	DeleteByLinkFunc      func(a0 context.Context, a1 string) error
+	DeleteByIdFunc        func(a0 context.Context, a1 string) error
+}
+
+var _ api.DevicesService = (*DevicesService)(nil)
+
+// GetById implements api.DevicesService
+func (m *DevicesService) GetById(a0 string, a1 ...interface{}) (*api.Device, error) {
+	return m.GetByIdContext(context.Background(), a0, a1...)
+}
+
+// GetByIdContext implements api.DevicesService
+func (m *DevicesService) GetByIdContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Device, r1 error) {
+	if res, ok := m.record("GetById", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetById", res, &r0, &r1)
+		return
+	}
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// GetByLink implements api.DevicesService
+func (m *DevicesService) GetByLink(a0 string, a1 ...interface{}) (*api.Device, error) {
+	return m.GetByLinkContext(context.Background(), a0, a1...)
+}
+
+// GetByLinkContext implements api.DevicesService
+func (m *DevicesService) GetByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Device, r1 error) {
+	if res, ok := m.record("GetByLink", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetByLink", res, &r0, &r1)
+		return
+	}
+	if m.GetByLinkFunc != nil {
+		return m.GetByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// ListByLink implements api.DevicesService
+func (m *DevicesService) ListByLink(a0 string, a1 ...interface{}) ([]api.Device, *api.ListParams, error) {
+	return m.ListByLinkContext(context.Background(), a0, a1...)
+}
+
+// ListByLinkContext implements api.DevicesService
+func (m *DevicesService) ListByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Device, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByLink", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByLink", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByLinkFunc != nil {
+		return m.ListByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByLink implements api.DevicesService
+func (m *DevicesService) IterateByLink(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Device] {
+	first := true
+	return api.NewIterator(a0, "IterateByLink", func(ctx context.Context, link string, args ...interface{}) ([]api.Device, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByLinkContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// ListByCluster implements api.DevicesService
+func (m *DevicesService) ListByCluster(a0 string, a1 ...interface{}) ([]api.Device, *api.ListParams, error) {
+	return m.ListByClusterContext(context.Background(), a0, a1...)
+}
+
+// ListByClusterContext implements api.DevicesService
+func (m *DevicesService) ListByClusterContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Device, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByCluster", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByCluster", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByClusterFunc != nil {
+		return m.ListByClusterFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByCluster implements api.DevicesService
+func (m *DevicesService) IterateByCluster(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Device] {
+	first := true
+	return api.NewIterator(a0, "IterateByCluster", func(ctx context.Context, link string, args ...interface{}) ([]api.Device, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByClusterContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// ListByApplication implements api.DevicesService
+func (m *DevicesService) ListByApplication(a0 string, a1 ...interface{}) ([]api.Device, *api.ListParams, error) {
+	return m.ListByApplicationContext(context.Background(), a0, a1...)
+}
+
+// ListByApplicationContext implements api.DevicesService
+func (m *DevicesService) ListByApplicationContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Device, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByApplication", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByApplication", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByApplicationFunc != nil {
+		return m.ListByApplicationFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByApplication implements api.DevicesService
+func (m *DevicesService) IterateByApplication(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Device] {
+	first := true
+	return api.NewIterator(a0, "IterateByApplication", func(ctx context.Context, link string, args ...interface{}) ([]api.Device, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByApplicationContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// ListByGroup implements api.DevicesService
+func (m *DevicesService) ListByGroup(a0 string, a1 ...interface{}) ([]api.Device, *api.ListParams, error) {
+	return m.ListByGroupContext(context.Background(), a0, a1...)
+}
+
+// ListByGroupContext implements api.DevicesService
+func (m *DevicesService) ListByGroupContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Device, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByGroup", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByGroup", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByGroupFunc != nil {
+		return m.ListByGroupFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByGroup implements api.DevicesService
+func (m *DevicesService) IterateByGroup(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Device] {
+	first := true
+	return api.NewIterator(a0, "IterateByGroup", func(ctx context.Context, link string, args ...interface{}) ([]api.Device, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByGroupContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// ListByProduct implements api.DevicesService
+func (m *DevicesService) ListByProduct(a0 string, a1 ...interface{}) ([]api.Device, *api.ListParams, error) {
+	return m.ListByProductContext(context.Background(), a0, a1...)
+}
+
+// ListByProductContext implements api.DevicesService
+func (m *DevicesService) ListByProductContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Device, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByProduct", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByProduct", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByProductFunc != nil {
+		return m.ListByProductFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByProduct implements api.DevicesService
+func (m *DevicesService) IterateByProduct(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Device] {
+	first := true
+	return api.NewIterator(a0, "IterateByProduct", func(ctx context.Context, link string, args ...interface{}) ([]api.Device, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByProductContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// CreateByLink implements api.DevicesService
+func (m *DevicesService) CreateByLink(a0 string, a1 *api.DeviceRequestCreate) (*api.Device, error) {
+	return m.CreateByLinkContext(context.Background(), a0, a1)
+}
+
+// CreateByLinkContext implements api.DevicesService
+func (m *DevicesService) CreateByLinkContext(a0 context.Context, a1 string, a2 *api.DeviceRequestCreate) (r0 *api.Device, r1 error) {
+	if res, ok := m.record("CreateByLink", 2, a0, a1, a2); ok {
+		assign("CreateByLink", res, &r0, &r1)
+		return
+	}
+	if m.CreateByLinkFunc != nil {
+		return m.CreateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// CreateByProduct implements api.DevicesService
+func (m *DevicesService) CreateByProduct(a0 string, a1 *api.DeviceRequestCreate) (*api.Device, error) {
+	return m.CreateByProductContext(context.Background(), a0, a1)
+}
+
+// CreateByProductContext implements api.DevicesService
+func (m *DevicesService) CreateByProductContext(a0 context.Context, a1 string, a2 *api.DeviceRequestCreate) (r0 *api.Device, r1 error) {
+	if res, ok := m.record("CreateByProduct", 2, a0, a1, a2); ok {
+		assign("CreateByProduct", res, &r0, &r1)
+		return
+	}
+	if m.CreateByProductFunc != nil {
+		return m.CreateByProductFunc(a0, a1, a2)
+	}
+	return
+}
+
+// UpdateById implements api.DevicesService
+func (m *DevicesService) UpdateById(a0 string, a1 *api.DeviceRequestUpdate) (*api.Device, error) {
+	return m.UpdateByIdContext(context.Background(), a0, a1)
+}
+
+// UpdateByIdContext implements api.DevicesService
+func (m *DevicesService) UpdateByIdContext(a0 context.Context, a1 string, a2 *api.DeviceRequestUpdate) (r0 *api.Device, r1 error) {
+	if res, ok := m.record("UpdateById", 2, a0, a1, a2); ok {
+		assign("UpdateById", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByIdFunc != nil {
+		return m.UpdateByIdFunc(a0, a1, a2)
+	}
+	return
+}
+
+// UpdateByLink implements api.DevicesService
+func (m *DevicesService) UpdateByLink(a0 string, a1 *api.DeviceRequestUpdate) (*api.Device, error) {
+	return m.UpdateByLinkContext(context.Background(), a0, a1)
+}
+
+// UpdateByLinkContext implements api.DevicesService
+func (m *DevicesService) UpdateByLinkContext(a0 context.Context, a1 string, a2 *api.DeviceRequestUpdate) (r0 *api.Device, r1 error) {
+	if res, ok := m.record("UpdateByLink", 2, a0, a1, a2); ok {
+		assign("UpdateByLink", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByLinkFunc != nil {
+		return m.UpdateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// Delete implements api.DevicesService
+func (m *DevicesService) Delete(a0 *api.Device) error {
+	return m.DeleteContext(context.Background(), a0)
+}
+
+// DeleteContext implements api.DevicesService
+func (m *DevicesService) DeleteContext(a0 context.Context, a1 *api.Device) (r0 error) {
+	if res, ok := m.record("Delete", 1, a0, a1); ok {
+		assign("Delete", res, &r0)
+		return
+	}
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteByLink implements api.DevicesService
+func (m *DevicesService) DeleteByLink(a0 string) error {
+	return m.DeleteByLinkContext(context.Background(), a0)
+}
+
+// DeleteByLinkContext implements api.DevicesService
+func (m *DevicesService) DeleteByLinkContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteByLink", 1, a0, a1); ok {
+		assign("DeleteByLink", res, &r0)
+		return
+	}
+	if m.DeleteByLinkFunc != nil {
+		return m.DeleteByLinkFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteById implements api.DevicesService
+func (m *DevicesService) DeleteById(a0 string) error {
+	return m.DeleteByIdContext(context.Background(), a0)
+}
+
+// DeleteByIdContext implements api.DevicesService
+func (m *DevicesService) DeleteByIdContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteById", 1, a0, a1); ok {
+		assign("DeleteById", res, &r0)
+		return
+	}
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(a0, a1)
+	}
+	return
+}
+
+// DirectoriesService is a fake of api.DirectoriesService. Fields ending with Func implement methods
+// of the same name and their Context variants.
+type DirectoriesService struct {
+	Mock
+
+	GetByIdFunc      func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Directory, error)
+	GetByLinkFunc    func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Directory, error)
+	ListFunc         func(a0 context.Context, a1 ...interface{}) ([]api.Directory, *api.ListParams, error)
+	ListByLinkFunc   func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Directory, *api.ListParams, error)
+	CreateFunc       func(a0 context.Context, a1 *api.DirectoryRequestCreate) (*api.Directory, error)
+	UpdateByIdFunc   func(a0 context.Context, a1 string, a2 *api.DirectoryRequestUpdate) (*api.Directory, error)
+	UpdateByLinkFunc func(a0 context.Context, a1 string, a2 *api.DirectoryRequestUpdate) (*api.Directory, error)
+	DeleteFunc       func(a0 context.Context, a1 *api.Directory) error
+	DeleteByLinkFunc func(a0 context.Context, a1 string) error
+	DeleteByIdFunc   func(a0 context.Context, a1 string) error
+}
+
+var _ api.DirectoriesService = (*DirectoriesService)(nil)
+
+// GetById implements api.DirectoriesService
+func (m *DirectoriesService) GetById(a0 string, a1 ...interface{}) (*api.Directory, error) {
+	return m.GetByIdContext(context.Background(), a0, a1...)
+}
+
+// GetByIdContext implements api.DirectoriesService
+func (m *DirectoriesService) GetByIdContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Directory, r1 error) {
+	if res, ok := m.record("GetById", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetById", res, &r0, &r1)
+		return
+	}
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// GetByLink implements api.DirectoriesService
+func (m *DirectoriesService) GetByLink(a0 string, a1 ...interface{}) (*api.Directory, error) {
+	return m.GetByLinkContext(context.Background(), a0, a1...)
+}
+
+// GetByLinkContext implements api.DirectoriesService
+func (m *DirectoriesService) GetByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Directory, r1 error) {
+	if res, ok := m.record("GetByLink", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetByLink", res, &r0, &r1)
+		return
+	}
+	if m.GetByLinkFunc != nil {
+		return m.GetByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// List implements api.DirectoriesService
+func (m *DirectoriesService) List(a0 ...interface{}) ([]api.Directory, *api.ListParams, error) {
+	return m.ListContext(context.Background(), a0...)
+}
+
+// ListContext implements api.DirectoriesService
+func (m *DirectoriesService) ListContext(a0 context.Context, a1 ...interface{}) (r0 []api.Directory, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("List", 3, a0, a1...); ok {
+		assign("List", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListFunc != nil {
+		return m.ListFunc(a0, a1...)
+	}
+	return
+}
+
+// Iterate implements api.DirectoriesService
+func (m *DirectoriesService) Iterate(a0 context.Context, a1 ...interface{}) *api.Iterator[api.Directory] {
+	first := true
+	return api.NewIterator(a0, "Iterate", func(ctx context.Context, link string, args ...interface{}) ([]api.Directory, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListContext(ctx, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a1...)
+}
+
+// ListByLink implements api.DirectoriesService
+func (m *DirectoriesService) ListByLink(a0 string, a1 ...interface{}) ([]api.Directory, *api.ListParams, error) {
+	return m.ListByLinkContext(context.Background(), a0, a1...)
+}
+
+// ListByLinkContext implements api.DirectoriesService
+func (m *DirectoriesService) ListByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Directory, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByLink", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByLink", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByLinkFunc != nil {
+		return m.ListByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByLink implements api.DirectoriesService
+func (m *DirectoriesService) IterateByLink(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Directory] {
+	first := true
+	return api.NewIterator(a0, "IterateByLink", func(ctx context.Context, link string, args ...interface{}) ([]api.Directory, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByLinkContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// Create implements api.DirectoriesService
+func (m *DirectoriesService) Create(a0 *api.DirectoryRequestCreate) (*api.Directory, error) {
+	return m.CreateContext(context.Background(), a0)
+}
+
+// CreateContext implements api.DirectoriesService
+func (m *DirectoriesService) CreateContext(a0 context.Context, a1 *api.DirectoryRequestCreate) (r0 *api.Directory, r1 error) {
+	if res, ok := m.record("Create", 2, a0, a1); ok {
+		assign("Create", res, &r0, &r1)
+		return
+	}
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0, a1)
+	}
+	return
+}
+
+// UpdateById implements api.DirectoriesService
+func (m *DirectoriesService) UpdateById(a0 string, a1 *api.DirectoryRequestUpdate) (*api.Directory, error) {
+	return m.UpdateByIdContext(context.Background(), a0, a1)
+}
+
+// UpdateByIdContext implements api.DirectoriesService
+func (m *DirectoriesService) UpdateByIdContext(a0 context.Context, a1 string, a2 *api.DirectoryRequestUpdate) (r0 *api.Directory, r1 error) {
+	if res, ok := m.record("UpdateById", 2, a0, a1, a2); ok {
+		assign("UpdateById", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByIdFunc != nil {
+		return m.UpdateByIdFunc(a0, a1, a2)
+	}
+	return
+}
+
+// UpdateByLink implements api.DirectoriesService
+func (m *DirectoriesService) UpdateByLink(a0 string, a1 *api.DirectoryRequestUpdate) (*api.Directory, error) {
+	return m.UpdateByLinkContext(context.Background(), a0, a1)
+}
+
+// UpdateByLinkContext implements api.DirectoriesService
+func (m *DirectoriesService) UpdateByLinkContext(a0 context.Context, a1 string, a2 *api.DirectoryRequestUpdate) (r0 *api.Directory, r1 error) {
+	if res, ok := m.record("UpdateByLink", 2, a0, a1, a2); ok {
+		assign("UpdateByLink", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByLinkFunc != nil {
+		return m.UpdateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// Delete implements api.DirectoriesService
+func (m *DirectoriesService) Delete(a0 *api.Directory) error {
+	return m.DeleteContext(context.Background(), a0)
+}
+
+// DeleteContext implements api.DirectoriesService
+func (m *DirectoriesService) DeleteContext(a0 context.Context, a1 *api.Directory) (r0 error) {
+	if res, ok := m.record("Delete", 1, a0, a1); ok {
+		assign("Delete", res, &r0)
+		return
+	}
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteByLink implements api.DirectoriesService
+func (m *DirectoriesService) DeleteByLink(a0 string) error {
+	return m.DeleteByLinkContext(context.Background(), a0)
+}
+
+// DeleteByLinkContext implements api.DirectoriesService
+func (m *DirectoriesService) DeleteByLinkContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteByLink", 1, a0, a1); ok {
+		assign("DeleteByLink", res, &r0)
+		return
+	}
+	if m.DeleteByLinkFunc != nil {
+		return m.DeleteByLinkFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteById implements api.DirectoriesService
+func (m *DirectoriesService) DeleteById(a0 string) error {
+	return m.DeleteByIdContext(context.Background(), a0)
+}
+
+// DeleteByIdContext implements api.DirectoriesService
+func (m *DirectoriesService) DeleteByIdContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteById", 1, a0, a1); ok {
+		assign("DeleteById", res, &r0)
+		return
+	}
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(a0, a1)
+	}
+	return
+}
+
+// ExportsService is a fake of api.ExportsService. Fields ending with Func implement methods
+// of the same name and their Context variants.
+type ExportsService struct {
+	Mock
+
+	GetByIdFunc             func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Export, error)
+	GetByLinkFunc           func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Export, error)
+	ListByLinkFunc          func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Export, *api.ListParams, error)
+	ListByApplicationFunc   func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Export, *api.ListParams, error)
+	ListFunc                func(a0 context.Context, a1 ...interface{}) ([]api.Export, *api.ListParams, error)
+	CreateByLinkFunc        func(a0 context.Context, a1 string, a2 *api.ExportRequestCreate) (*api.Export, error)
+	CreateByApplicationFunc func(a0 context.Context, a1 string, a2 *api.ExportRequestCreate) (*api.Export, error)
+	UpdateByIdFunc          func(a0 context.Context, a1 string, a2 *api.ExportRequestUpdate) (*api.Export, error)
+	UpdateByLinkFunc        func(a0 context.Context, a1 string, a2 *api.ExportRequestUpdate) (*api.Export, error)
+	DeleteFunc              func(a0 context.Context, a1 *api.Export) error
+	DeleteByLinkFunc        func(a0 context.Context, a1 string) error
+	DeleteByIdFunc          func(a0 context.Context, a1 string) error
+}
+
+var _ api.ExportsService = (*ExportsService)(nil)
+
+// GetById implements api.ExportsService
+func (m *ExportsService) GetById(a0 string, a1 ...interface{}) (*api.Export, error) {
+	return m.GetByIdContext(context.Background(), a0, a1...)
+}
+
+// GetByIdContext implements api.ExportsService
+func (m *ExportsService) GetByIdContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Export, r1 error) {
+	if res, ok := m.record("GetById", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetById", res, &r0, &r1)
+		return
+	}
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// GetByLink implements api.ExportsService
+func (m *ExportsService) GetByLink(a0 string, a1 ...interface{}) (*api.Export, error) {
+	return m.GetByLinkContext(context.Background(), a0, a1...)
+}
+
+// GetByLinkContext implements api.ExportsService
+func (m *ExportsService) GetByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Export, r1 error) {
+	if res, ok := m.record("GetByLink", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetByLink", res, &r0, &r1)
+		return
+	}
+	if m.GetByLinkFunc != nil {
+		return m.GetByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// ListByLink implements api.ExportsService
+func (m *ExportsService) ListByLink(a0 string, a1 ...interface{}) ([]api.Export, *api.ListParams, error) {
+	return m.ListByLinkContext(context.Background(), a0, a1...)
+}
+
+// ListByLinkContext implements api.ExportsService
+func (m *ExportsService) ListByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Export, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByLink", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByLink", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByLinkFunc != nil {
+		return m.ListByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByLink implements api.ExportsService
+func (m *ExportsService) IterateByLink(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Export] {
+	first := true
+	return api.NewIterator(a0, "IterateByLink", func(ctx context.Context, link string, args ...interface{}) ([]api.Export, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByLinkContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// ListByApplication implements api.ExportsService
+func (m *ExportsService) ListByApplication(a0 string, a1 ...interface{}) ([]api.Export, *api.ListParams, error) {
+	return m.ListByApplicationContext(context.Background(), a0, a1...)
+}
+
+// ListByApplicationContext implements api.ExportsService
+func (m *ExportsService) ListByApplicationContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Export, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByApplication", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByApplication", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByApplicationFunc != nil {
+		return m.ListByApplicationFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByApplication implements api.ExportsService
+func (m *ExportsService) IterateByApplication(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Export] {
+	first := true
+	return api.NewIterator(a0, "IterateByApplication", func(ctx context.Context, link string, args ...interface{}) ([]api.Export, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByApplicationContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// List implements api.ExportsService
+func (m *ExportsService) List(a0 ...interface{}) ([]api.Export, *api.ListParams, error) {
+	return m.ListContext(context.Background(), a0...)
+}
+
+// ListContext implements api.ExportsService
+func (m *ExportsService) ListContext(a0 context.Context, a1 ...interface{}) (r0 []api.Export, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("List", 3, a0, a1...); ok {
+		assign("List", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListFunc != nil {
+		return m.ListFunc(a0, a1...)
+	}
+	return
+}
+
+// Iterate implements api.ExportsService
+func (m *ExportsService) Iterate(a0 context.Context, a1 ...interface{}) *api.Iterator[api.Export] {
+	first := true
+	return api.NewIterator(a0, "Iterate", func(ctx context.Context, link string, args ...interface{}) ([]api.Export, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListContext(ctx, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a1...)
+}
+
+// CreateByLink implements api.ExportsService
+func (m *ExportsService) CreateByLink(a0 string, a1 *api.ExportRequestCreate) (*api.Export, error) {
+	return m.CreateByLinkContext(context.Background(), a0, a1)
+}
+
+// CreateByLinkContext implements api.ExportsService
+func (m *ExportsService) CreateByLinkContext(a0 context.Context, a1 string, a2 *api.ExportRequestCreate) (r0 *api.Export, r1 error) {
+	if res, ok := m.record("CreateByLink", 2, a0, a1, a2); ok {
+		assign("CreateByLink", res, &r0, &r1)
+		return
+	}
+	if m.CreateByLinkFunc != nil {
+		return m.CreateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// CreateByApplication implements api.ExportsService
+func (m *ExportsService) CreateByApplication(a0 string, a1 *api.ExportRequestCreate) (*api.Export, error) {
+	return m.CreateByApplicationContext(context.Background(), a0, a1)
+}
+
+// CreateByApplicationContext implements api.ExportsService
+func (m *ExportsService) CreateByApplicationContext(a0 context.Context, a1 string, a2 *api.ExportRequestCreate) (r0 *api.Export, r1 error) {
+	if res, ok := m.record("CreateByApplication", 2, a0, a1, a2); ok {
+		assign("CreateByApplication", res, &r0, &r1)
+		return
+	}
+	if m.CreateByApplicationFunc != nil {
+		return m.CreateByApplicationFunc(a0, a1, a2)
+	}
+	return
+}
+
+// UpdateById implements api.ExportsService
+func (m *ExportsService) UpdateById(a0 string, a1 *api.ExportRequestUpdate) (*api.Export, error) {
+	return m.UpdateByIdContext(context.Background(), a0, a1)
+}
+
+// UpdateByIdContext implements api.ExportsService
+func (m *ExportsService) UpdateByIdContext(a0 context.Context, a1 string, a2 *api.ExportRequestUpdate) (r0 *api.Export, r1 error) {
+	if res, ok := m.record("UpdateById", 2, a0, a1, a2); ok {
+		assign("UpdateById", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByIdFunc != nil {
+		return m.UpdateByIdFunc(a0, a1, a2)
+	}
+	return
+}
+
+// UpdateByLink implements api.ExportsService
+func (m *ExportsService) UpdateByLink(a0 string, a1 *api.ExportRequestUpdate) (*api.Export, error) {
+	return m.UpdateByLinkContext(context.Background(), a0, a1)
+}
+
+// UpdateByLinkContext implements api.ExportsService
+func (m *ExportsService) UpdateByLinkContext(a0 context.Context, a1 string, a2 *api.ExportRequestUpdate) (r0 *api.Export, r1 error) {
+	if res, ok := m.record("UpdateByLink", 2, a0, a1, a2); ok {
+		assign("UpdateByLink", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByLinkFunc != nil {
+		return m.UpdateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// Delete implements api.ExportsService
+func (m *ExportsService) Delete(a0 *api.Export) error {
+	return m.DeleteContext(context.Background(), a0)
+}
+
+// DeleteContext implements api.ExportsService
+func (m *ExportsService) DeleteContext(a0 context.Context, a1 *api.Export) (r0 error) {
+	if res, ok := m.record("Delete", 1, a0, a1); ok {
+		assign("Delete", res, &r0)
+		return
+	}
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteByLink implements api.ExportsService
+func (m *ExportsService) DeleteByLink(a0 string) error {
+	return m.DeleteByLinkContext(context.Background(), a0)
+}
+
+// DeleteByLinkContext implements api.ExportsService
+func (m *ExportsService) DeleteByLinkContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteByLink", 1, a0, a1); ok {
+		assign("DeleteByLink", res, &r0)
+		return
+	}
+	if m.DeleteByLinkFunc != nil {
+		return m.DeleteByLinkFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteById implements api.ExportsService
+func (m *ExportsService) DeleteById(a0 string) error {
+	return m.DeleteByIdContext(context.Background(), a0)
+}
+
+// DeleteByIdContext implements api.ExportsService
+func (m *ExportsService) DeleteByIdContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteById", 1, a0, a1); ok {
+		assign("DeleteById", res, &r0)
+		return
+	}
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(a0, a1)
+	}
+	return
+}
+
+// GroupMembershipsService is a fake of api.GroupMembershipsService. Fields ending with Func implement methods
+// of the same name and their Context variants.
+type GroupMembershipsService struct {
+	Mock
+
+	GetByIdFunc        func(a0 context.Context, a1 string, a2 ...interface{}) (*api.GroupMembership, error)
+	GetByLinkFunc      func(a0 context.Context, a1 string, a2 ...interface{}) (*api.GroupMembership, error)
+	ListByLinkFunc     func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.GroupMembership, *api.ListParams, error)
+	ListByDeviceFunc   func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.GroupMembership, *api.ListParams, error)
+	ListByGroupFunc    func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.GroupMembership, *api.ListParams, error)
+	CreateByLinkFunc   func(a0 context.Context, a1 string, a2 *api.GroupMembershipRequestCreate) (*api.GroupMembership, error)
+	CreateByDeviceFunc func(a0 context.Context, a1 string, a2 *api.GroupMembershipRequestCreate) (*api.GroupMembership, error)
+	CreateByGroupFunc  func(a0 context.Context, a1 string, a2 *api.GroupMembershipRequestCreate) (*api.GroupMembership, error)
+	DeleteFunc         func(a0 context.Context, a1 *api.GroupMembership) error
+	DeleteByLinkFunc   func(a0 context.Context, a1 string) error
+	DeleteByIdFunc     func(a0 context.Context, a1 string) error
+}
+
+var _ api.GroupMembershipsService = (*GroupMembershipsService)(nil)
+
+// GetById implements api.GroupMembershipsService
+func (m *GroupMembershipsService) GetById(a0 string, a1 ...interface{}) (*api.GroupMembership, error) {
+	return m.GetByIdContext(context.Background(), a0, a1...)
+}
+
+// GetByIdContext implements api.GroupMembershipsService
+func (m *GroupMembershipsService) GetByIdContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.GroupMembership, r1 error) {
+	if res, ok := m.record("GetById", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetById", res, &r0, &r1)
+		return
+	}
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// GetByLink implements api.GroupMembershipsService
+func (m *GroupMembershipsService) GetByLink(a0 string, a1 ...interface{}) (*api.GroupMembership, error) {
+	return m.GetByLinkContext(context.Background(), a0, a1...)
+}
+
+// GetByLinkContext implements api.GroupMembershipsService
+func (m *GroupMembershipsService) GetByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.GroupMembership, r1 error) {
+	if res, ok := m.record("GetByLink", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetByLink", res, &r0, &r1)
+		return
+	}
+	if m.GetByLinkFunc != nil {
+		return m.GetByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// ListByLink implements api.GroupMembershipsService
+func (m *GroupMembershipsService) ListByLink(a0 string, a1 ...interface{}) ([]api.GroupMembership, *api.ListParams, error) {
+	return m.ListByLinkContext(context.Background(), a0, a1...)
+}
+
+// ListByLinkContext implements api.GroupMembershipsService
+func (m *GroupMembershipsService) ListByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.GroupMembership, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByLink", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByLink", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByLinkFunc != nil {
+		return m.ListByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByLink implements api.GroupMembershipsService
+func (m *GroupMembershipsService) IterateByLink(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.GroupMembership] {
+	first := true
+	return api.NewIterator(a0, "IterateByLink", func(ctx context.Context, link string, args ...interface{}) ([]api.GroupMembership, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByLinkContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// ListByDevice implements api.GroupMembershipsService
+func (m *GroupMembershipsService) ListByDevice(a0 string, a1 ...interface{}) ([]api.GroupMembership, *api.ListParams, error) {
+	return m.ListByDeviceContext(context.Background(), a0, a1...)
+}
+
+// ListByDeviceContext implements api.GroupMembershipsService
+func (m *GroupMembershipsService) ListByDeviceContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.GroupMembership, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByDevice", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByDevice", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByDeviceFunc != nil {
+		return m.ListByDeviceFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByDevice implements api.GroupMembershipsService
+func (m *GroupMembershipsService) IterateByDevice(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.GroupMembership] {
+	first := true
+	return api.NewIterator(a0, "IterateByDevice", func(ctx context.Context, link string, args ...interface{}) ([]api.GroupMembership, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByDeviceContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// ListByGroup implements api.GroupMembershipsService
+func (m *GroupMembershipsService) ListByGroup(a0 string, a1 ...interface{}) ([]api.GroupMembership, *api.ListParams, error) {
+	return m.ListByGroupContext(context.Background(), a0, a1...)
+}
+
+// ListByGroupContext implements api.GroupMembershipsService
+func (m *GroupMembershipsService) ListByGroupContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.GroupMembership, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByGroup", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByGroup", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByGroupFunc != nil {
+		return m.ListByGroupFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByGroup implements api.GroupMembershipsService
+func (m *GroupMembershipsService) IterateByGroup(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.GroupMembership] {
+	first := true
+	return api.NewIterator(a0, "IterateByGroup", func(ctx context.Context, link string, args ...interface{}) ([]api.GroupMembership, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByGroupContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// CreateByLink implements api.GroupMembershipsService
+func (m *GroupMembershipsService) CreateByLink(a0 string, a1 *api.GroupMembershipRequestCreate) (*api.GroupMembership, error) {
+	return m.CreateByLinkContext(context.Background(), a0, a1)
+}
+
+// CreateByLinkContext implements api.GroupMembershipsService
+func (m *GroupMembershipsService) CreateByLinkContext(a0 context.Context, a1 string, a2 *api.GroupMembershipRequestCreate) (r0 *api.GroupMembership, r1 error) {
+	if res, ok := m.record("CreateByLink", 2, a0, a1, a2); ok {
+		assign("CreateByLink", res, &r0, &r1)
+		return
+	}
+	if m.CreateByLinkFunc != nil {
+		return m.CreateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// CreateByDevice implements api.GroupMembershipsService
+func (m *GroupMembershipsService) CreateByDevice(a0 string, a1 *api.GroupMembershipRequestCreate) (*api.GroupMembership, error) {
+	return m.CreateByDeviceContext(context.Background(), a0, a1)
+}
+
+// CreateByDeviceContext implements api.GroupMembershipsService
+func (m *GroupMembershipsService) CreateByDeviceContext(a0 context.Context, a1 string, a2 *api.GroupMembershipRequestCreate) (r0 *api.GroupMembership, r1 error) {
+	if res, ok := m.record("CreateByDevice", 2, a0, a1, a2); ok {
+		assign("CreateByDevice", res, &r0, &r1)
+		return
+	}
+	if m.CreateByDeviceFunc != nil {
+		return m.CreateByDeviceFunc(a0, a1, a2)
+	}
+	return
+}
+
+// CreateByGroup implements api.GroupMembershipsService
+func (m *GroupMembershipsService) CreateByGroup(a0 string, a1 *api.GroupMembershipRequestCreate) (*api.GroupMembership, error) {
+	return m.CreateByGroupContext(context.Background(), a0, a1)
+}
+
+// CreateByGroupContext implements api.GroupMembershipsService
+func (m *GroupMembershipsService) CreateByGroupContext(a0 context.Context, a1 string, a2 *api.GroupMembershipRequestCreate) (r0 *api.GroupMembership, r1 error) {
+	if res, ok := m.record("CreateByGroup", 2, a0, a1, a2); ok {
+		assign("CreateByGroup", res, &r0, &r1)
+		return
+	}
+	if m.CreateByGroupFunc != nil {
+		return m.CreateByGroupFunc(a0, a1, a2)
+	}
+	return
+}
+
+// Delete implements api.GroupMembershipsService
+func (m *GroupMembershipsService) Delete(a0 *api.GroupMembership) error {
+	return m.DeleteContext(context.Background(), a0)
+}
+
+// DeleteContext implements api.GroupMembershipsService
+func (m *GroupMembershipsService) DeleteContext(a0 context.Context, a1 *api.GroupMembership) (r0 error) {
+	if res, ok := m.record("Delete", 1, a0, a1); ok {
+		assign("Delete", res, &r0)
+		return
+	}
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteByLink implements api.GroupMembershipsService
+func (m *GroupMembershipsService) DeleteByLink(a0 string) error {
+	return m.DeleteByLinkContext(context.Background(), a0)
+}
+
+// DeleteByLinkContext implements api.GroupMembershipsService
+func (m *GroupMembershipsService) DeleteByLinkContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteByLink", 1, a0, a1); ok {
+		assign("DeleteByLink", res, &r0)
+		return
+	}
+	if m.DeleteByLinkFunc != nil {
+		return m.DeleteByLinkFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteById implements api.GroupMembershipsService
+func (m *GroupMembershipsService) DeleteById(a0 string) error {
+	return m.DeleteByIdContext(context.Background(), a0)
+}
+
+// DeleteByIdContext implements api.GroupMembershipsService
+func (m *GroupMembershipsService) DeleteByIdContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteById", 1, a0, a1); ok {
+		assign("DeleteById", res, &r0)
+		return
+	}
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(a0, a1)
+	}
+	return
+}
+
+// GroupsService is a fake of api.GroupsService. Fields ending with Func implement methods
+// of the same name and their Context variants.
+type GroupsService struct {
+	Mock
+
+	GetByIdFunc         func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Group, error)
+	GetByLinkFunc       func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Group, error)
+	ListByLinkFunc      func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Group, *api.ListParams, error)
+	ListByClusterFunc   func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Group, *api.ListParams, error)
+	ListByDeviceFunc    func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Group, *api.ListParams, error)
+	CreateByLinkFunc    func(a0 context.Context, a1 string, a2 *api.GroupRequestCreate) (*api.Group, error)
+	CreateByClusterFunc func(a0 context.Context, a1 string, a2 *api.GroupRequestCreate) (*api.Group, error)
+	UpdateByIdFunc      func(a0 context.Context, a1 string, a2 *api.GroupRequestUpdate) (*api.Group, error)
+	UpdateByLinkFunc    func(a0 context.Context, a1 string, a2 *api.GroupRequestUpdate) (*api.Group, error)
+	DeleteFunc          func(a0 context.Context, a1 *api.Group) error
+	DeleteByLinkFunc    func(a0 context.Context, a1 string) error
+	DeleteByIdFunc      func(a0 context.Context, a1 string) error
+}
+
+var _ api.GroupsService = (*GroupsService)(nil)
+
+// GetById implements api.GroupsService
+func (m *GroupsService) GetById(a0 string, a1 ...interface{}) (*api.Group, error) {
+	return m.GetByIdContext(context.Background(), a0, a1...)
+}
+
+// GetByIdContext implements api.GroupsService
+func (m *GroupsService) GetByIdContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Group, r1 error) {
+	if res, ok := m.record("GetById", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetById", res, &r0, &r1)
+		return
+	}
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// GetByLink implements api.GroupsService
+func (m *GroupsService) GetByLink(a0 string, a1 ...interface{}) (*api.Group, error) {
+	return m.GetByLinkContext(context.Background(), a0, a1...)
+}
+
+// GetByLinkContext implements api.GroupsService
+func (m *GroupsService) GetByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Group, r1 error) {
+	if res, ok := m.record("GetByLink", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetByLink", res, &r0, &r1)
+		return
+	}
+	if m.GetByLinkFunc != nil {
+		return m.GetByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// ListByLink implements api.GroupsService
+func (m *GroupsService) ListByLink(a0 string, a1 ...interface{}) ([]api.Group, *api.ListParams, error) {
+	return m.ListByLinkContext(context.Background(), a0, a1...)
+}
+
+// ListByLinkContext implements api.GroupsService
+func (m *GroupsService) ListByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Group, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByLink", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByLink", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByLinkFunc != nil {
+		return m.ListByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByLink implements api.GroupsService
+func (m *GroupsService) IterateByLink(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Group] {
+	first := true
+	return api.NewIterator(a0, "IterateByLink", func(ctx context.Context, link string, args ...interface{}) ([]api.Group, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByLinkContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// ListByCluster implements api.GroupsService
+func (m *GroupsService) ListByCluster(a0 string, a1 ...interface{}) ([]api.Group, *api.ListParams, error) {
+	return m.ListByClusterContext(context.Background(), a0, a1...)
+}
+
+// ListByClusterContext implements api.GroupsService
+func (m *GroupsService) ListByClusterContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Group, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByCluster", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByCluster", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByClusterFunc != nil {
+		return m.ListByClusterFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByCluster implements api.GroupsService
+func (m *GroupsService) IterateByCluster(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Group] {
+	first := true
+	return api.NewIterator(a0, "IterateByCluster", func(ctx context.Context, link string, args ...interface{}) ([]api.Group, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByClusterContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// ListByDevice implements api.GroupsService
+func (m *GroupsService) ListByDevice(a0 string, a1 ...interface{}) ([]api.Group, *api.ListParams, error) {
+	return m.ListByDeviceContext(context.Background(), a0, a1...)
+}
+
+// ListByDeviceContext implements api.GroupsService
+func (m *GroupsService) ListByDeviceContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Group, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByDevice", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByDevice", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByDeviceFunc != nil {
+		return m.ListByDeviceFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByDevice implements api.GroupsService
+func (m *GroupsService) IterateByDevice(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Group] {
+	first := true
+	return api.NewIterator(a0, "IterateByDevice", func(ctx context.Context, link string, args ...interface{}) ([]api.Group, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByDeviceContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// CreateByLink implements api.GroupsService
+func (m *GroupsService) CreateByLink(a0 string, a1 *api.GroupRequestCreate) (*api.Group, error) {
+	return m.CreateByLinkContext(context.Background(), a0, a1)
+}
+
+// CreateByLinkContext implements api.GroupsService
+func (m *GroupsService) CreateByLinkContext(a0 context.Context, a1 string, a2 *api.GroupRequestCreate) (r0 *api.Group, r1 error) {
+	if res, ok := m.record("CreateByLink", 2, a0, a1, a2); ok {
+		assign("CreateByLink", res, &r0, &r1)
+		return
+	}
+	if m.CreateByLinkFunc != nil {
+		return m.CreateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// CreateByCluster implements api.GroupsService
+func (m *GroupsService) CreateByCluster(a0 string, a1 *api.GroupRequestCreate) (*api.Group, error) {
+	return m.CreateByClusterContext(context.Background(), a0, a1)
+}
+
+// CreateByClusterContext implements api.GroupsService
+func (m *GroupsService) CreateByClusterContext(a0 context.Context, a1 string, a2 *api.GroupRequestCreate) (r0 *api.Group, r1 error) {
+	if res, ok := m.record("CreateByCluster", 2, a0, a1, a2); ok {
+		assign("CreateByCluster", res, &r0, &r1)
+		return
+	}
+	if m.CreateByClusterFunc != nil {
+		return m.CreateByClusterFunc(a0, a1, a2)
+	}
+	return
+}
+
+// UpdateById implements api.GroupsService
+func (m *GroupsService) UpdateById(a0 string, a1 *api.GroupRequestUpdate) (*api.Group, error) {
+	return m.UpdateByIdContext(context.Background(), a0, a1)
+}
+
+// UpdateByIdContext implements api.GroupsService
+func (m *GroupsService) UpdateByIdContext(a0 context.Context, a1 string, a2 *api.GroupRequestUpdate) (r0 *api.Group, r1 error) {
+	if res, ok := m.record("UpdateById", 2, a0, a1, a2); ok {
+		assign("UpdateById", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByIdFunc != nil {
+		return m.UpdateByIdFunc(a0, a1, a2)
+	}
+	return
+}
+
+// UpdateByLink implements api.GroupsService
+func (m *GroupsService) UpdateByLink(a0 string, a1 *api.GroupRequestUpdate) (*api.Group, error) {
+	return m.UpdateByLinkContext(context.Background(), a0, a1)
+}
+
+// UpdateByLinkContext implements api.GroupsService
+func (m *GroupsService) UpdateByLinkContext(a0 context.Context, a1 string, a2 *api.GroupRequestUpdate) (r0 *api.Group, r1 error) {
+	if res, ok := m.record("UpdateByLink", 2, a0, a1, a2); ok {
+		assign("UpdateByLink", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByLinkFunc != nil {
+		return m.UpdateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// Delete implements api.GroupsService
+func (m *GroupsService) Delete(a0 *api.Group) error {
+	return m.DeleteContext(context.Background(), a0)
+}
+
+// DeleteContext implements api.GroupsService
+func (m *GroupsService) DeleteContext(a0 context.Context, a1 *api.Group) (r0 error) {
+	if res, ok := m.record("Delete", 1, a0, a1); ok {
+		assign("Delete", res, &r0)
+		return
+	}
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteByLink implements api.GroupsService
+func (m *GroupsService) DeleteByLink(a0 string) error {
+	return m.DeleteByLinkContext(context.Background(), a0)
+}
+
+// DeleteByLinkContext implements api.GroupsService
+func (m *GroupsService) DeleteByLinkContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteByLink", 1, a0, a1); ok {
+		assign("DeleteByLink", res, &r0)
+		return
+	}
+	if m.DeleteByLinkFunc != nil {
+		return m.DeleteByLinkFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteById implements api.GroupsService
+func (m *GroupsService) DeleteById(a0 string) error {
+	return m.DeleteByIdContext(context.Background(), a0)
+}
+
+// DeleteByIdContext implements api.GroupsService
+func (m *GroupsService) DeleteByIdContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteById", 1, a0, a1); ok {
+		assign("DeleteById", res, &r0)
+		return
+	}
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(a0, a1)
+	}
+	return
+}
+
+// MembershipsService is a fake of api.MembershipsService. Fields ending with Func implement methods
+// of the same name and their Context variants.
+type MembershipsService struct {
+	Mock
+
+	GetByIdFunc           func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Membership, error)
+	GetByLinkFunc         func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Membership, error)
+	ListByLinkFunc        func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Membership, *api.ListParams, error)
+	ListByUserFunc        func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Membership, *api.ListParams, error)
+	ListByUsergroupFunc   func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Membership, *api.ListParams, error)
+	CreateByLinkFunc      func(a0 context.Context, a1 string, a2 *api.MembershipRequestCreate) (*api.Membership, error)
+	CreateByUserFunc      func(a0 context.Context, a1 string, a2 *api.MembershipRequestCreate) (*api.Membership, error)
+	CreateByUsergroupFunc func(a0 context.Context, a1 string, a2 *api.MembershipRequestCreate) (*api.Membership, error)
+	DeleteFunc            func(a0 context.Context, a1 *api.Membership) error
+	DeleteByLinkFunc      func(a0 context.Context, a1 string) error
+	DeleteByIdFunc        func(a0 context.Context, a1 string) error
+}
+
+var _ api.MembershipsService = (*MembershipsService)(nil)
+
+// GetById implements api.MembershipsService
+func (m *MembershipsService) GetById(a0 string, a1 ...interface{}) (*api.Membership, error) {
+	return m.GetByIdContext(context.Background(), a0, a1...)
+}
+
+// GetByIdContext implements api.MembershipsService
+func (m *MembershipsService) GetByIdContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Membership, r1 error) {
+	if res, ok := m.record("GetById", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetById", res, &r0, &r1)
+		return
+	}
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// GetByLink implements api.MembershipsService
+func (m *MembershipsService) GetByLink(a0 string, a1 ...interface{}) (*api.Membership, error) {
+	return m.GetByLinkContext(context.Background(), a0, a1...)
+}
+
+// GetByLinkContext implements api.MembershipsService
+func (m *MembershipsService) GetByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Membership, r1 error) {
+	if res, ok := m.record("GetByLink", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetByLink", res, &r0, &r1)
+		return
+	}
+	if m.GetByLinkFunc != nil {
+		return m.GetByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// ListByLink implements api.MembershipsService
+func (m *MembershipsService) ListByLink(a0 string, a1 ...interface{}) ([]api.Membership, *api.ListParams, error) {
+	return m.ListByLinkContext(context.Background(), a0, a1...)
+}
+
+// ListByLinkContext implements api.MembershipsService
+func (m *MembershipsService) ListByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Membership, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByLink", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByLink", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByLinkFunc != nil {
+		return m.ListByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByLink implements api.MembershipsService
+func (m *MembershipsService) IterateByLink(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Membership] {
+	first := true
+	return api.NewIterator(a0, "IterateByLink", func(ctx context.Context, link string, args ...interface{}) ([]api.Membership, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByLinkContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// ListByUser implements api.MembershipsService
+func (m *MembershipsService) ListByUser(a0 string, a1 ...interface{}) ([]api.Membership, *api.ListParams, error) {
+	return m.ListByUserContext(context.Background(), a0, a1...)
+}
+
+// ListByUserContext implements api.MembershipsService
+func (m *MembershipsService) ListByUserContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Membership, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByUser", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByUser", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByUserFunc != nil {
+		return m.ListByUserFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByUser implements api.MembershipsService
+func (m *MembershipsService) IterateByUser(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Membership] {
+	first := true
+	return api.NewIterator(a0, "IterateByUser", func(ctx context.Context, link string, args ...interface{}) ([]api.Membership, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByUserContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// ListByUsergroup implements api.MembershipsService
+func (m *MembershipsService) ListByUsergroup(a0 string, a1 ...interface{}) ([]api.Membership, *api.ListParams, error) {
+	return m.ListByUsergroupContext(context.Background(), a0, a1...)
+}
+
+// ListByUsergroupContext implements api.MembershipsService
+func (m *MembershipsService) ListByUsergroupContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Membership, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByUsergroup", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByUsergroup", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByUsergroupFunc != nil {
+		return m.ListByUsergroupFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByUsergroup implements api.MembershipsService
+func (m *MembershipsService) IterateByUsergroup(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Membership] {
+	first := true
+	return api.NewIterator(a0, "IterateByUsergroup", func(ctx context.Context, link string, args ...interface{}) ([]api.Membership, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByUsergroupContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// CreateByLink implements api.MembershipsService
+func (m *MembershipsService) CreateByLink(a0 string, a1 *api.MembershipRequestCreate) (*api.Membership, error) {
+	return m.CreateByLinkContext(context.Background(), a0, a1)
+}
+
+// CreateByLinkContext implements api.MembershipsService
+func (m *MembershipsService) CreateByLinkContext(a0 context.Context, a1 string, a2 *api.MembershipRequestCreate) (r0 *api.Membership, r1 error) {
+	if res, ok := m.record("CreateByLink", 2, a0, a1, a2); ok {
+		assign("CreateByLink", res, &r0, &r1)
+		return
+	}
+	if m.CreateByLinkFunc != nil {
+		return m.CreateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// CreateByUser implements api.MembershipsService
+func (m *MembershipsService) CreateByUser(a0 string, a1 *api.MembershipRequestCreate) (*api.Membership, error) {
+	return m.CreateByUserContext(context.Background(), a0, a1)
+}
+
+// CreateByUserContext implements api.MembershipsService
+func (m *MembershipsService) CreateByUserContext(a0 context.Context, a1 string, a2 *api.MembershipRequestCreate) (r0 *api.Membership, r1 error) {
+	if res, ok := m.record("CreateByUser", 2, a0, a1, a2); ok {
+		assign("CreateByUser", res, &r0, &r1)
+		return
+	}
+	if m.CreateByUserFunc != nil {
+		return m.CreateByUserFunc(a0, a1, a2)
+	}
+	return
+}
+
+// CreateByUsergroup implements api.MembershipsService
+func (m *MembershipsService) CreateByUsergroup(a0 string, a1 *api.MembershipRequestCreate) (*api.Membership, error) {
+	return m.CreateByUsergroupContext(context.Background(), a0, a1)
+}
+
+// CreateByUsergroupContext implements api.MembershipsService
+func (m *MembershipsService) CreateByUsergroupContext(a0 context.Context, a1 string, a2 *api.MembershipRequestCreate) (r0 *api.Membership, r1 error) {
+	if res, ok := m.record("CreateByUsergroup", 2, a0, a1, a2); ok {
+		assign("CreateByUsergroup", res, &r0, &r1)
+		return
+	}
+	if m.CreateByUsergroupFunc != nil {
+		return m.CreateByUsergroupFunc(a0, a1, a2)
+	}
+	return
+}
+
+// Delete implements api.MembershipsService
+func (m *MembershipsService) Delete(a0 *api.Membership) error {
+	return m.DeleteContext(context.Background(), a0)
+}
+
+// DeleteContext implements api.MembershipsService
+func (m *MembershipsService) DeleteContext(a0 context.Context, a1 *api.Membership) (r0 error) {
+	if res, ok := m.record("Delete", 1, a0, a1); ok {
+		assign("Delete", res, &r0)
+		return
+	}
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteByLink implements api.MembershipsService
+func (m *MembershipsService) DeleteByLink(a0 string) error {
+	return m.DeleteByLinkContext(context.Background(), a0)
+}
+
+// DeleteByLinkContext implements api.MembershipsService
+func (m *MembershipsService) DeleteByLinkContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteByLink", 1, a0, a1); ok {
+		assign("DeleteByLink", res, &r0)
+		return
+	}
+	if m.DeleteByLinkFunc != nil {
+		return m.DeleteByLinkFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteById implements api.MembershipsService
+func (m *MembershipsService) DeleteById(a0 string) error {
+	return m.DeleteByIdContext(context.Background(), a0)
+}
+
+// DeleteByIdContext implements api.MembershipsService
+func (m *MembershipsService) DeleteByIdContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteById", 1, a0, a1); ok {
+		assign("DeleteById", res, &r0)
+		return
+	}
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(a0, a1)
+	}
+	return
+}
+
+// ProductsService is a fake of api.ProductsService. Fields ending with Func implement methods
+// of the same name and their Context variants.
+type ProductsService struct {
+	Mock
+
+	GetByIdFunc      func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Product, error)
+	GetByLinkFunc    func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Product, error)
+	ListFunc         func(a0 context.Context, a1 ...interface{}) ([]api.Product, *api.ListParams, error)
+	ListByLinkFunc   func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Product, *api.ListParams, error)
+	CreateFunc       func(a0 context.Context, a1 *api.ProductRequestCreate) (*api.Product, error)
+	UpdateByIdFunc   func(a0 context.Context, a1 string, a2 *api.ProductRequestUpdate) (*api.Product, error)
+	UpdateByLinkFunc func(a0 context.Context, a1 string, a2 *api.ProductRequestUpdate) (*api.Product, error)
+	DeleteFunc       func(a0 context.Context, a1 *api.Product) error
+	DeleteByLinkFunc func(a0 context.Context, a1 string) error
+	DeleteByIdFunc   func(a0 context.Context, a1 string) error
+}
+
+var _ api.ProductsService = (*ProductsService)(nil)
+
+// GetById implements api.ProductsService
+func (m *ProductsService) GetById(a0 string, a1 ...interface{}) (*api.Product, error) {
+	return m.GetByIdContext(context.Background(), a0, a1...)
+}
+
+// GetByIdContext implements api.ProductsService
+func (m *ProductsService) GetByIdContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Product, r1 error) {
+	if res, ok := m.record("GetById", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetById", res, &r0, &r1)
+		return
+	}
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// GetByLink implements api.ProductsService
+func (m *ProductsService) GetByLink(a0 string, a1 ...interface{}) (*api.Product, error) {
+	return m.GetByLinkContext(context.Background(), a0, a1...)
+}
+
+// GetByLinkContext implements api.ProductsService
+func (m *ProductsService) GetByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Product, r1 error) {
+	if res, ok := m.record("GetByLink", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetByLink", res, &r0, &r1)
+		return
+	}
+	if m.GetByLinkFunc != nil {
+		return m.GetByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// List implements api.ProductsService
+func (m *ProductsService) List(a0 ...interface{}) ([]api.Product, *api.ListParams, error) {
+	return m.ListContext(context.Background(), a0...)
+}
+
+// ListContext implements api.ProductsService
+func (m *ProductsService) ListContext(a0 context.Context, a1 ...interface{}) (r0 []api.Product, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("List", 3, a0, a1...); ok {
+		assign("List", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListFunc != nil {
+		return m.ListFunc(a0, a1...)
+	}
+	return
+}
+
+// Iterate implements api.ProductsService
+func (m *ProductsService) Iterate(a0 context.Context, a1 ...interface{}) *api.Iterator[api.Product] {
+	first := true
+	return api.NewIterator(a0, "Iterate", func(ctx context.Context, link string, args ...interface{}) ([]api.Product, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListContext(ctx, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a1...)
+}
+
+// ListByLink implements api.ProductsService
+func (m *ProductsService) ListByLink(a0 string, a1 ...interface{}) ([]api.Product, *api.ListParams, error) {
+	return m.ListByLinkContext(context.Background(), a0, a1...)
+}
+
+// ListByLinkContext implements api.ProductsService
+func (m *ProductsService) ListByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Product, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByLink", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByLink", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByLinkFunc != nil {
+		return m.ListByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByLink implements api.ProductsService
+func (m *ProductsService) IterateByLink(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Product] {
+	first := true
+	return api.NewIterator(a0, "IterateByLink", func(ctx context.Context, link string, args ...interface{}) ([]api.Product, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByLinkContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// Create implements api.ProductsService
+func (m *ProductsService) Create(a0 *api.ProductRequestCreate) (*api.Product, error) {
+	return m.CreateContext(context.Background(), a0)
+}
+
+// CreateContext implements api.ProductsService
+func (m *ProductsService) CreateContext(a0 context.Context, a1 *api.ProductRequestCreate) (r0 *api.Product, r1 error) {
+	if res, ok := m.record("Create", 2, a0, a1); ok {
+		assign("Create", res, &r0, &r1)
+		return
+	}
+	if m.CreateFunc != nil {
+		return m.CreateFunc(a0, a1)
+	}
+	return
+}
+
+// UpdateById implements api.ProductsService
+func (m *ProductsService) UpdateById(a0 string, a1 *api.ProductRequestUpdate) (*api.Product, error) {
+	return m.UpdateByIdContext(context.Background(), a0, a1)
+}
+
+// UpdateByIdContext implements api.ProductsService
+func (m *ProductsService) UpdateByIdContext(a0 context.Context, a1 string, a2 *api.ProductRequestUpdate) (r0 *api.Product, r1 error) {
+	if res, ok := m.record("UpdateById", 2, a0, a1, a2); ok {
+		assign("UpdateById", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByIdFunc != nil {
+		return m.UpdateByIdFunc(a0, a1, a2)
+	}
+	return
+}
+
+// UpdateByLink implements api.ProductsService
+func (m *ProductsService) UpdateByLink(a0 string, a1 *api.ProductRequestUpdate) (*api.Product, error) {
+	return m.UpdateByLinkContext(context.Background(), a0, a1)
+}
+
+// UpdateByLinkContext implements api.ProductsService
+func (m *ProductsService) UpdateByLinkContext(a0 context.Context, a1 string, a2 *api.ProductRequestUpdate) (r0 *api.Product, r1 error) {
+	if res, ok := m.record("UpdateByLink", 2, a0, a1, a2); ok {
+		assign("UpdateByLink", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByLinkFunc != nil {
+		return m.UpdateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// Delete implements api.ProductsService
+func (m *ProductsService) Delete(a0 *api.Product) error {
+	return m.DeleteContext(context.Background(), a0)
+}
+
+// DeleteContext implements api.ProductsService
+func (m *ProductsService) DeleteContext(a0 context.Context, a1 *api.Product) (r0 error) {
+	if res, ok := m.record("Delete", 1, a0, a1); ok {
+		assign("Delete", res, &r0)
+		return
+	}
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteByLink implements api.ProductsService
+func (m *ProductsService) DeleteByLink(a0 string) error {
+	return m.DeleteByLinkContext(context.Background(), a0)
+}
+
+// DeleteByLinkContext implements api.ProductsService
+func (m *ProductsService) DeleteByLinkContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteByLink", 1, a0, a1); ok {
+		assign("DeleteByLink", res, &r0)
+		return
+	}
+	if m.DeleteByLinkFunc != nil {
+		return m.DeleteByLinkFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteById implements api.ProductsService
+func (m *ProductsService) DeleteById(a0 string) error {
+	return m.DeleteByIdContext(context.Background(), a0)
+}
+
+// DeleteByIdContext implements api.ProductsService
+func (m *ProductsService) DeleteByIdContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteById", 1, a0, a1); ok {
+		assign("DeleteById", res, &r0)
+		return
+	}
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(a0, a1)
+	}
+	return
+}
+
+// ResourcesService is a fake of api.ResourcesService. Fields ending with Func implement methods
+// of the same name and their Context variants.
+type ResourcesService struct {
+	Mock
+
+	GetDataByDeviceIDFunc         func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.DataPoint, *api.ListParams, error)
+	GetEventsByDeviceIDFunc       func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.EventPoint, *api.ListParams, error)
+	GetCommandsByDeviceIDFunc     func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.CommandPoint, *api.ListParams, error)
+	WriteDataForDeviceIDFunc      func(a0 context.Context, a1 string, a2 []api.DataPoint) ([]api.DataPoint, error)
+	WriteEventsForDeviceIDFunc    func(a0 context.Context, a1 string, a2 []api.EventPoint) ([]api.EventPoint, error)
+	WriteCommandsForDeviceIDFunc  func(a0 context.Context, a1 string, a2 []api.CommandPoint) ([]api.CommandPoint, error)
+	GetDataByClusterIDFunc        func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.DataPoint, *api.ListParams, error)
+	GetEventsByClusterIDFunc      func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.EventPoint, *api.ListParams, error)
+	GetCommandsByClusterIDFunc    func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.CommandPoint, *api.ListParams, error)
+	WriteDataForClusterIDFunc     func(a0 context.Context, a1 string, a2 []api.DataPoint) ([]api.DataPoint, error)
+	WriteEventsForClusterIDFunc   func(a0 context.Context, a1 string, a2 []api.EventPoint) ([]api.EventPoint, error)
+	WriteCommandsForClusterIDFunc func(a0 context.Context, a1 string, a2 []api.CommandPoint) ([]api.CommandPoint, error)
+	GetDataByLinkFunc             func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.DataPoint, *api.ListParams, error)
+	GetEventsByLinkFunc           func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.EventPoint, *api.ListParams, error)
+	GetCommandsByLinkFunc         func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.CommandPoint, *api.ListParams, error)
+	WriteDataForLinkFunc          func(a0 context.Context, a1 string, a2 []api.DataPoint) ([]api.DataPoint, error)
+	WriteEventsForLinkFunc        func(a0 context.Context, a1 string, a2 []api.EventPoint) ([]api.EventPoint, error)
+	WriteCommandsForLinkFunc      func(a0 context.Context, a1 string, a2 []api.CommandPoint) ([]api.CommandPoint, error)
+	GetDataByDeviceKeyFunc        func(a0 context.Context, a1 string, a2 string, a3 ...interface{}) ([]api.DataPoint, *api.ListParams, error)
+	GetEventsByDeviceKeyFunc      func(a0 context.Context, a1 string, a2 string, a3 ...interface{}) ([]api.EventPoint, *api.ListParams, error)
+	GetCommandsByDeviceKeyFunc    func(a0 context.Context, a1 string, a2 string, a3 ...interface{}) ([]api.CommandPoint, *api.ListParams, error)
+	GetDataByClusterKeyFunc       func(a0 context.Context, a1 string, a2 string, a3 ...interface{}) ([]api.DataPoint, *api.ListParams, error)
+	GetEventsByClusterKeyFunc     func(a0 context.Context, a1 string, a2 string, a3 ...interface{}) ([]api.EventPoint, *api.ListParams, error)
+	GetCommandsByClusterKeyFunc   func(a0 context.Context, a1 string, a2 string, a3 ...interface{}) ([]api.CommandPoint, *api.ListParams, error)
+	GetDataByDeviceKeysFunc       func(a0 context.Context, a1 string, a2 []string, a3 ...interface{}) (map[string][]api.DataPoint, error)
+	GetEventsByDeviceKeysFunc     func(a0 context.Context, a1 string, a2 []string, a3 ...interface{}) (map[string][]api.EventPoint, error)
+	GetCommandsByDeviceKeysFunc   func(a0 context.Context, a1 string, a2 []string, a3 ...interface{}) (map[string][]api.CommandPoint, error)
+	GetDataByClusterKeysFunc      func(a0 context.Context, a1 string, a2 []string, a3 ...interface{}) (map[string][]api.DataPoint, error)
+	GetEventsByClusterKeysFunc    func(a0 context.Context, a1 string, a2 []string, a3 ...interface{}) (map[string][]api.EventPoint, error)
+	GetCommandsByClusterKeysFunc  func(a0 context.Context, a1 string, a2 []string, a3 ...interface{}) (map[string][]api.CommandPoint, error)
+	AggregateDataByDeviceIDFunc   func(a0 context.Context, a1 string, a2 *api.Aggregation, a3 ...interface{}) (map[string][]api.Bucket, error)
+	AggregateDataByClusterIDFunc  func(a0 context.Context, a1 string, a2 *api.Aggregation, a3 ...interface{}) (map[string][]api.Bucket, error)
+	AggregateDataByLinkFunc       func(a0 context.Context, a1 string, a2 *api.Aggregation, a3 ...interface{}) (map[string][]api.Bucket, error)
+	SubscribeFunc                 func(a0 context.Context, a1 string, a2 ...api.ResourceKind) (*api.Subscription, error)
+}
+
+var _ api.ResourcesService = (*ResourcesService)(nil)
+
+// GetDataByDeviceID implements api.ResourcesService
+func (m *ResourcesService) GetDataByDeviceID(a0 string, a1 ...interface{}) ([]api.DataPoint, *api.ListParams, error) {
+	return m.GetDataByDeviceIDContext(context.Background(), a0, a1...)
+}
+
+// GetDataByDeviceIDContext implements api.ResourcesService
+func (m *ResourcesService) GetDataByDeviceIDContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.DataPoint, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("GetDataByDeviceID", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetDataByDeviceID", res, &r0, &r1, &r2)
+		return
+	}
+	if m.GetDataByDeviceIDFunc != nil {
+		return m.GetDataByDeviceIDFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// GetEventsByDeviceID implements api.ResourcesService
+func (m *ResourcesService) GetEventsByDeviceID(a0 string, a1 ...interface{}) ([]api.EventPoint, *api.ListParams, error) {
+	return m.GetEventsByDeviceIDContext(context.Background(), a0, a1...)
+}
+
+// GetEventsByDeviceIDContext implements api.ResourcesService
+func (m *ResourcesService) GetEventsByDeviceIDContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.EventPoint, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("GetEventsByDeviceID", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetEventsByDeviceID", res, &r0, &r1, &r2)
+		return
+	}
+	if m.GetEventsByDeviceIDFunc != nil {
+		return m.GetEventsByDeviceIDFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// GetCommandsByDeviceID implements api.ResourcesService
+func (m *ResourcesService) GetCommandsByDeviceID(a0 string, a1 ...interface{}) ([]api.CommandPoint, *api.ListParams, error) {
+	return m.GetCommandsByDeviceIDContext(context.Background(), a0, a1...)
+}
+
+// GetCommandsByDeviceIDContext implements api.ResourcesService
+func (m *ResourcesService) GetCommandsByDeviceIDContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.CommandPoint, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("GetCommandsByDeviceID", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetCommandsByDeviceID", res, &r0, &r1, &r2)
+		return
+	}
+	if m.GetCommandsByDeviceIDFunc != nil {
+		return m.GetCommandsByDeviceIDFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// WriteDataForDeviceID implements api.ResourcesService
+func (m *ResourcesService) WriteDataForDeviceID(a0 string, a1 []api.DataPoint) ([]api.DataPoint, error) {
+	return m.WriteDataForDeviceIDContext(context.Background(), a0, a1)
+}
+
+// WriteDataForDeviceIDContext implements api.ResourcesService
+func (m *ResourcesService) WriteDataForDeviceIDContext(a0 context.Context, a1 string, a2 []api.DataPoint) (r0 []api.DataPoint, r1 error) {
+	if res, ok := m.record("WriteDataForDeviceID", 2, a0, a1, a2); ok {
+		assign("WriteDataForDeviceID", res, &r0, &r1)
+		return
+	}
+	if m.WriteDataForDeviceIDFunc != nil {
+		return m.WriteDataForDeviceIDFunc(a0, a1, a2)
+	}
+	return
+}
+
+// WriteEventsForDeviceID implements api.ResourcesService
+func (m *ResourcesService) WriteEventsForDeviceID(a0 string, a1 []api.EventPoint) ([]api.EventPoint, error) {
+	return m.WriteEventsForDeviceIDContext(context.Background(), a0, a1)
+}
+
+// WriteEventsForDeviceIDContext implements api.ResourcesService
+func (m *ResourcesService) WriteEventsForDeviceIDContext(a0 context.Context, a1 string, a2 []api.EventPoint) (r0 []api.EventPoint, r1 error) {
+	if res, ok := m.record("WriteEventsForDeviceID", 2, a0, a1, a2); ok {
+		assign("WriteEventsForDeviceID", res, &r0, &r1)
+		return
+	}
+	if m.WriteEventsForDeviceIDFunc != nil {
+		return m.WriteEventsForDeviceIDFunc(a0, a1, a2)
+	}
+	return
+}
+
+// WriteCommandsForDeviceID implements api.ResourcesService
+func (m *ResourcesService) WriteCommandsForDeviceID(a0 string, a1 []api.CommandPoint) ([]api.CommandPoint, error) {
+	return m.WriteCommandsForDeviceIDContext(context.Background(), a0, a1)
+}
+
+// WriteCommandsForDeviceIDContext implements api.ResourcesService
+func (m *ResourcesService) WriteCommandsForDeviceIDContext(a0 context.Context, a1 string, a2 []api.CommandPoint) (r0 []api.CommandPoint, r1 error) {
+	if res, ok := m.record("WriteCommandsForDeviceID", 2, a0, a1, a2); ok {
+		assign("WriteCommandsForDeviceID", res, &r0, &r1)
+		return
+	}
+	if m.WriteCommandsForDeviceIDFunc != nil {
+		return m.WriteCommandsForDeviceIDFunc(a0, a1, a2)
+	}
+	return
+}
+
+// GetDataByClusterID implements api.ResourcesService
+func (m *ResourcesService) GetDataByClusterID(a0 string, a1 ...interface{}) ([]api.DataPoint, *api.ListParams, error) {
+	return m.GetDataByClusterIDContext(context.Background(), a0, a1...)
+}
+
+// GetDataByClusterIDContext implements api.ResourcesService
+func (m *ResourcesService) GetDataByClusterIDContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.DataPoint, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("GetDataByClusterID", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetDataByClusterID", res, &r0, &r1, &r2)
+		return
+	}
+	if m.GetDataByClusterIDFunc != nil {
+		return m.GetDataByClusterIDFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// GetEventsByClusterID implements api.ResourcesService
+func (m *ResourcesService) GetEventsByClusterID(a0 string, a1 ...interface{}) ([]api.EventPoint, *api.ListParams, error) {
+	return m.GetEventsByClusterIDContext(context.Background(), a0, a1...)
+}
+
+// GetEventsByClusterIDContext implements api.ResourcesService
+func (m *ResourcesService) GetEventsByClusterIDContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.EventPoint, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("GetEventsByClusterID", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetEventsByClusterID", res, &r0, &r1, &r2)
+		return
+	}
+	if m.GetEventsByClusterIDFunc != nil {
+		return m.GetEventsByClusterIDFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// GetCommandsByClusterID implements api.ResourcesService
+func (m *ResourcesService) GetCommandsByClusterID(a0 string, a1 ...interface{}) ([]api.CommandPoint, *api.ListParams, error) {
+	return m.GetCommandsByClusterIDContext(context.Background(), a0, a1...)
+}
+
+// GetCommandsByClusterIDContext implements api.ResourcesService
+func (m *ResourcesService) GetCommandsByClusterIDContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.CommandPoint, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("GetCommandsByClusterID", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetCommandsByClusterID", res, &r0, &r1, &r2)
+		return
+	}
+	if m.GetCommandsByClusterIDFunc != nil {
+		return m.GetCommandsByClusterIDFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// WriteDataForClusterID implements api.ResourcesService
+func (m *ResourcesService) WriteDataForClusterID(a0 string, a1 []api.DataPoint) ([]api.DataPoint, error) {
+	return m.WriteDataForClusterIDContext(context.Background(), a0, a1)
+}
+
+// WriteDataForClusterIDContext implements api.ResourcesService
+func (m *ResourcesService) WriteDataForClusterIDContext(a0 context.Context, a1 string, a2 []api.DataPoint) (r0 []api.DataPoint, r1 error) {
+	if res, ok := m.record("WriteDataForClusterID", 2, a0, a1, a2); ok {
+		assign("WriteDataForClusterID", res, &r0, &r1)
+		return
+	}
+	if m.WriteDataForClusterIDFunc != nil {
+		return m.WriteDataForClusterIDFunc(a0, a1, a2)
+	}
+	return
+}
+
+// WriteEventsForClusterID implements api.ResourcesService
+func (m *ResourcesService) WriteEventsForClusterID(a0 string, a1 []api.EventPoint) ([]api.EventPoint, error) {
+	return m.WriteEventsForClusterIDContext(context.Background(), a0, a1)
+}
+
+// WriteEventsForClusterIDContext implements api.ResourcesService
+func (m *ResourcesService) WriteEventsForClusterIDContext(a0 context.Context, a1 string, a2 []api.EventPoint) (r0 []api.EventPoint, r1 error) {
+	if res, ok := m.record("WriteEventsForClusterID", 2, a0, a1, a2); ok {
+		assign("WriteEventsForClusterID", res, &r0, &r1)
+		return
+	}
+	if m.WriteEventsForClusterIDFunc != nil {
+		return m.WriteEventsForClusterIDFunc(a0, a1, a2)
+	}
+	return
+}
+
+// WriteCommandsForClusterID implements api.ResourcesService
+func (m *ResourcesService) WriteCommandsForClusterID(a0 string, a1 []api.CommandPoint) ([]api.CommandPoint, error) {
+	return m.WriteCommandsForClusterIDContext(context.Background(), a0, a1)
+}
+
+// WriteCommandsForClusterIDContext implements api.ResourcesService
+func (m *ResourcesService) WriteCommandsForClusterIDContext(a0 context.Context, a1 string, a2 []api.CommandPoint) (r0 []api.CommandPoint, r1 error) {
+	if res, ok := m.record("WriteCommandsForClusterID", 2, a0, a1, a2); ok {
+		assign("WriteCommandsForClusterID", res, &r0, &r1)
+		return
+	}
+	if m.WriteCommandsForClusterIDFunc != nil {
+		return m.WriteCommandsForClusterIDFunc(a0, a1, a2)
+	}
+	return
+}
+
+// GetDataByLink implements api.ResourcesService
+func (m *ResourcesService) GetDataByLink(a0 string, a1 ...interface{}) ([]api.DataPoint, *api.ListParams, error) {
+	return m.GetDataByLinkContext(context.Background(), a0, a1...)
+}
+
+// GetDataByLinkContext implements api.ResourcesService
+func (m *ResourcesService) GetDataByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.DataPoint, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("GetDataByLink", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetDataByLink", res, &r0, &r1, &r2)
+		return
+	}
+	if m.GetDataByLinkFunc != nil {
+		return m.GetDataByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// GetEventsByLink implements api.ResourcesService
+func (m *ResourcesService) GetEventsByLink(a0 string, a1 ...interface{}) ([]api.EventPoint, *api.ListParams, error) {
+	return m.GetEventsByLinkContext(context.Background(), a0, a1...)
+}
+
+// GetEventsByLinkContext implements api.ResourcesService
+func (m *ResourcesService) GetEventsByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.EventPoint, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("GetEventsByLink", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetEventsByLink", res, &r0, &r1, &r2)
+		return
+	}
+	if m.GetEventsByLinkFunc != nil {
+		return m.GetEventsByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// GetCommandsByLink implements api.ResourcesService
+func (m *ResourcesService) GetCommandsByLink(a0 string, a1 ...interface{}) ([]api.CommandPoint, *api.ListParams, error) {
+	return m.GetCommandsByLinkContext(context.Background(), a0, a1...)
+}
+
+// GetCommandsByLinkContext implements api.ResourcesService
+func (m *ResourcesService) GetCommandsByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.CommandPoint, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("GetCommandsByLink", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetCommandsByLink", res, &r0, &r1, &r2)
+		return
+	}
+	if m.GetCommandsByLinkFunc != nil {
+		return m.GetCommandsByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// WriteDataForLink implements api.ResourcesService
+func (m *ResourcesService) WriteDataForLink(a0 string, a1 []api.DataPoint) ([]api.DataPoint, error) {
+	return m.WriteDataForLinkContext(context.Background(), a0, a1)
+}
+
+// WriteDataForLinkContext implements api.ResourcesService
+func (m *ResourcesService) WriteDataForLinkContext(a0 context.Context, a1 string, a2 []api.DataPoint) (r0 []api.DataPoint, r1 error) {
+	if res, ok := m.record("WriteDataForLink", 2, a0, a1, a2); ok {
+		assign("WriteDataForLink", res, &r0, &r1)
+		return
+	}
+	if m.WriteDataForLinkFunc != nil {
+		return m.WriteDataForLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// WriteEventsForLink implements api.ResourcesService
+func (m *ResourcesService) WriteEventsForLink(a0 string, a1 []api.EventPoint) ([]api.EventPoint, error) {
+	return m.WriteEventsForLinkContext(context.Background(), a0, a1)
+}
+
+// WriteEventsForLinkContext implements api.ResourcesService
+func (m *ResourcesService) WriteEventsForLinkContext(a0 context.Context, a1 string, a2 []api.EventPoint) (r0 []api.EventPoint, r1 error) {
+	if res, ok := m.record("WriteEventsForLink", 2, a0, a1, a2); ok {
+		assign("WriteEventsForLink", res, &r0, &r1)
+		return
+	}
+	if m.WriteEventsForLinkFunc != nil {
+		return m.WriteEventsForLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// WriteCommandsForLink implements api.ResourcesService
+func (m *ResourcesService) WriteCommandsForLink(a0 string, a1 []api.CommandPoint) ([]api.CommandPoint, error) {
+	return m.WriteCommandsForLinkContext(context.Background(), a0, a1)
+}
+
+// WriteCommandsForLinkContext implements api.ResourcesService
+func (m *ResourcesService) WriteCommandsForLinkContext(a0 context.Context, a1 string, a2 []api.CommandPoint) (r0 []api.CommandPoint, r1 error) {
+	if res, ok := m.record("WriteCommandsForLink", 2, a0, a1, a2); ok {
+		assign("WriteCommandsForLink", res, &r0, &r1)
+		return
+	}
+	if m.WriteCommandsForLinkFunc != nil {
+		return m.WriteCommandsForLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// StreamDataByDeviceID implements api.ResourcesService
+func (m *ResourcesService) StreamDataByDeviceID(a0 context.Context, a1 string, a2 ...interface{}) *api.Stream[api.DataPoint] {
+	first := true
+	return api.NewStreamFromPages(a0, "StreamDataByDeviceID", func(ctx context.Context, link string, args ...interface{}) ([]api.DataPoint, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.GetDataByDeviceIDContext(ctx, a1, args...)
+		}
+		return m.GetDataByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// StreamDataByClusterID implements api.ResourcesService
+func (m *ResourcesService) StreamDataByClusterID(a0 context.Context, a1 string, a2 ...interface{}) *api.Stream[api.DataPoint] {
+	first := true
+	return api.NewStreamFromPages(a0, "StreamDataByClusterID", func(ctx context.Context, link string, args ...interface{}) ([]api.DataPoint, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.GetDataByClusterIDContext(ctx, a1, args...)
+		}
+		return m.GetDataByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// StreamDataByLink implements api.ResourcesService
+func (m *ResourcesService) StreamDataByLink(a0 context.Context, a1 string, a2 ...interface{}) *api.Stream[api.DataPoint] {
+	first := true
+	return api.NewStreamFromPages(a0, "StreamDataByLink", func(ctx context.Context, link string, args ...interface{}) ([]api.DataPoint, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.GetDataByLinkContext(ctx, a1, args...)
+		}
+		return m.GetDataByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// StreamEventsByDeviceID implements api.ResourcesService
+func (m *ResourcesService) StreamEventsByDeviceID(a0 context.Context, a1 string, a2 ...interface{}) *api.Stream[api.EventPoint] {
+	first := true
+	return api.NewStreamFromPages(a0, "StreamEventsByDeviceID", func(ctx context.Context, link string, args ...interface{}) ([]api.EventPoint, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.GetEventsByDeviceIDContext(ctx, a1, args...)
+		}
+		return m.GetEventsByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// StreamEventsByClusterID implements api.ResourcesService
+func (m *ResourcesService) StreamEventsByClusterID(a0 context.Context, a1 string, a2 ...interface{}) *api.Stream[api.EventPoint] {
+	first := true
+	return api.NewStreamFromPages(a0, "StreamEventsByClusterID", func(ctx context.Context, link string, args ...interface{}) ([]api.EventPoint, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.GetEventsByClusterIDContext(ctx, a1, args...)
+		}
+		return m.GetEventsByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// StreamEventsByLink implements api.ResourcesService
+func (m *ResourcesService) StreamEventsByLink(a0 context.Context, a1 string, a2 ...interface{}) *api.Stream[api.EventPoint] {
+	first := true
+	return api.NewStreamFromPages(a0, "StreamEventsByLink", func(ctx context.Context, link string, args ...interface{}) ([]api.EventPoint, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.GetEventsByLinkContext(ctx, a1, args...)
+		}
+		return m.GetEventsByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// StreamCommandsByDeviceID implements api.ResourcesService
+func (m *ResourcesService) StreamCommandsByDeviceID(a0 context.Context, a1 string, a2 ...interface{}) *api.Stream[api.CommandPoint] {
+	first := true
+	return api.NewStreamFromPages(a0, "StreamCommandsByDeviceID", func(ctx context.Context, link string, args ...interface{}) ([]api.CommandPoint, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.GetCommandsByDeviceIDContext(ctx, a1, args...)
+		}
+		return m.GetCommandsByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// StreamCommandsByClusterID implements api.ResourcesService
+func (m *ResourcesService) StreamCommandsByClusterID(a0 context.Context, a1 string, a2 ...interface{}) *api.Stream[api.CommandPoint] {
+	first := true
+	return api.NewStreamFromPages(a0, "StreamCommandsByClusterID", func(ctx context.Context, link string, args ...interface{}) ([]api.CommandPoint, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.GetCommandsByClusterIDContext(ctx, a1, args...)
+		}
+		return m.GetCommandsByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// StreamCommandsByLink implements api.ResourcesService
+func (m *ResourcesService) StreamCommandsByLink(a0 context.Context, a1 string, a2 ...interface{}) *api.Stream[api.CommandPoint] {
+	first := true
+	return api.NewStreamFromPages(a0, "StreamCommandsByLink", func(ctx context.Context, link string, args ...interface{}) ([]api.CommandPoint, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.GetCommandsByLinkContext(ctx, a1, args...)
+		}
+		return m.GetCommandsByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// GetDataByDeviceKey implements api.ResourcesService
+func (m *ResourcesService) GetDataByDeviceKey(a0 string, a1 string, a2 ...interface{}) ([]api.DataPoint, *api.ListParams, error) {
+	return m.GetDataByDeviceKeyContext(context.Background(), a0, a1, a2...)
+}
+
+// GetDataByDeviceKeyContext implements api.ResourcesService
+func (m *ResourcesService) GetDataByDeviceKeyContext(a0 context.Context, a1 string, a2 string, a3 ...interface{}) (r0 []api.DataPoint, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("GetDataByDeviceKey", 3, a0, append([]interface{}{a1, a2}, a3...)...); ok {
+		assign("GetDataByDeviceKey", res, &r0, &r1, &r2)
+		return
+	}
+	if m.GetDataByDeviceKeyFunc != nil {
+		return m.GetDataByDeviceKeyFunc(a0, a1, a2, a3...)
+	}
+	return
+}
+
+// GetEventsByDeviceKey implements api.ResourcesService
+func (m *ResourcesService) GetEventsByDeviceKey(a0 string, a1 string, a2 ...interface{}) ([]api.EventPoint, *api.ListParams, error) {
+	return m.GetEventsByDeviceKeyContext(context.Background(), a0, a1, a2...)
+}
+
+// GetEventsByDeviceKeyContext implements api.ResourcesService
+func (m *ResourcesService) GetEventsByDeviceKeyContext(a0 context.Context, a1 string, a2 string, a3 ...interface{}) (r0 []api.EventPoint, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("GetEventsByDeviceKey", 3, a0, append([]interface{}{a1, a2}, a3...)...); ok {
+		assign("GetEventsByDeviceKey", res, &r0, &r1, &r2)
+		return
+	}
+	if m.GetEventsByDeviceKeyFunc != nil {
+		return m.GetEventsByDeviceKeyFunc(a0, a1, a2, a3...)
+	}
+	return
+}
+
+// GetCommandsByDeviceKey implements api.ResourcesService
+func (m *ResourcesService) GetCommandsByDeviceKey(a0 string, a1 string, a2 ...interface{}) ([]api.CommandPoint, *api.ListParams, error) {
+	return m.GetCommandsByDeviceKeyContext(context.Background(), a0, a1, a2...)
+}
+
+// GetCommandsByDeviceKeyContext implements api.ResourcesService
+func (m *ResourcesService) GetCommandsByDeviceKeyContext(a0 context.Context, a1 string, a2 string, a3 ...interface{}) (r0 []api.CommandPoint, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("GetCommandsByDeviceKey", 3, a0, append([]interface{}{a1, a2}, a3...)...); ok {
+		assign("GetCommandsByDeviceKey", res, &r0, &r1, &r2)
+		return
+	}
+	if m.GetCommandsByDeviceKeyFunc != nil {
+		return m.GetCommandsByDeviceKeyFunc(a0, a1, a2, a3...)
+	}
+	return
+}
+
+// GetDataByClusterKey implements api.ResourcesService
+func (m *ResourcesService) GetDataByClusterKey(a0 string, a1 string, a2 ...interface{}) ([]api.DataPoint, *api.ListParams, error) {
+	return m.GetDataByClusterKeyContext(context.Background(), a0, a1, a2...)
+}
+
+// GetDataByClusterKeyContext implements api.ResourcesService
+func (m *ResourcesService) GetDataByClusterKeyContext(a0 context.Context, a1 string, a2 string, a3 ...interface{}) (r0 []api.DataPoint, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("GetDataByClusterKey", 3, a0, append([]interface{}{a1, a2}, a3...)...); ok {
+		assign("GetDataByClusterKey", res, &r0, &r1, &r2)
+		return
+	}
+	if m.GetDataByClusterKeyFunc != nil {
+		return m.GetDataByClusterKeyFunc(a0, a1, a2, a3...)
+	}
+	return
+}
+
+// GetEventsByClusterKey implements api.ResourcesService
+func (m *ResourcesService) GetEventsByClusterKey(a0 string, a1 string, a2 ...interface{}) ([]api.EventPoint, *api.ListParams, error) {
+	return m.GetEventsByClusterKeyContext(context.Background(), a0, a1, a2...)
+}
+
+// GetEventsByClusterKeyContext implements api.ResourcesService
+func (m *ResourcesService) GetEventsByClusterKeyContext(a0 context.Context, a1 string, a2 string, a3 ...interface{}) (r0 []api.EventPoint, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("GetEventsByClusterKey", 3, a0, append([]interface{}{a1, a2}, a3...)...); ok {
+		assign("GetEventsByClusterKey", res, &r0, &r1, &r2)
+		return
+	}
+	if m.GetEventsByClusterKeyFunc != nil {
+		return m.GetEventsByClusterKeyFunc(a0, a1, a2, a3...)
+	}
+	return
+}
+
+// GetCommandsByClusterKey implements api.ResourcesService
+func (m *ResourcesService) GetCommandsByClusterKey(a0 string, a1 string, a2 ...interface{}) ([]api.CommandPoint, *api.ListParams, error) {
+	return m.GetCommandsByClusterKeyContext(context.Background(), a0, a1, a2...)
+}
+
+// GetCommandsByClusterKeyContext implements api.ResourcesService
+func (m *ResourcesService) GetCommandsByClusterKeyContext(a0 context.Context, a1 string, a2 string, a3 ...interface{}) (r0 []api.CommandPoint, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("GetCommandsByClusterKey", 3, a0, append([]interface{}{a1, a2}, a3...)...); ok {
+		assign("GetCommandsByClusterKey", res, &r0, &r1, &r2)
+		return
+	}
+	if m.GetCommandsByClusterKeyFunc != nil {
+		return m.GetCommandsByClusterKeyFunc(a0, a1, a2, a3...)
+	}
+	return
+}
+
+// GetDataByDeviceKeys implements api.ResourcesService
+func (m *ResourcesService) GetDataByDeviceKeys(a0 string, a1 []string, a2 ...interface{}) (map[string][]api.DataPoint, error) {
+	return m.GetDataByDeviceKeysContext(context.Background(), a0, a1, a2...)
+}
+
+// GetDataByDeviceKeysContext implements api.ResourcesService
+func (m *ResourcesService) GetDataByDeviceKeysContext(a0 context.Context, a1 string, a2 []string, a3 ...interface{}) (r0 map[string][]api.DataPoint, r1 error) {
+	if res, ok := m.record("GetDataByDeviceKeys", 2, a0, append([]interface{}{a1, a2}, a3...)...); ok {
+		assign("GetDataByDeviceKeys", res, &r0, &r1)
+		return
+	}
+	if m.GetDataByDeviceKeysFunc != nil {
+		return m.GetDataByDeviceKeysFunc(a0, a1, a2, a3...)
+	}
+	return
+}
+
+// GetEventsByDeviceKeys implements api.ResourcesService
+func (m *ResourcesService) GetEventsByDeviceKeys(a0 string, a1 []string, a2 ...interface{}) (map[string][]api.EventPoint, error) {
+	return m.GetEventsByDeviceKeysContext(context.Background(), a0, a1, a2...)
+}
+
+// GetEventsByDeviceKeysContext implements api.ResourcesService
+func (m *ResourcesService) GetEventsByDeviceKeysContext(a0 context.Context, a1 string, a2 []string, a3 ...interface{}) (r0 map[string][]api.EventPoint, r1 error) {
+	if res, ok := m.record("GetEventsByDeviceKeys", 2, a0, append([]interface{}{a1, a2}, a3...)...); ok {
+		assign("GetEventsByDeviceKeys", res, &r0, &r1)
+		return
+	}
+	if m.GetEventsByDeviceKeysFunc != nil {
+		return m.GetEventsByDeviceKeysFunc(a0, a1, a2, a3...)
+	}
+	return
+}
+
+// GetCommandsByDeviceKeys implements api.ResourcesService
+func (m *ResourcesService) GetCommandsByDeviceKeys(a0 string, a1 []string, a2 ...interface{}) (map[string][]api.CommandPoint, error) {
+	return m.GetCommandsByDeviceKeysContext(context.Background(), a0, a1, a2...)
+}
+
+// GetCommandsByDeviceKeysContext implements api.ResourcesService
+func (m *ResourcesService) GetCommandsByDeviceKeysContext(a0 context.Context, a1 string, a2 []string, a3 ...interface{}) (r0 map[string][]api.CommandPoint, r1 error) {
+	if res, ok := m.record("GetCommandsByDeviceKeys", 2, a0, append([]interface{}{a1, a2}, a3...)...); ok {
+		assign("GetCommandsByDeviceKeys", res, &r0, &r1)
+		return
+	}
+	if m.GetCommandsByDeviceKeysFunc != nil {
+		return m.GetCommandsByDeviceKeysFunc(a0, a1, a2, a3...)
+	}
+	return
+}
+
+// GetDataByClusterKeys implements api.ResourcesService
+func (m *ResourcesService) GetDataByClusterKeys(a0 string, a1 []string, a2 ...interface{}) (map[string][]api.DataPoint, error) {
+	return m.GetDataByClusterKeysContext(context.Background(), a0, a1, a2...)
+}
+
+// GetDataByClusterKeysContext implements api.ResourcesService
+func (m *ResourcesService) GetDataByClusterKeysContext(a0 context.Context, a1 string, a2 []string, a3 ...interface{}) (r0 map[string][]api.DataPoint, r1 error) {
+	if res, ok := m.record("GetDataByClusterKeys", 2, a0, append([]interface{}{a1, a2}, a3...)...); ok {
+		assign("GetDataByClusterKeys", res, &r0, &r1)
+		return
+	}
+	if m.GetDataByClusterKeysFunc != nil {
+		return m.GetDataByClusterKeysFunc(a0, a1, a2, a3...)
+	}
+	return
+}
+
+// GetEventsByClusterKeys implements api.ResourcesService
+func (m *ResourcesService) GetEventsByClusterKeys(a0 string, a1 []string, a2 ...interface{}) (map[string][]api.EventPoint, error) {
+	return m.GetEventsByClusterKeysContext(context.Background(), a0, a1, a2...)
+}
+
+// GetEventsByClusterKeysContext implements api.ResourcesService
+func (m *ResourcesService) GetEventsByClusterKeysContext(a0 context.Context, a1 string, a2 []string, a3 ...interface{}) (r0 map[string][]api.EventPoint, r1 error) {
+	if res, ok := m.record("GetEventsByClusterKeys", 2, a0, append([]interface{}{a1, a2}, a3...)...); ok {
+		assign("GetEventsByClusterKeys", res, &r0, &r1)
+		return
+	}
+	if m.GetEventsByClusterKeysFunc != nil {
+		return m.GetEventsByClusterKeysFunc(a0, a1, a2, a3...)
+	}
+	return
+}
+
+// GetCommandsByClusterKeys implements api.ResourcesService
+func (m *ResourcesService) GetCommandsByClusterKeys(a0 string, a1 []string, a2 ...interface{}) (map[string][]api.CommandPoint, error) {
+	return m.GetCommandsByClusterKeysContext(context.Background(), a0, a1, a2...)
+}
+
+// GetCommandsByClusterKeysContext implements api.ResourcesService
+func (m *ResourcesService) GetCommandsByClusterKeysContext(a0 context.Context, a1 string, a2 []string, a3 ...interface{}) (r0 map[string][]api.CommandPoint, r1 error) {
+	if res, ok := m.record("GetCommandsByClusterKeys", 2, a0, append([]interface{}{a1, a2}, a3...)...); ok {
+		assign("GetCommandsByClusterKeys", res, &r0, &r1)
+		return
+	}
+	if m.GetCommandsByClusterKeysFunc != nil {
+		return m.GetCommandsByClusterKeysFunc(a0, a1, a2, a3...)
+	}
+	return
+}
+
+// AggregateDataByDeviceID implements api.ResourcesService
+func (m *ResourcesService) AggregateDataByDeviceID(a0 string, a1 *api.Aggregation, a2 ...interface{}) (map[string][]api.Bucket, error) {
+	return m.AggregateDataByDeviceIDContext(context.Background(), a0, a1, a2...)
+}
+
+// AggregateDataByDeviceIDContext implements api.ResourcesService
+func (m *ResourcesService) AggregateDataByDeviceIDContext(a0 context.Context, a1 string, a2 *api.Aggregation, a3 ...interface{}) (r0 map[string][]api.Bucket, r1 error) {
+	if res, ok := m.record("AggregateDataByDeviceID", 2, a0, append([]interface{}{a1, a2}, a3...)...); ok {
+		assign("AggregateDataByDeviceID", res, &r0, &r1)
+		return
+	}
+	if m.AggregateDataByDeviceIDFunc != nil {
+		return m.AggregateDataByDeviceIDFunc(a0, a1, a2, a3...)
+	}
+	return
+}
+
+// AggregateDataByClusterID implements api.ResourcesService
+func (m *ResourcesService) AggregateDataByClusterID(a0 string, a1 *api.Aggregation, a2 ...interface{}) (map[string][]api.Bucket, error) {
+	return m.AggregateDataByClusterIDContext(context.Background(), a0, a1, a2...)
+}
+
+// AggregateDataByClusterIDContext implements api.ResourcesService
+func (m *ResourcesService) AggregateDataByClusterIDContext(a0 context.Context, a1 string, a2 *api.Aggregation, a3 ...interface{}) (r0 map[string][]api.Bucket, r1 error) {
+	if res, ok := m.record("AggregateDataByClusterID", 2, a0, append([]interface{}{a1, a2}, a3...)...); ok {
+		assign("AggregateDataByClusterID", res, &r0, &r1)
+		return
+	}
+	if m.AggregateDataByClusterIDFunc != nil {
+		return m.AggregateDataByClusterIDFunc(a0, a1, a2, a3...)
+	}
+	return
+}
+
+// AggregateDataByLink implements api.ResourcesService
+func (m *ResourcesService) AggregateDataByLink(a0 string, a1 *api.Aggregation, a2 ...interface{}) (map[string][]api.Bucket, error) {
+	return m.AggregateDataByLinkContext(context.Background(), a0, a1, a2...)
+}
+
+// AggregateDataByLinkContext implements api.ResourcesService
+func (m *ResourcesService) AggregateDataByLinkContext(a0 context.Context, a1 string, a2 *api.Aggregation, a3 ...interface{}) (r0 map[string][]api.Bucket, r1 error) {
+	if res, ok := m.record("AggregateDataByLink", 2, a0, append([]interface{}{a1, a2}, a3...)...); ok {
+		assign("AggregateDataByLink", res, &r0, &r1)
+		return
+	}
+	if m.AggregateDataByLinkFunc != nil {
+		return m.AggregateDataByLinkFunc(a0, a1, a2, a3...)
+	}
+	return
+}
+
+// Subscribe implements api.ResourcesService
+func (m *ResourcesService) Subscribe(a0 context.Context, a1 string, a2 ...api.ResourceKind) (r0 *api.Subscription, r1 error) {
+	if res, ok := m.record("Subscribe", 2, a0, append([]interface{}{a1}, flatten(a2)...)...); ok {
+		assign("Subscribe", res, &r0, &r1)
+		return
+	}
+	if m.SubscribeFunc != nil {
+		return m.SubscribeFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// TenantService is a fake of api.TenantService. Fields ending with Func implement methods
+// of the same name and their Context variants.
+type TenantService struct {
+	Mock
+
+	GetFunc          func(a0 context.Context) (*api.Tenant, error)
+	UpdateByLinkFunc func(a0 context.Context, a1 string, a2 *api.TenantRequestUpdate) (*api.Tenant, error)
+}
+
+var _ api.TenantService = (*TenantService)(nil)
+
+// Get implements api.TenantService
+func (m *TenantService) Get() (*api.Tenant, error) {
+	return m.GetContext(context.Background())
+}
+
+// GetContext implements api.TenantService
+func (m *TenantService) GetContext(a0 context.Context) (r0 *api.Tenant, r1 error) {
+	if res, ok := m.record("Get", 2, a0); ok {
+		assign("Get", res, &r0, &r1)
+		return
+	}
+	if m.GetFunc != nil {
+		return m.GetFunc(a0)
+	}
+	return
+}
+
+// UpdateByLink implements api.TenantService
+func (m *TenantService) UpdateByLink(a0 string, a1 *api.TenantRequestUpdate) (*api.Tenant, error) {
+	return m.UpdateByLinkContext(context.Background(), a0, a1)
+}
+
+// UpdateByLinkContext implements api.TenantService
+func (m *TenantService) UpdateByLinkContext(a0 context.Context, a1 string, a2 *api.TenantRequestUpdate) (r0 *api.Tenant, r1 error) {
+	if res, ok := m.record("UpdateByLink", 2, a0, a1, a2); ok {
+		assign("UpdateByLink", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByLinkFunc != nil {
+		return m.UpdateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// UsergroupsService is a fake of api.UsergroupsService. Fields ending with Func implement methods
+// of the same name and their Context variants.
+type UsergroupsService struct {
+	Mock
+
+	GetByIdFunc           func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Usergroup, error)
+	GetByLinkFunc         func(a0 context.Context, a1 string, a2 ...interface{}) (*api.Usergroup, error)
+	ListByLinkFunc        func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Usergroup, *api.ListParams, error)
+	ListByDirectoryFunc   func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.Usergroup, *api.ListParams, error)
+	CreateByLinkFunc      func(a0 context.Context, a1 string, a2 *api.UsergroupRequestCreate) (*api.Usergroup, error)
+	CreateByDirectoryFunc func(a0 context.Context, a1 string, a2 *api.UsergroupRequestCreate) (*api.Usergroup, error)
+	UpdateByIdFunc        func(a0 context.Context, a1 string, a2 *api.UsergroupRequestUpdate) (*api.Usergroup, error)
+	UpdateByLinkFunc      func(a0 context.Context, a1 string, a2 *api.UsergroupRequestUpdate) (*api.Usergroup, error)
+	DeleteFunc            func(a0 context.Context, a1 *api.Usergroup) error
+	DeleteByLinkFunc      func(a0 context.Context, a1 string) error
+	DeleteByIdFunc        func(a0 context.Context, a1 string) error
+}
+
+var _ api.UsergroupsService = (*UsergroupsService)(nil)
+
+// GetById implements api.UsergroupsService
+func (m *UsergroupsService) GetById(a0 string, a1 ...interface{}) (*api.Usergroup, error) {
+	return m.GetByIdContext(context.Background(), a0, a1...)
+}
+
+// GetByIdContext implements api.UsergroupsService
+func (m *UsergroupsService) GetByIdContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Usergroup, r1 error) {
+	if res, ok := m.record("GetById", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetById", res, &r0, &r1)
+		return
+	}
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// GetByLink implements api.UsergroupsService
+func (m *UsergroupsService) GetByLink(a0 string, a1 ...interface{}) (*api.Usergroup, error) {
+	return m.GetByLinkContext(context.Background(), a0, a1...)
+}
+
+// GetByLinkContext implements api.UsergroupsService
+func (m *UsergroupsService) GetByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.Usergroup, r1 error) {
+	if res, ok := m.record("GetByLink", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetByLink", res, &r0, &r1)
+		return
+	}
+	if m.GetByLinkFunc != nil {
+		return m.GetByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// ListByLink implements api.UsergroupsService
+func (m *UsergroupsService) ListByLink(a0 string, a1 ...interface{}) ([]api.Usergroup, *api.ListParams, error) {
+	return m.ListByLinkContext(context.Background(), a0, a1...)
+}
+
+// ListByLinkContext implements api.UsergroupsService
+func (m *UsergroupsService) ListByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Usergroup, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByLink", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByLink", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByLinkFunc != nil {
+		return m.ListByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByLink implements api.UsergroupsService
+func (m *UsergroupsService) IterateByLink(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Usergroup] {
+	first := true
+	return api.NewIterator(a0, "IterateByLink", func(ctx context.Context, link string, args ...interface{}) ([]api.Usergroup, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByLinkContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// ListByDirectory implements api.UsergroupsService
+func (m *UsergroupsService) ListByDirectory(a0 string, a1 ...interface{}) ([]api.Usergroup, *api.ListParams, error) {
+	return m.ListByDirectoryContext(context.Background(), a0, a1...)
+}
+
+// ListByDirectoryContext implements api.UsergroupsService
+func (m *UsergroupsService) ListByDirectoryContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.Usergroup, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByDirectory", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByDirectory", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByDirectoryFunc != nil {
+		return m.ListByDirectoryFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByDirectory implements api.UsergroupsService
+func (m *UsergroupsService) IterateByDirectory(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.Usergroup] {
+	first := true
+	return api.NewIterator(a0, "IterateByDirectory", func(ctx context.Context, link string, args ...interface{}) ([]api.Usergroup, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByDirectoryContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// CreateByLink implements api.UsergroupsService
+func (m *UsergroupsService) CreateByLink(a0 string, a1 *api.UsergroupRequestCreate) (*api.Usergroup, error) {
+	return m.CreateByLinkContext(context.Background(), a0, a1)
+}
+
+// CreateByLinkContext implements api.UsergroupsService
+func (m *UsergroupsService) CreateByLinkContext(a0 context.Context, a1 string, a2 *api.UsergroupRequestCreate) (r0 *api.Usergroup, r1 error) {
+	if res, ok := m.record("CreateByLink", 2, a0, a1, a2); ok {
+		assign("CreateByLink", res, &r0, &r1)
+		return
+	}
+	if m.CreateByLinkFunc != nil {
+		return m.CreateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// CreateByDirectory implements api.UsergroupsService
+func (m *UsergroupsService) CreateByDirectory(a0 string, a1 *api.UsergroupRequestCreate) (*api.Usergroup, error) {
+	return m.CreateByDirectoryContext(context.Background(), a0, a1)
+}
+
+// CreateByDirectoryContext implements api.UsergroupsService
+func (m *UsergroupsService) CreateByDirectoryContext(a0 context.Context, a1 string, a2 *api.UsergroupRequestCreate) (r0 *api.Usergroup, r1 error) {
+	if res, ok := m.record("CreateByDirectory", 2, a0, a1, a2); ok {
+		assign("CreateByDirectory", res, &r0, &r1)
+		return
+	}
+	if m.CreateByDirectoryFunc != nil {
+		return m.CreateByDirectoryFunc(a0, a1, a2)
+	}
+	return
+}
+
+// UpdateById implements api.UsergroupsService
+func (m *UsergroupsService) UpdateById(a0 string, a1 *api.UsergroupRequestUpdate) (*api.Usergroup, error) {
+	return m.UpdateByIdContext(context.Background(), a0, a1)
+}
+
+// UpdateByIdContext implements api.UsergroupsService
+func (m *UsergroupsService) UpdateByIdContext(a0 context.Context, a1 string, a2 *api.UsergroupRequestUpdate) (r0 *api.Usergroup, r1 error) {
+	if res, ok := m.record("UpdateById", 2, a0, a1, a2); ok {
+		assign("UpdateById", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByIdFunc != nil {
+		return m.UpdateByIdFunc(a0, a1, a2)
+	}
+	return
+}
+
+// UpdateByLink implements api.UsergroupsService
+func (m *UsergroupsService) UpdateByLink(a0 string, a1 *api.UsergroupRequestUpdate) (*api.Usergroup, error) {
+	return m.UpdateByLinkContext(context.Background(), a0, a1)
+}
+
+// UpdateByLinkContext implements api.UsergroupsService
+func (m *UsergroupsService) UpdateByLinkContext(a0 context.Context, a1 string, a2 *api.UsergroupRequestUpdate) (r0 *api.Usergroup, r1 error) {
+	if res, ok := m.record("UpdateByLink", 2, a0, a1, a2); ok {
+		assign("UpdateByLink", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByLinkFunc != nil {
+		return m.UpdateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// Delete implements api.UsergroupsService
+func (m *UsergroupsService) Delete(a0 *api.Usergroup) error {
+	return m.DeleteContext(context.Background(), a0)
+}
+
+// DeleteContext implements api.UsergroupsService
+func (m *UsergroupsService) DeleteContext(a0 context.Context, a1 *api.Usergroup) (r0 error) {
+	if res, ok := m.record("Delete", 1, a0, a1); ok {
+		assign("Delete", res, &r0)
+		return
+	}
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteByLink implements api.UsergroupsService
+func (m *UsergroupsService) DeleteByLink(a0 string) error {
+	return m.DeleteByLinkContext(context.Background(), a0)
+}
+
+// DeleteByLinkContext implements api.UsergroupsService
+func (m *UsergroupsService) DeleteByLinkContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteByLink", 1, a0, a1); ok {
+		assign("DeleteByLink", res, &r0)
+		return
+	}
+	if m.DeleteByLinkFunc != nil {
+		return m.DeleteByLinkFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteById implements api.UsergroupsService
+func (m *UsergroupsService) DeleteById(a0 string) error {
+	return m.DeleteByIdContext(context.Background(), a0)
+}
+
+// DeleteByIdContext implements api.UsergroupsService
+func (m *UsergroupsService) DeleteByIdContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteById", 1, a0, a1); ok {
+		assign("DeleteById", res, &r0)
+		return
+	}
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(a0, a1)
+	}
+	return
+}
+
+// UsersService is a fake of api.UsersService. Fields ending with Func implement methods
+// of the same name and their Context variants.
+type UsersService struct {
+	Mock
+
+	GetCurrentFunc        func(a0 context.Context, a1 ...interface{}) (*api.User, error)
+	GetByIdFunc           func(a0 context.Context, a1 string, a2 ...interface{}) (*api.User, error)
+	GetByLinkFunc         func(a0 context.Context, a1 string, a2 ...interface{}) (*api.User, error)
+	ListByLinkFunc        func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.User, *api.ListParams, error)
+	ListByDirectoryFunc   func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.User, *api.ListParams, error)
+	ListByUsergroupFunc   func(a0 context.Context, a1 string, a2 ...interface{}) ([]api.User, *api.ListParams, error)
+	CreateByLinkFunc      func(a0 context.Context, a1 string, a2 *api.UserRequestCreate) (*api.User, error)
+	CreateByDirectoryFunc func(a0 context.Context, a1 string, a2 *api.UserRequestCreate) (*api.User, error)
+	UpdateByIdFunc        func(a0 context.Context, a1 string, a2 *api.UserRequestUpdate) (*api.User, error)
+	UpdateByLinkFunc      func(a0 context.Context, a1 string, a2 *api.UserRequestUpdate) (*api.User, error)
+	DeleteFunc            func(a0 context.Context, a1 *api.User) error
+	DeleteByLinkFunc      func(a0 context.Context, a1 string) error
+	DeleteByIdFunc        func(a0 context.Context, a1 string) error
+}
+
+var _ api.UsersService = (*UsersService)(nil)
+
+// GetCurrent implements api.UsersService
+func (m *UsersService) GetCurrent(a0 ...interface{}) (*api.User, error) {
+	return m.GetCurrentContext(context.Background(), a0...)
+}
+
+// GetCurrentContext implements api.UsersService
+func (m *UsersService) GetCurrentContext(a0 context.Context, a1 ...interface{}) (r0 *api.User, r1 error) {
+	if res, ok := m.record("GetCurrent", 2, a0, a1...); ok {
+		assign("GetCurrent", res, &r0, &r1)
+		return
+	}
+	if m.GetCurrentFunc != nil {
+		return m.GetCurrentFunc(a0, a1...)
+	}
+	return
+}
+
+// GetById implements api.UsersService
+func (m *UsersService) GetById(a0 string, a1 ...interface{}) (*api.User, error) {
+	return m.GetByIdContext(context.Background(), a0, a1...)
+}
+
+// GetByIdContext implements api.UsersService
+func (m *UsersService) GetByIdContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.User, r1 error) {
+	if res, ok := m.record("GetById", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetById", res, &r0, &r1)
+		return
+	}
+	if m.GetByIdFunc != nil {
+		return m.GetByIdFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// GetByLink implements api.UsersService
+func (m *UsersService) GetByLink(a0 string, a1 ...interface{}) (*api.User, error) {
+	return m.GetByLinkContext(context.Background(), a0, a1...)
+}
+
+// GetByLinkContext implements api.UsersService
+func (m *UsersService) GetByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 *api.User, r1 error) {
+	if res, ok := m.record("GetByLink", 2, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("GetByLink", res, &r0, &r1)
+		return
+	}
+	if m.GetByLinkFunc != nil {
+		return m.GetByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// ListByLink implements api.UsersService
+func (m *UsersService) ListByLink(a0 string, a1 ...interface{}) ([]api.User, *api.ListParams, error) {
+	return m.ListByLinkContext(context.Background(), a0, a1...)
+}
+
+// ListByLinkContext implements api.UsersService
+func (m *UsersService) ListByLinkContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.User, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByLink", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByLink", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByLinkFunc != nil {
+		return m.ListByLinkFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByLink implements api.UsersService
+func (m *UsersService) IterateByLink(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.User] {
+	first := true
+	return api.NewIterator(a0, "IterateByLink", func(ctx context.Context, link string, args ...interface{}) ([]api.User, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByLinkContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// ListByDirectory implements api.UsersService
+func (m *UsersService) ListByDirectory(a0 string, a1 ...interface{}) ([]api.User, *api.ListParams, error) {
+	return m.ListByDirectoryContext(context.Background(), a0, a1...)
+}
+
+// ListByDirectoryContext implements api.UsersService
+func (m *UsersService) ListByDirectoryContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.User, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByDirectory", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByDirectory", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByDirectoryFunc != nil {
+		return m.ListByDirectoryFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByDirectory implements api.UsersService
+func (m *UsersService) IterateByDirectory(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.User] {
+	first := true
+	return api.NewIterator(a0, "IterateByDirectory", func(ctx context.Context, link string, args ...interface{}) ([]api.User, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByDirectoryContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// ListByUsergroup implements api.UsersService
+func (m *UsersService) ListByUsergroup(a0 string, a1 ...interface{}) ([]api.User, *api.ListParams, error) {
+	return m.ListByUsergroupContext(context.Background(), a0, a1...)
+}
+
+// ListByUsergroupContext implements api.UsersService
+func (m *UsersService) ListByUsergroupContext(a0 context.Context, a1 string, a2 ...interface{}) (r0 []api.User, r1 *api.ListParams, r2 error) {
+	if res, ok := m.record("ListByUsergroup", 3, a0, append([]interface{}{a1}, a2...)...); ok {
+		assign("ListByUsergroup", res, &r0, &r1, &r2)
+		return
+	}
+	if m.ListByUsergroupFunc != nil {
+		return m.ListByUsergroupFunc(a0, a1, a2...)
+	}
+	return
+}
+
+// IterateByUsergroup implements api.UsersService
+func (m *UsersService) IterateByUsergroup(a0 context.Context, a1 string, a2 ...interface{}) *api.Iterator[api.User] {
+	first := true
+	return api.NewIterator(a0, "IterateByUsergroup", func(ctx context.Context, link string, args ...interface{}) ([]api.User, *api.ListParams, error) {
+		if first {
+			first = false
+			return m.ListByUsergroupContext(ctx, a1, args...)
+		}
+		return m.ListByLinkContext(ctx, link, args...)
+	}, a2...)
+}
+
+// CreateByLink implements api.UsersService
+func (m *UsersService) CreateByLink(a0 string, a1 *api.UserRequestCreate) (*api.User, error) {
+	return m.CreateByLinkContext(context.Background(), a0, a1)
+}
+
+// CreateByLinkContext implements api.UsersService
+func (m *UsersService) CreateByLinkContext(a0 context.Context, a1 string, a2 *api.UserRequestCreate) (r0 *api.User, r1 error) {
+	if res, ok := m.record("CreateByLink", 2, a0, a1, a2); ok {
+		assign("CreateByLink", res, &r0, &r1)
+		return
+	}
+	if m.CreateByLinkFunc != nil {
+		return m.CreateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// CreateByDirectory implements api.UsersService
+func (m *UsersService) CreateByDirectory(a0 string, a1 *api.UserRequestCreate) (*api.User, error) {
+	return m.CreateByDirectoryContext(context.Background(), a0, a1)
+}
+
+// CreateByDirectoryContext implements api.UsersService
+func (m *UsersService) CreateByDirectoryContext(a0 context.Context, a1 string, a2 *api.UserRequestCreate) (r0 *api.User, r1 error) {
+	if res, ok := m.record("CreateByDirectory", 2, a0, a1, a2); ok {
+		assign("CreateByDirectory", res, &r0, &r1)
+		return
+	}
+	if m.CreateByDirectoryFunc != nil {
+		return m.CreateByDirectoryFunc(a0, a1, a2)
+	}
+	return
+}
+
+// UpdateById implements api.UsersService
+func (m *UsersService) UpdateById(a0 string, a1 *api.UserRequestUpdate) (*api.User, error) {
+	return m.UpdateByIdContext(context.Background(), a0, a1)
+}
+
+// UpdateByIdContext implements api.UsersService
+func (m *UsersService) UpdateByIdContext(a0 context.Context, a1 string, a2 *api.UserRequestUpdate) (r0 *api.User, r1 error) {
+	if res, ok := m.record("UpdateById", 2, a0, a1, a2); ok {
+		assign("UpdateById", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByIdFunc != nil {
+		return m.UpdateByIdFunc(a0, a1, a2)
+	}
+	return
+}
+
+// UpdateByLink implements api.UsersService
+func (m *UsersService) UpdateByLink(a0 string, a1 *api.UserRequestUpdate) (*api.User, error) {
+	return m.UpdateByLinkContext(context.Background(), a0, a1)
+}
+
+// UpdateByLinkContext implements api.UsersService
+func (m *UsersService) UpdateByLinkContext(a0 context.Context, a1 string, a2 *api.UserRequestUpdate) (r0 *api.User, r1 error) {
+	if res, ok := m.record("UpdateByLink", 2, a0, a1, a2); ok {
+		assign("UpdateByLink", res, &r0, &r1)
+		return
+	}
+	if m.UpdateByLinkFunc != nil {
+		return m.UpdateByLinkFunc(a0, a1, a2)
+	}
+	return
+}
+
+// Delete implements api.UsersService
+func (m *UsersService) Delete(a0 *api.User) error {
+	return m.DeleteContext(context.Background(), a0)
+}
+
+// DeleteContext implements api.UsersService
+func (m *UsersService) DeleteContext(a0 context.Context, a1 *api.User) (r0 error) {
+	if res, ok := m.record("Delete", 1, a0, a1); ok {
+		assign("Delete", res, &r0)
+		return
+	}
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteByLink implements api.UsersService
+func (m *UsersService) DeleteByLink(a0 string) error {
+	return m.DeleteByLinkContext(context.Background(), a0)
+}
+
+// DeleteByLinkContext implements api.UsersService
+func (m *UsersService) DeleteByLinkContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteByLink", 1, a0, a1); ok {
+		assign("DeleteByLink", res, &r0)
+		return
+	}
+	if m.DeleteByLinkFunc != nil {
+		return m.DeleteByLinkFunc(a0, a1)
+	}
+	return
+}
+
+// DeleteById implements api.UsersService
+func (m *UsersService) DeleteById(a0 string) error {
+	return m.DeleteByIdContext(context.Background(), a0)
+}
+
+// DeleteByIdContext implements api.UsersService
+func (m *UsersService) DeleteByIdContext(a0 context.Context, a1 string) (r0 error) {
+	if res, ok := m.record("DeleteById", 1, a0, a1); ok {
+		assign("DeleteById", res, &r0)
+		return
+	}
+	if m.DeleteByIdFunc != nil {
+		return m.DeleteByIdFunc(a0, a1)
+	}
+	return
+}
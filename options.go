@@ -0,0 +1,200 @@
+package api
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Option configures Client created with NewClientWithOptions
+type Option func(*clientOptions) error
+
+// clientOptions gathers configuration before Client is created
+type clientOptions struct {
+	httpClient  *http.Client
+	baseURL     string
+	apiPath     string
+	userAgent   string
+	timeout     time.Duration
+	tlsConfig   *tls.Config
+	proxy       func(*http.Request) (*url.URL, error)
+	retryPolicy *RetryPolicy
+	rateLimiter *RateLimiter
+	logger      Logger
+	logBodies   bool
+	expand      *Expansion
+	credentials Credentials
+	middleware  []Middleware
+
+	schemaValidation bool
+	schemaTTL        time.Duration
+
+	subscriptions SubscriptionOptions
+}
+
+// WithHTTPClient sets HTTP client used to communicate with API
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) error {
+		o.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithBaseURL sets URL of tenant, e.g. https://tenant-name.cloudthing.io
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) error {
+		o.baseURL = baseURL
+		return nil
+	}
+}
+
+// WithAPIPath sets path of API version, "/api/v1/" by default
+func WithAPIPath(path string) Option {
+	return func(o *clientOptions) error {
+		if path == "" {
+			return fmt.Errorf("API path can't be empty")
+		}
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		if !strings.HasSuffix(path, "/") {
+			path = path + "/"
+		}
+		o.apiPath = path
+		return nil
+	}
+}
+
+// WithUserAgent sets User-Agent header sent with requests
+func WithUserAgent(ua string) Option {
+	return func(o *clientOptions) error {
+		o.userAgent = ua
+		return nil
+	}
+}
+
+// WithTimeout sets time limit for a single HTTP request, streams of subscriptions
+// aren't limited
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) error {
+		o.timeout = timeout
+		return nil
+	}
+}
+
+// WithTLSConfig sets TLS configuration used for connections to API
+func WithTLSConfig(config *tls.Config) Option {
+	return func(o *clientOptions) error {
+		o.tlsConfig = config
+		return nil
+	}
+}
+
+// WithProxy sets proxy used for connections to API
+func WithProxy(proxyURL string) Option {
+	return func(o *clientOptions) error {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return err
+		}
+		o.proxy = http.ProxyURL(u)
+		return nil
+	}
+}
+
+// WithRetryPolicy sets policy of retrying failed requests
+func WithRetryPolicy(p *RetryPolicy) Option {
+	return func(o *clientOptions) error {
+		o.retryPolicy = p
+		return nil
+	}
+}
+
+// WithRateLimiter sets limiter shared by all services
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(o *clientOptions) error {
+		o.rateLimiter = l
+		return nil
+	}
+}
+
+// WithLogger sets logger for diagnostic messages, see NewSlogLogger
+func WithLogger(l Logger) Option {
+	return func(o *clientOptions) error {
+		o.logger = l
+		return nil
+	}
+}
+
+// WithLogBodies enables logging of headers and bodies, see Client.SetLogBodies
+func WithLogBodies(enabled bool) Option {
+	return func(o *clientOptions) error {
+		o.logBodies = enabled
+		return nil
+	}
+}
+
+// WithDefaultExpand sets expansions added to every GET request of management
+// endpoints which doesn't specify its own
+func WithDefaultExpand(e *Expansion) Option {
+	return func(o *clientOptions) error {
+		o.expand = e
+		return nil
+	}
+}
+
+// WithCredentials sets source of tokens. First token is requested with first call.
+func WithCredentials(creds Credentials) Option {
+	return func(o *clientOptions) error {
+		o.credentials = creds
+		return nil
+	}
+}
+
+// WithMiddleware appends middleware wrapping sending of requests, see Client.Use
+func WithMiddleware(mw ...Middleware) Option {
+	return func(o *clientOptions) error {
+		o.middleware = append(o.middleware, mw...)
+		return nil
+	}
+}
+
+// client builds HTTP client according to transport related options
+func (o *clientOptions) client() (*http.Client, error) {
+	if o.timeout == 0 && o.tlsConfig == nil && o.proxy == nil {
+		if o.httpClient == nil {
+			return http.DefaultClient, nil
+		}
+		return o.httpClient, nil
+	}
+
+	hc := &http.Client{}
+	if o.httpClient != nil {
+		*hc = *o.httpClient
+	}
+	if o.timeout != 0 {
+		hc.Timeout = o.timeout
+	}
+	if o.tlsConfig != nil || o.proxy != nil {
+		base := hc.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		t, ok := base.(*http.Transport)
+		if !ok {
+			return nil, fmt.Errorf("TLS config and proxy require *http.Transport, got %T", base)
+		}
+		t = t.Clone()
+		if o.tlsConfig != nil {
+			t.TLSClientConfig = o.tlsConfig
+		}
+		if o.proxy != nil {
+			t.Proxy = o.proxy
+		}
+		hc.Transport = t
+	}
+	return hc, nil
+}
@@ -0,0 +1,148 @@
+package api_test
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+)
+
+func TestNewClientWithOptions(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, err := api.NewClientWithOptions(
+		api.WithBaseURL(srv.URL+"/"),
+		api.WithAPIPath("api/v1"),
+		api.WithHTTPClient(srv.Client()),
+		api.WithUserAgent("lamp-controller/1.0"),
+		api.WithTimeout(5*time.Second),
+		api.WithDefaultExpand(api.Expand("product")),
+		api.WithCredentials(&api.BasicCredentials{Username: apitest.AdminUsername, Password: apitest.AdminPassword}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u := client.BaseURL.String(); u != srv.URL+"/api/v1/" {
+		t.Errorf("base URL = %s, want %s/api/v1/", u, srv.URL)
+	}
+	product := srv.Add("products", map[string]interface{}{"name": "Lamp"}, nil)
+	id := srv.Add("devices", map[string]interface{}{}, map[string]string{"product": "products/" + product})
+
+	d, err := client.Devices.GetById(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Product == nil || d.Product.Name != "Lamp" {
+		t.Errorf("product = %+v, want expanded by default", d.Product)
+	}
+	reqs := srv.Requests()
+	last := reqs[len(reqs)-1]
+	if ua := last.Header.Get("User-Agent"); ua != "lamp-controller/1.0" {
+		t.Errorf("User-Agent = %q", ua)
+	}
+	// expansion of request replaces the default one
+	if _, err := client.Devices.GetById(id, api.Expand("clusters")); err != nil {
+		t.Fatal(err)
+	}
+	reqs = srv.Requests()
+	if exp := reqs[len(reqs)-1].Query.Get("expand"); exp != "clusters" {
+		t.Errorf("expand = %q, want clusters only", exp)
+	}
+
+	legacy, err := api.NewClient(srv.Client(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if legacy.BaseURL.String() != client.BaseURL.String() {
+		t.Errorf("NewClient base URL = %s, want %s", legacy.BaseURL, client.BaseURL)
+	}
+}
+
+func TestNewClientWithInvalidOptions(t *testing.T) {
+	tests := map[string][]api.Option{
+		"empty API path": {api.WithAPIPath("")},
+		"proxy":          {api.WithProxy("://proxy")},
+		"TLS config with custom transport": {
+			api.WithHTTPClient(&http.Client{Transport: api.RoundTripFunc(func(*http.Request) (*http.Response, error) { return nil, nil })}),
+			api.WithTLSConfig(&tls.Config{}),
+		},
+	}
+	for name, opts := range tests {
+		if _, err := api.NewClientWithOptions(opts...); err == nil {
+			t.Errorf("%s: client created", name)
+		}
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer slow.Close()
+	client, err := api.NewClientWithOptions(api.WithBaseURL(slow.URL), api.WithHTTPClient(slow.Client()), api.WithTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if err := client.SetTokenAuth(&api.Token{Token: "x"}); err == nil {
+		t.Error("request to slow server succeeded")
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("request timed out after %s", d)
+	}
+}
+
+func TestLazyCredentials(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	var tenants []string
+	tenant := func(next http.RoundTripper) http.RoundTripper {
+		return api.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if op, ok := api.OperationFromContext(req.Context()); ok && op.Service == "Devices" {
+				tenants = append(tenants, op.TenantId)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+	client, err := srv.NewClient(api.WithMiddleware(tenant))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Errorf("%d requests sent by creating client", n)
+	}
+	if client.IsAuthenticated() || client.GetToken() != nil {
+		t.Error("client authenticated before the first request")
+	}
+
+	// the first request retrieves token and knows its tenant
+	if _, err := client.Devices.GetById(srv.Add("devices", map[string]interface{}{}, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if len(tenants) != 1 || tenants[0] != srv.TenantId() {
+		t.Errorf("tenants of requests = %v, want %s", tenants, srv.TenantId())
+	}
+	if !client.IsAuthenticated() || countRequests(srv, "POST", "auth/token") != 1 {
+		t.Error("token wasn't retrieved by the first request")
+	}
+
+	failing := errors.New("no credentials")
+	client, err = srv.NewClient(api.WithCredentials(api.CredentialsFunc(func(context.Context, *api.Client) (*api.Token, error) {
+		return nil, failing
+	})))
+	if err != nil {
+		t.Fatalf("client with failing credentials = %v, want error of the first request", err)
+	}
+	if _, err := client.Devices.GetById("1"); !errors.Is(err, failing) {
+		t.Errorf("request = %v, want error of credentials", err)
+	}
+}
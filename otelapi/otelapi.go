@@ -0,0 +1,171 @@
+// Package otelapi provides OpenTelemetry instrumentation of api.Client. Every request
+// is recorded as a client span named after its operation, e.g.
+// cloudthing.Devices.ListByProduct, and measured by latency histogram and error counter:
+//
+//	client, err := api.NewClientWithOptions(
+//		api.WithBaseURL("https://tenant-name.cloudthing.io"),
+//		api.WithMiddleware(otelapi.Middleware()),
+//	)
+//
+// Global providers are used unless set with options. Trace context is propagated
+// to API in request headers.
+package otelapi
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	api "github.com/cloudthing-io/go-client-api"
+)
+
+// ScopeName is name of instrumentation scope of tracer and meter
+const ScopeName = "github.com/cloudthing-io/go-client-api/otelapi"
+
+// Prefix of span names and attributes
+const prefix = "cloudthing."
+
+// Attributes of spans and measurements
+const (
+	OperationKey  = attribute.Key("cloudthing.operation")
+	TenantIdKey   = attribute.Key("cloudthing.tenant.id")
+	HrefKey       = attribute.Key("cloudthing.resource.href")
+	PageKey       = attribute.Key("cloudthing.page")
+	LimitKey      = attribute.Key("cloudthing.limit")
+	MethodKey     = attribute.Key("http.request.method")
+	StatusCodeKey = attribute.Key("http.response.status_code")
+)
+
+// Names of instruments
+const (
+	DurationMetric = "cloudthing.client.request.duration"
+	ErrorsMetric   = "cloudthing.client.request.errors"
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// Option configures instrumentation
+type Option func(*config)
+
+// WithTracerProvider sets provider of tracer, global one is used by default
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets provider of meter, global one is used by default
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithPropagators sets propagators injecting trace context into requests,
+// global one is used by default
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = p
+	}
+}
+
+// Middleware returns middleware instrumenting requests of client
+func Middleware(opts ...Option) api.Middleware {
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, o := range opts {
+		o(c)
+	}
+
+	tracer := c.tracerProvider.Tracer(ScopeName)
+	meter := c.meterProvider.Meter(ScopeName)
+	duration, err := meter.Float64Histogram(DurationMetric,
+		metric.WithDescription("Duration of requests to CloudThing API"),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	errors, err := meter.Int64Counter(ErrorsMetric,
+		metric.WithDescription("Number of failed requests to CloudThing API"),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return api.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			op, _ := api.OperationFromContext(req.Context())
+			name := op.String()
+			if name == "" {
+				name = req.Method
+			}
+
+			attrs := []attribute.KeyValue{
+				OperationKey.String(name),
+				MethodKey.String(req.Method),
+			}
+			if op.TenantId != "" {
+				attrs = append(attrs, TenantIdKey.String(op.TenantId))
+			}
+			spanAttrs := append([]attribute.KeyValue{}, attrs...)
+			if op.Href != "" {
+				spanAttrs = append(spanAttrs, HrefKey.String(op.Href))
+			}
+			q := req.URL.Query()
+			if n, err := strconv.Atoi(q.Get("page")); err == nil {
+				spanAttrs = append(spanAttrs, PageKey.Int(n))
+			}
+			if n, err := strconv.Atoi(q.Get("limit")); err == nil {
+				spanAttrs = append(spanAttrs, LimitKey.Int(n))
+			}
+
+			ctx, span := tracer.Start(req.Context(), prefix+name,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(spanAttrs...))
+			defer span.End()
+
+			req = req.Clone(ctx)
+			c.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			elapsed := time.Since(start).Seconds()
+
+			failed := err != nil
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			} else {
+				attrs = append(attrs, StatusCodeKey.Int(resp.StatusCode))
+				span.SetAttributes(StatusCodeKey.Int(resp.StatusCode))
+				if resp.StatusCode >= 400 {
+					failed = true
+					span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+				}
+			}
+
+			set := metric.WithAttributes(attrs...)
+			if duration != nil {
+				duration.Record(ctx, elapsed, set)
+			}
+			if failed && errors != nil {
+				errors.Add(ctx, 1, set)
+			}
+			return resp, err
+		})
+	}
+}
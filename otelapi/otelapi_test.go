@@ -0,0 +1,133 @@
+package otelapi_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+	"github.com/cloudthing-io/go-client-api/otelapi"
+	"github.com/cloudthing-io/go-client-api/otelapi/otelapitest"
+)
+
+// span returns the last recorded span named name
+func span(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	t.Helper()
+	for i := len(spans) - 1; i >= 0; i-- {
+		if spans[i].Name == name {
+			return spans[i]
+		}
+	}
+	t.Fatalf("no span %s", name)
+	return tracetest.SpanStub{}
+}
+
+func attrs(kvs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value, len(kvs))
+	for _, kv := range kvs {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+// find returns instrument named name from collected metrics
+func find(rm metricdata.ResourceMetrics, name string) metricdata.Aggregation {
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m.Data
+			}
+		}
+	}
+	return nil
+}
+
+func TestMiddleware(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	rec := otelapitest.NewRecorder()
+	client, err := srv.NewClient(api.WithMiddleware(otelapi.Middleware(rec.Options()...)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	product := srv.Add("products", map[string]interface{}{"name": "Lamp"}, nil)
+	srv.Add("devices", map[string]interface{}{}, map[string]string{"product": "products/" + product})
+
+	if _, _, err := client.Devices.ListByProduct(product, &api.ListOptions{Page: 1, Limit: 10}); err != nil {
+		t.Fatal(err)
+	}
+	s := span(t, rec.Spans(), "cloudthing.Devices.ListByProduct")
+	a := attrs(s.Attributes)
+	if v := a[otelapi.TenantIdKey].AsString(); v != srv.TenantId() {
+		t.Errorf("tenant = %q, want %q", v, srv.TenantId())
+	}
+	if v := a[otelapi.HrefKey].AsString(); v != srv.URL+"/api/v1/products/"+product+"/devices" {
+		t.Errorf("href = %q", v)
+	}
+	if a[otelapi.PageKey].AsInt64() != 1 || a[otelapi.LimitKey].AsInt64() != 10 {
+		t.Errorf("page and limit = %v and %v, want 1 and 10", a[otelapi.PageKey].Emit(), a[otelapi.LimitKey].Emit())
+	}
+	if a[otelapi.StatusCodeKey].AsInt64() != http.StatusOK || a[otelapi.MethodKey].AsString() != "GET" {
+		t.Errorf("status and method = %v and %v, want 200 GET", a[otelapi.StatusCodeKey].Emit(), a[otelapi.MethodKey].Emit())
+	}
+	if s.Status.Code == codes.Error {
+		t.Errorf("successful request has error status")
+	}
+
+	// trace context is propagated to API
+	reqs := srv.Requests()
+	if h := reqs[len(reqs)-1].Header.Get("Traceparent"); h == "" {
+		t.Error("request has no traceparent header")
+	}
+
+	rm, err := rec.Metrics(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	hist, ok := find(rm, otelapi.DurationMetric).(metricdata.Histogram[float64])
+	if !ok {
+		t.Fatalf("no %s histogram", otelapi.DurationMetric)
+	}
+	var recorded uint64
+	for _, dp := range hist.DataPoints {
+		if v, _ := dp.Attributes.Value(otelapi.OperationKey); v.AsString() == "Devices.ListByProduct" {
+			recorded += dp.Count
+		}
+	}
+	if recorded != 1 {
+		t.Errorf("%d durations of Devices.ListByProduct recorded, want 1", recorded)
+	}
+	if sum := errorCount(find(rm, otelapi.ErrorsMetric)); sum != 0 {
+		t.Errorf("%d errors counted for successful requests", sum)
+	}
+
+	if _, err := client.Devices.GetById("missing"); err == nil {
+		t.Fatal("get of missing device succeeded")
+	}
+	s = span(t, rec.Spans(), "cloudthing.Devices.GetById")
+	if s.Status.Code != codes.Error || attrs(s.Attributes)[otelapi.StatusCodeKey].AsInt64() != http.StatusNotFound {
+		t.Errorf("span of failed request has status %v and code %v, want error 404", s.Status, attrs(s.Attributes)[otelapi.StatusCodeKey].Emit())
+	}
+	if rm, err = rec.Metrics(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if sum := errorCount(find(rm, otelapi.ErrorsMetric)); sum != 1 {
+		t.Errorf("%d errors counted, want 1", sum)
+	}
+}
+
+// errorCount sums data points of errors counter, which is absent until first error
+func errorCount(data metricdata.Aggregation) int64 {
+	sum, _ := data.(metricdata.Sum[int64])
+	var n int64
+	for _, dp := range sum.DataPoints {
+		n += dp.Value
+	}
+	return n
+}
@@ -0,0 +1,66 @@
+// Package otelapitest records spans and metrics of otelapi instrumentation in memory,
+// so instrumentation can be verified in tests without any collector:
+//
+//	rec := otelapitest.NewRecorder()
+//	client, err := srv.NewClient(api.WithMiddleware(otelapi.Middleware(rec.Options()...)))
+//	...
+//	spans := rec.Spans()
+package otelapitest
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/cloudthing-io/go-client-api/otelapi"
+)
+
+// Recorder holds providers exporting into memory
+type Recorder struct {
+	TracerProvider *sdktrace.TracerProvider
+	MeterProvider  *sdkmetric.MeterProvider
+
+	exporter *tracetest.InMemoryExporter
+	reader   *sdkmetric.ManualReader
+}
+
+// NewRecorder creates recorder with synchronously exporting providers
+func NewRecorder() *Recorder {
+	r := &Recorder{
+		exporter: tracetest.NewInMemoryExporter(),
+		reader:   sdkmetric.NewManualReader(),
+	}
+	r.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(r.exporter))
+	r.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(r.reader))
+	return r
+}
+
+// Options returns options of otelapi.Middleware using recorder's providers
+func (r *Recorder) Options() []otelapi.Option {
+	return []otelapi.Option{
+		otelapi.WithTracerProvider(r.TracerProvider),
+		otelapi.WithMeterProvider(r.MeterProvider),
+		otelapi.WithPropagators(propagation.TraceContext{}),
+	}
+}
+
+// Spans returns ended spans in order they ended
+func (r *Recorder) Spans() tracetest.SpanStubs {
+	return r.exporter.GetSpans()
+}
+
+// Metrics collects current values of all instruments
+func (r *Recorder) Metrics(ctx context.Context) (metricdata.ResourceMetrics, error) {
+	var rm metricdata.ResourceMetrics
+	err := r.reader.Collect(ctx, &rm)
+	return rm, err
+}
+
+// Reset removes recorded spans
+func (r *Recorder) Reset() {
+	r.exporter.Reset()
+}
@@ -0,0 +1,228 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrValueType is returned by typed getters of DataPoint when value has other type
+var ErrValueType = errors.New("unexpected type of value")
+
+// ErrNoTime is returned by Timestamp of point without time
+var ErrNoTime = errors.New("point has no time")
+
+// TimePrecision is precision of time of points sent to CloudThing
+type TimePrecision int
+
+const (
+	// PrecisionMillisecond formats time with milliseconds, e.g. 2006-01-02T15:04:05.000Z
+	PrecisionMillisecond TimePrecision = iota
+	// PrecisionNanosecond formats time with nanoseconds, trailing zeros are removed
+	PrecisionNanosecond
+)
+
+// Layout of time with milliseconds, RFC3339 with fixed fraction
+const timeLayoutMillis = "2006-01-02T15:04:05.000Z07:00"
+
+// FormatPointTime formats t as time of point in UTC, zero t is formatted as empty string
+// which stands for time of arrival
+func FormatPointTime(t time.Time, precision TimePrecision) string {
+	if t.IsZero() {
+		return ""
+	}
+	t = t.UTC()
+	if precision == PrecisionNanosecond {
+		return t.Format(time.RFC3339Nano)
+	}
+	return t.Truncate(time.Millisecond).Format(timeLayoutMillis)
+}
+
+// ParsePointTime parses time of point, it accepts RFC3339 with any fraction of second
+func ParsePointTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, ErrNoTime
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+// NewDataPoint creates point of key with value at t with millisecond precision.
+// Zero t leaves time empty.
+func NewDataPoint(key string, value interface{}, t time.Time) DataPoint {
+	return DataPoint{Key: key, Value: value, Time: FormatPointTime(t, PrecisionMillisecond)}
+}
+
+// NewFloatDataPoint creates point with numeric value
+func NewFloatDataPoint(key string, value float64, t time.Time) DataPoint {
+	return NewDataPoint(key, value, t)
+}
+
+// NewIntDataPoint creates point with integer value
+func NewIntDataPoint(key string, value int64, t time.Time) DataPoint {
+	return NewDataPoint(key, value, t)
+}
+
+// NewBoolDataPoint creates point with boolean value
+func NewBoolDataPoint(key string, value bool, t time.Time) DataPoint {
+	return NewDataPoint(key, value, t)
+}
+
+// NewStringDataPoint creates point with string value
+func NewStringDataPoint(key string, value string, t time.Time) DataPoint {
+	return NewDataPoint(key, value, t)
+}
+
+// NewJSONDataPoint creates point with value encoded from v, e.g. a struct
+func NewJSONDataPoint(key string, v interface{}, t time.Time) (DataPoint, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return DataPoint{}, err
+	}
+	return NewDataPoint(key, json.RawMessage(b), t), nil
+}
+
+// Timestamp parses time of point
+func (p DataPoint) Timestamp() (time.Time, error) {
+	return ParsePointTime(p.Time)
+}
+
+// SetTimestamp sets time of point formatted with precision
+func (p *DataPoint) SetTimestamp(t time.Time, precision TimePrecision) {
+	p.Time = FormatPointTime(t, precision)
+}
+
+// FloatValue returns numeric value of point
+func (p DataPoint) FloatValue() (float64, error) {
+	switch v := p.Value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s is not a number", ErrValueType, v)
+		}
+		return f, nil
+	case json.RawMessage:
+		var f float64
+		if err := json.Unmarshal(v, &f); err != nil {
+			return 0, valueTypeError(p.Value, "number")
+		}
+		return f, nil
+	}
+	return 0, valueTypeError(p.Value, "number")
+}
+
+// IntValue returns integer value of point. Numbers with fraction or out of
+// range of int64 are rejected.
+func (p DataPoint) IntValue() (int64, error) {
+	switch v := p.Value.(type) {
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case json.Number:
+		i, err := v.Int64()
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s is not an integer", ErrValueType, v)
+		}
+		return i, nil
+	case json.RawMessage:
+		var i int64
+		if err := json.Unmarshal(v, &i); err != nil {
+			return 0, valueTypeError(p.Value, "integer")
+		}
+		return i, nil
+	case float32, float64:
+		f, _ := p.FloatValue()
+		// 2^63 is the first float above range of int64
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, fmt.Errorf("%w: %v is not an integer", ErrValueType, f)
+		}
+		return int64(f), nil
+	}
+	return 0, valueTypeError(p.Value, "integer")
+}
+
+// BoolValue returns boolean value of point
+func (p DataPoint) BoolValue() (bool, error) {
+	switch v := p.Value.(type) {
+	case bool:
+		return v, nil
+	case json.RawMessage:
+		var b bool
+		if err := json.Unmarshal(v, &b); err != nil {
+			return false, valueTypeError(p.Value, "boolean")
+		}
+		return b, nil
+	}
+	return false, valueTypeError(p.Value, "boolean")
+}
+
+// StringValue returns string value of point
+func (p DataPoint) StringValue() (string, error) {
+	switch v := p.Value.(type) {
+	case string:
+		return v, nil
+	case json.RawMessage:
+		var s string
+		if err := json.Unmarshal(v, &s); err != nil {
+			return "", valueTypeError(p.Value, "string")
+		}
+		return s, nil
+	}
+	return "", valueTypeError(p.Value, "string")
+}
+
+// JSONValue decodes value of point into v, e.g. pointer to a struct
+func (p DataPoint) JSONValue(v interface{}) error {
+	if p.Value == nil {
+		return valueTypeError(nil, "JSON value")
+	}
+	b, ok := p.Value.(json.RawMessage)
+	if !ok {
+		var err error
+		if b, err = json.Marshal(p.Value); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(b, v)
+}
+
+func valueTypeError(v interface{}, expected string) error {
+	if v == nil {
+		return fmt.Errorf("%w: value is null, expected %s", ErrValueType, expected)
+	}
+	return fmt.Errorf("%w: %T is not a %s", ErrValueType, v, expected)
+}
+
+// Timestamp parses time of event
+func (p EventPoint) Timestamp() (time.Time, error) {
+	return ParsePointTime(p.Time)
+}
+
+// SetTimestamp sets time of event formatted with precision
+func (p *EventPoint) SetTimestamp(t time.Time, precision TimePrecision) {
+	p.Time = FormatPointTime(t, precision)
+}
+
+// Timestamp parses time of command
+func (p CommandPoint) Timestamp() (time.Time, error) {
+	return ParsePointTime(p.Time)
+}
+
+// SetTimestamp sets time of command formatted with precision
+func (p *CommandPoint) SetTimestamp(t time.Time, precision TimePrecision) {
+	p.Time = FormatPointTime(t, precision)
+}
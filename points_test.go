@@ -0,0 +1,149 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	api "github.com/cloudthing-io/go-client-api"
+)
+
+func TestPointNumericValues(t *testing.T) {
+	floats := []struct {
+		value interface{}
+		want  float64
+	}{
+		{2.5, 2.5}, {float32(0.5), 0.5}, {7, 7}, {int32(-3), -3}, {int64(1 << 40), 1 << 40},
+		{json.Number("1.25"), 1.25}, {json.RawMessage("-4e2"), -400},
+	}
+	for _, tt := range floats {
+		if v, err := (api.DataPoint{Value: tt.value}).FloatValue(); err != nil || v != tt.want {
+			t.Errorf("FloatValue of %#v = %v, %v, want %v", tt.value, v, err, tt.want)
+		}
+	}
+
+	ints := []struct {
+		value interface{}
+		want  int64
+	}{
+		{7, 7}, {int32(-3), -3}, {int64(1 << 40), 1 << 40}, {3.0, 3}, {float32(-2), -2},
+		{json.Number("42"), 42}, {json.RawMessage("9007199254740993"), 9007199254740993},
+	}
+	for _, tt := range ints {
+		if v, err := (api.DataPoint{Value: tt.value}).IntValue(); err != nil || v != tt.want {
+			t.Errorf("IntValue of %#v = %v, %v, want %v", tt.value, v, err, tt.want)
+		}
+	}
+
+	for _, value := range []interface{}{nil, "1", true, json.Number("x"), json.RawMessage(`"1"`)} {
+		if _, err := (api.DataPoint{Value: value}).FloatValue(); !errors.Is(err, api.ErrValueType) {
+			t.Errorf("FloatValue of %#v = %v, want ErrValueType", value, err)
+		}
+	}
+	for _, value := range []interface{}{nil, "1", 3.5, 1e19, -1e19, json.Number("1.5"), json.Number("99999999999999999999"), json.RawMessage("1.5")} {
+		if _, err := (api.DataPoint{Value: value}).IntValue(); !errors.Is(err, api.ErrValueType) {
+			t.Errorf("IntValue of %#v = %v, want ErrValueType", value, err)
+		}
+	}
+}
+
+func TestPointOtherValues(t *testing.T) {
+	if v, err := (api.DataPoint{Value: true}).BoolValue(); err != nil || !v {
+		t.Errorf("BoolValue of true = %v, %v", v, err)
+	}
+	if v, err := (api.DataPoint{Value: json.RawMessage("false")}).BoolValue(); err != nil || v {
+		t.Errorf("BoolValue of raw false = %v, %v", v, err)
+	}
+	if v, err := (api.DataPoint{Value: "on"}).StringValue(); err != nil || v != "on" {
+		t.Errorf("StringValue of on = %q, %v", v, err)
+	}
+	if v, err := (api.DataPoint{Value: json.RawMessage(`"off"`)}).StringValue(); err != nil || v != "off" {
+		t.Errorf("StringValue of raw off = %q, %v", v, err)
+	}
+	for _, value := range []interface{}{nil, 1.0, "true", json.RawMessage("1")} {
+		if _, err := (api.DataPoint{Value: value}).BoolValue(); !errors.Is(err, api.ErrValueType) {
+			t.Errorf("BoolValue of %#v = %v, want ErrValueType", value, err)
+		}
+	}
+	for _, value := range []interface{}{nil, 1.0, false, json.RawMessage("1")} {
+		if _, err := (api.DataPoint{Value: value}).StringValue(); !errors.Is(err, api.ErrValueType) {
+			t.Errorf("StringValue of %#v = %v, want ErrValueType", value, err)
+		}
+	}
+
+	type state struct {
+		Level int    `json:"level"`
+		Mode  string `json:"mode"`
+	}
+	p, err := api.NewJSONDataPoint("state", state{Level: 2, Mode: "eco"}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// point decoded from API holds generic value
+	var decoded api.DataPoint
+	b, _ := json.Marshal(p)
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	for _, point := range []api.DataPoint{p, decoded} {
+		var s state
+		if err := point.JSONValue(&s); err != nil || s != (state{Level: 2, Mode: "eco"}) {
+			t.Errorf("JSONValue of %#v = %+v, %v", point.Value, s, err)
+		}
+	}
+	if err := (api.DataPoint{}).JSONValue(&state{}); !errors.Is(err, api.ErrValueType) {
+		t.Errorf("JSONValue of null = %v, want ErrValueType", err)
+	}
+}
+
+func TestPointTime(t *testing.T) {
+	at := time.Date(2024, 3, 1, 11, 30, 15, 123456700, time.FixedZone("CET", 3600))
+	tests := []struct {
+		t         time.Time
+		precision api.TimePrecision
+		want      string
+	}{
+		{at, api.PrecisionMillisecond, "2024-03-01T10:30:15.123Z"},
+		{at, api.PrecisionNanosecond, "2024-03-01T10:30:15.1234567Z"},
+		{at.Truncate(time.Second), api.PrecisionMillisecond, "2024-03-01T10:30:15.000Z"},
+		{at.Truncate(time.Second), api.PrecisionNanosecond, "2024-03-01T10:30:15Z"},
+		{time.Time{}, api.PrecisionNanosecond, ""},
+	}
+	for _, tt := range tests {
+		if s := api.FormatPointTime(tt.t, tt.precision); s != tt.want {
+			t.Errorf("FormatPointTime(%s, %d) = %s, want %s", tt.t, tt.precision, s, tt.want)
+		}
+	}
+
+	for _, s := range []string{"2024-03-01T10:30:15Z", "2024-03-01T10:30:15.1Z", "2024-03-01T11:30:15.100+01:00", "2024-03-01T10:30:15.100000000Z"} {
+		parsed, err := api.ParsePointTime(s)
+		if err != nil {
+			t.Errorf("ParsePointTime(%s) = %v", s, err)
+			continue
+		}
+		if want := at.Truncate(time.Second); !parsed.Equal(want) && !parsed.Equal(want.Add(100*time.Millisecond)) {
+			t.Errorf("ParsePointTime(%s) = %s", s, parsed)
+		}
+	}
+	if _, err := api.ParsePointTime(""); !errors.Is(err, api.ErrNoTime) {
+		t.Errorf("ParsePointTime of empty time = %v, want ErrNoTime", err)
+	}
+	if _, err := api.ParsePointTime("yesterday"); err == nil {
+		t.Error("ParsePointTime of yesterday succeeded")
+	}
+
+	var event api.EventPoint
+	event.SetTimestamp(at, api.PrecisionNanosecond)
+	if ts, err := event.Timestamp(); err != nil || !ts.Equal(at) {
+		t.Errorf("event time = %s, %v, want %s", ts, err, at)
+	}
+	command := api.CommandPoint{}
+	command.SetTimestamp(at, api.PrecisionMillisecond)
+	if ts, err := command.Timestamp(); err != nil || !ts.Equal(at.Truncate(time.Millisecond)) {
+		t.Errorf("command time = %s, %v, want %s", ts, err, at.Truncate(time.Millisecond))
+	}
+	if _, err := (api.DataPoint{}).Timestamp(); !errors.Is(err, api.ErrNoTime) {
+		t.Errorf("time of point without time = %v, want ErrNoTime", err)
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
     "bytes"
+    "context"
     "encoding/json" 
     "fmt"   
     "net/http"
@@ -13,17 +14,27 @@ import (
 // https://tenant-name.cloudthing.io/api/v1/products
 type ProductsService interface {
     GetById(string, ...interface{}) (*Product, error)
+    GetByIdContext(context.Context, string, ...interface{}) (*Product, error)
     GetByLink(string, ...interface{}) (*Product, error)
+    GetByLinkContext(context.Context, string, ...interface{}) (*Product, error)
     List(...interface{}) ([]Product, *ListParams, error)
+    ListContext(context.Context, ...interface{}) ([]Product, *ListParams, error)
+    Iterate(context.Context, ...interface{}) *Iterator[Product]
+    ListByLink(string, ...interface{}) ([]Product, *ListParams, error)
+    ListByLinkContext(context.Context, string, ...interface{}) ([]Product, *ListParams, error)
+    IterateByLink(context.Context, string, ...interface{}) *Iterator[Product]
     Create(*ProductRequestCreate) (*Product, error)
+    CreateContext(context.Context, *ProductRequestCreate) (*Product, error)
     UpdateById(string, *ProductRequestUpdate) (*Product, error)
+    UpdateByIdContext(context.Context, string, *ProductRequestUpdate) (*Product, error)
     UpdateByLink(string, *ProductRequestUpdate) (*Product, error)
+    UpdateByLinkContext(context.Context, string, *ProductRequestUpdate) (*Product, error)
     Delete(*Product) (error)
+    DeleteContext(context.Context, *Product) (error)
     DeleteByLink(string) (error)
+    DeleteByLinkContext(context.Context, string) (error)
     DeleteById(string) (error)
-
-    get(*ProductResponse) (*Product, error)
-    getCollection(*ProductsResponse) ([]Product, *ListParams, error)
+    DeleteByIdContext(context.Context, string) (error)
 }
 
 // ProductsServiceOp handles communication with Products related methods of API
@@ -169,15 +180,27 @@ func (t *Product) Delete() error {
 
 // GetById retrieves product by its ID
 func (s *ProductsServiceOp) GetById(id string, args ...interface{}) (*Product, error) {
+    return s.GetByIdContext(context.Background(), id, args...)
+}
+
+// GetByIdContext is like GetById but uses ctx for the underlying request.
+func (s *ProductsServiceOp) GetByIdContext(ctx context.Context, id string, args ...interface{}) (*Product, error) {
+    ctx = withOperation(ctx, "Products", "GetById")
     endpoint := "products/"
     endpoint = fmt.Sprintf("%s%s", endpoint, id)
 
-    return s.GetByLink(endpoint, args...)
+    return s.GetByLinkContext(ctx, endpoint, args...)
 }
 
 // GetById retrieves product by its full link
 func (s *ProductsServiceOp) GetByLink(endpoint string, args ...interface{}) (*Product, error) {
-    resp, err := s.client.request("GET", endpoint, nil, args...)
+    return s.GetByLinkContext(context.Background(), endpoint, args...)
+}
+
+// GetByLinkContext is like GetByLink but uses ctx for the underlying request.
+func (s *ProductsServiceOp) GetByLinkContext(ctx context.Context, endpoint string, args ...interface{}) (*Product, error) {
+    ctx = withOperation(ctx, "Products", "GetByLink")
+    resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
     if err != nil {
         return nil, err
     }
@@ -185,7 +208,7 @@ func (s *ProductsServiceOp) GetByLink(endpoint string, args ...interface{}) (*Pr
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &ProductResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -211,25 +234,25 @@ func (s *ProductsServiceOp) get(r *ProductResponse) (*Product, error) {
         }
         ten := &TenantResponse{}
         json.Unmarshal(bytes, ten)
-        t, err := s.client.Tenant.get(ten)
+        t, err := s.client.conv.tenant.get(ten)
         if err != nil {
             return nil, err
         }
         obj.Tenant = t
     }
- /*   if len(r.Directory) > 1 {        
-        bytes, err := json.Marshal(r.Directory)
+    if len(r.Devices) > 1 {
+        bytes, err := json.Marshal(r.Devices)
         if err != nil {
             return nil, err
         }
-        ten := &DirectoryResponse{}
+        ten := &DevicesResponse{}
         json.Unmarshal(bytes, ten)
-        t, err := s.client.Directories.get(ten)
+        t, _, err := s.client.conv.devices.getCollection(ten)
         if err != nil {
             return nil, err
         }
-        obj.Directory = t
-    }*/
+        obj.Devices = t
+    }
     obj.service = s
     return obj, nil
 }
@@ -258,13 +281,32 @@ func (s *ProductsServiceOp) getCollection(r *ProductsResponse) ([]Product, *List
 
 // GetById retrieves collection of products of current tenant
 func (s *ProductsServiceOp) List(args ...interface{}) ([]Product, *ListParams, error) {
-    endpoint := fmt.Sprintf("tenants/%s/products", s.client.tenantId)
-    return s.ListByLink(endpoint, args...)
+    return s.ListContext(context.Background(), args...)
+}
+
+// ListContext is like List but uses ctx for the underlying request.
+func (s *ProductsServiceOp) ListContext(ctx context.Context, args ...interface{}) ([]Product, *ListParams, error) {
+    ctx = withOperation(ctx, "Products", "List")
+    endpoint := fmt.Sprintf("tenants/%s/products", s.client.currentTenantId(ctx))
+    return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// Iterate is like List but returns iterator walking over all pages
+func (s *ProductsServiceOp) Iterate(ctx context.Context, args ...interface{}) *Iterator[Product] {
+    ctx = withOperation(ctx, "Products", "Iterate")
+    endpoint := fmt.Sprintf("tenants/%s/products", s.client.currentTenantId(ctx))
+    return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of products by link
 func (s *ProductsServiceOp) ListByLink(endpoint string, args ...interface{}) ([]Product, *ListParams, error) {
-    resp, err := s.client.request("GET", endpoint, nil, args...)
+    return s.ListByLinkContext(context.Background(), endpoint, args...)
+}
+
+// ListByLinkContext is like ListByLink but uses ctx for the underlying request.
+func (s *ProductsServiceOp) ListByLinkContext(ctx context.Context, endpoint string, args ...interface{}) ([]Product, *ListParams, error) {
+    ctx = withOperation(ctx, "Products", "ListByLink")
+    resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
     if err != nil {
         return nil, nil, err
     }
@@ -272,7 +314,7 @@ func (s *ProductsServiceOp) ListByLink(endpoint string, args ...interface{}) ([]
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, nil, newApiError(resp, "non-ok status returned")
     }
     obj := &ProductsResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -281,14 +323,32 @@ func (s *ProductsServiceOp) ListByLink(endpoint string, args ...interface{}) ([]
     return s.getCollection(obj)
 }
 
+// IterateByLink is like ListByLink but returns iterator walking over all pages
+func (s *ProductsServiceOp) IterateByLink(ctx context.Context, endpoint string, args ...interface{}) *Iterator[Product] {
+    ctx = withOperation(ctx, "Products", "IterateByLink")
+    return newIterator(ctx, endpoint, s.ListByLinkContext, args...)
+}
+
 // GetById updates product with specified ID
 func (s *ProductsServiceOp) UpdateById(id string, t *ProductRequestUpdate) (*Product, error) {
+    return s.UpdateByIdContext(context.Background(), id, t)
+}
+
+// UpdateByIdContext is like UpdateById but uses ctx for the underlying request.
+func (s *ProductsServiceOp) UpdateByIdContext(ctx context.Context, id string, t *ProductRequestUpdate) (*Product, error) {
+    ctx = withOperation(ctx, "Products", "UpdateById")
     endpoint := fmt.Sprintf("products/%s", id)
-    return s.UpdateByLink(endpoint, t)
+    return s.UpdateByLinkContext(ctx, endpoint, t)
 }
 
 // GetById updates product specified by link
 func (s *ProductsServiceOp) UpdateByLink(endpoint string, t *ProductRequestUpdate) (*Product, error) {
+    return s.UpdateByLinkContext(context.Background(), endpoint, t)
+}
+
+// UpdateByLinkContext is like UpdateByLink but uses ctx for the underlying request.
+func (s *ProductsServiceOp) UpdateByLinkContext(ctx context.Context, endpoint string, t *ProductRequestUpdate) (*Product, error) {
+    ctx = withOperation(ctx, "Products", "UpdateByLink")
     enc, err := json.Marshal(t)
     if err != nil {
         return nil, err
@@ -296,7 +356,7 @@ func (s *ProductsServiceOp) UpdateByLink(endpoint string, t *ProductRequestUpdat
 
     buf := bytes.NewBuffer(enc)
 
-    resp, err := s.client.request("POST", endpoint, buf)
+    resp, err := s.client.request(ctx, "POST", endpoint, buf)
     if err != nil {
         return nil, err
     }
@@ -304,7 +364,7 @@ func (s *ProductsServiceOp) UpdateByLink(endpoint string, t *ProductRequestUpdat
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusOK {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &ProductResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -314,7 +374,13 @@ func (s *ProductsServiceOp) UpdateByLink(endpoint string, t *ProductRequestUpdat
 
 // Create creates new product within tenant
 func (s *ProductsServiceOp) Create(dir *ProductRequestCreate) (*Product, error) {
-    endpoint := fmt.Sprintf("tenants/%s/products", s.client.tenantId)
+    return s.CreateContext(context.Background(), dir)
+}
+
+// CreateContext is like Create but uses ctx for the underlying request.
+func (s *ProductsServiceOp) CreateContext(ctx context.Context, dir *ProductRequestCreate) (*Product, error) {
+    ctx = withOperation(ctx, "Products", "Create")
+    endpoint := fmt.Sprintf("tenants/%s/products", s.client.currentTenantId(ctx))
 
     enc, err := json.Marshal(dir)
     if err != nil {
@@ -323,7 +389,7 @@ func (s *ProductsServiceOp) Create(dir *ProductRequestCreate) (*Product, error)
 
     buf := bytes.NewBuffer(enc)
 
-    resp, err := s.client.request("POST", endpoint, buf)
+    resp, err := s.client.request(ctx, "POST", endpoint, buf)
     if err != nil {
         return nil, err
     }
@@ -331,7 +397,7 @@ func (s *ProductsServiceOp) Create(dir *ProductRequestCreate) (*Product, error)
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusCreated {
-        return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return nil, newApiError(resp, "non-ok status returned")
     }
     obj := &ProductResponse{}
     dec := json.NewDecoder(resp.Body)
@@ -341,18 +407,36 @@ func (s *ProductsServiceOp) Create(dir *ProductRequestCreate) (*Product, error)
 
 // Delete removes product
 func (s *ProductsServiceOp) Delete(t *Product) (error) {
-    return s.DeleteByLink(t.Href)
+    return s.DeleteContext(context.Background(), t)
+}
+
+// DeleteContext is like Delete but uses ctx for the underlying request.
+func (s *ProductsServiceOp) DeleteContext(ctx context.Context, t *Product) (error) {
+    ctx = withOperation(ctx, "Products", "Delete")
+    return s.DeleteByLinkContext(ctx, t.Href)
 }
 
 // Delete removes product by ID
 func (s *ProductsServiceOp) DeleteById(id string) (error) {
+    return s.DeleteByIdContext(context.Background(), id)
+}
+
+// DeleteByIdContext is like DeleteById but uses ctx for the underlying request.
+func (s *ProductsServiceOp) DeleteByIdContext(ctx context.Context, id string) (error) {
+    ctx = withOperation(ctx, "Products", "DeleteById")
     endpoint := fmt.Sprintf("products/%s", id)
-    return s.DeleteByLink(endpoint)
+    return s.DeleteByLinkContext(ctx, endpoint)
 }
 
 // Delete removes product by link
 func (s *ProductsServiceOp) DeleteByLink(endpoint string) (error) {
-    resp, err := s.client.request("DELETE", endpoint, nil)
+    return s.DeleteByLinkContext(context.Background(), endpoint)
+}
+
+// DeleteByLinkContext is like DeleteByLink but uses ctx for the underlying request.
+func (s *ProductsServiceOp) DeleteByLinkContext(ctx context.Context, endpoint string) (error) {
+    ctx = withOperation(ctx, "Products", "DeleteByLink")
+    resp, err := s.client.request(ctx, "DELETE", endpoint, nil)
     if err != nil {
         return err
     }
@@ -360,7 +444,7 @@ func (s *ProductsServiceOp) DeleteByLink(endpoint string) (error) {
     defer resp.Body.Close()
 
     if resp.StatusCode != http.StatusNoContent {
-        return ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+        return newApiError(resp, "non-ok status returned")
     }
     return nil
 }
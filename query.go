@@ -0,0 +1,172 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Filter operators supported by CloudThing API
+const (
+	OpEq     = "eq"
+	OpNe     = "ne"
+	OpGt     = "gt"
+	OpGte    = "gte"
+	OpLt     = "lt"
+	OpLte    = "lte"
+	OpPrefix = "prefix"
+)
+
+// Filter is a single condition on resource's attribute or custom key
+type Filter struct {
+	Field string
+	Op    string
+	Value interface{}
+}
+
+// Query allows server-side filtering, sorting and selecting fields of resources.
+// It can be passed to any Get* or List* method, e.g.
+//
+//	q := api.NewQuery().Where(api.Eq(api.Custom("site"), "X")).OrderBy("createdAt").Fields("href", "token")
+//	devices, _, err := client.Devices.ListByProduct(id, q)
+type Query struct {
+	filters []Filter
+	sort    []string
+	fields  []string
+}
+
+// NewQuery creates empty query
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// Custom returns name of field referring to key of resource's Custom data
+func Custom(key string) string {
+	return "custom." + key
+}
+
+// Eq matches resources with field equal to value
+func Eq(field string, value interface{}) Filter {
+	return Filter{Field: field, Op: OpEq, Value: value}
+}
+
+// Ne matches resources with field not equal to value
+func Ne(field string, value interface{}) Filter {
+	return Filter{Field: field, Op: OpNe, Value: value}
+}
+
+// Gt matches resources with field greater than value
+func Gt(field string, value interface{}) Filter {
+	return Filter{Field: field, Op: OpGt, Value: value}
+}
+
+// Gte matches resources with field greater than or equal to value
+func Gte(field string, value interface{}) Filter {
+	return Filter{Field: field, Op: OpGte, Value: value}
+}
+
+// Lt matches resources with field less than value
+func Lt(field string, value interface{}) Filter {
+	return Filter{Field: field, Op: OpLt, Value: value}
+}
+
+// Lte matches resources with field less than or equal to value
+func Lte(field string, value interface{}) Filter {
+	return Filter{Field: field, Op: OpLte, Value: value}
+}
+
+// Prefix matches resources with string field starting with prefix
+func Prefix(field string, prefix string) Filter {
+	return Filter{Field: field, Op: OpPrefix, Value: prefix}
+}
+
+// Where adds filters, resource has to match all of them
+func (q *Query) Where(filters ...Filter) *Query {
+	q.filters = append(q.filters, filters...)
+	return q
+}
+
+// Between adds filters matching field within range [from, to]
+func (q *Query) Between(field string, from, to interface{}) *Query {
+	return q.Where(Gte(field, from), Lte(field, to))
+}
+
+// OrderBy sorts resources by field in ascending order
+func (q *Query) OrderBy(field string) *Query {
+	q.sort = append(q.sort, field)
+	return q
+}
+
+// OrderByDesc sorts resources by field in descending order
+func (q *Query) OrderByDesc(field string) *Query {
+	q.sort = append(q.sort, "-"+field)
+	return q
+}
+
+// Fields limits returned attributes of resources to fields
+func (q *Query) Fields(fields ...string) *Query {
+	q.fields = append(q.fields, fields...)
+	return q
+}
+
+// Validate checks whether query may be sent to API
+func (q *Query) Validate() error {
+	for _, f := range q.filters {
+		if f.Field == "" {
+			return fmt.Errorf("filter without field")
+		}
+		if t, ok := f.Value.(*time.Time); ok && t == nil {
+			return fmt.Errorf("nil time in filter on %s", f.Field)
+		}
+		switch f.Op {
+		case OpEq, OpNe, OpGt, OpGte, OpLt, OpLte:
+		case OpPrefix:
+			if _, ok := f.Value.(string); !ok {
+				return fmt.Errorf("prefix filter on %s requires string value, got %T", f.Field, f.Value)
+			}
+		default:
+			return fmt.Errorf("unknown operator %q of filter on %s", f.Op, f.Field)
+		}
+	}
+	for _, s := range append(q.sort, q.fields...) {
+		if strings.TrimPrefix(s, "-") == "" {
+			return fmt.Errorf("empty field name in query")
+		}
+	}
+	return nil
+}
+
+func (q Query) String() string {
+	params := make([]string, 0, len(q.filters)+2)
+	for _, f := range q.filters {
+		key := url.QueryEscape(f.Field)
+		if f.Op != OpEq {
+			key = fmt.Sprintf("%s[%s]", key, f.Op)
+		}
+		params = append(params, fmt.Sprintf("%s=%s", key, url.QueryEscape(filterValue(f.Value))))
+	}
+	if len(q.sort) > 0 {
+		params = append(params, "sort="+url.QueryEscape(strings.Join(q.sort, ",")))
+	}
+	if len(q.fields) > 0 {
+		params = append(params, "fields="+url.QueryEscape(strings.Join(q.fields, ",")))
+	}
+	return strings.Join(params, "&")
+}
+
+// filterValue formats value of filter for query string
+func filterValue(v interface{}) string {
+	switch t := v.(type) {
+	case time.Time:
+		return t.UTC().Format(time.RFC3339Nano)
+	case *time.Time:
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339Nano)
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,94 @@
+package api_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+)
+
+func TestQueryString(t *testing.T) {
+	at := time.Date(2024, 1, 2, 4, 4, 5, 0, time.FixedZone("CET", 3600))
+	q := api.NewQuery().
+		Where(api.Eq(api.Custom("site"), "X & Y"), api.Gt("createdAt", at), api.Prefix("name", "La")).
+		Between("custom.level", 1, &at).
+		OrderBy("name").OrderByDesc("createdAt").
+		Fields("href", "name")
+	want := "custom.site=X+%26+Y" +
+		"&createdAt[gt]=2024-01-02T03%3A04%3A05Z" +
+		"&name[prefix]=La" +
+		"&custom.level[gte]=1" +
+		"&custom.level[lte]=2024-01-02T03%3A04%3A05Z" +
+		"&sort=name%2C-createdAt" +
+		"&fields=href%2Cname"
+	if s := q.String(); s != want {
+		t.Errorf("String() = %s, want %s", s, want)
+	}
+	if s := api.NewQuery().String(); s != "" {
+		t.Errorf("String() of empty query = %q", s)
+	}
+	if err := q.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestQueryValidate(t *testing.T) {
+	var nilTime *time.Time
+	tests := map[string]*api.Query{
+		"no field":    api.NewQuery().Where(api.Eq("", 1)),
+		"unknown op":  api.NewQuery().Where(api.Filter{Field: "name", Op: "like", Value: "La"}),
+		"prefix":      api.NewQuery().Where(api.Filter{Field: "name", Op: api.OpPrefix, Value: 1}),
+		"nil time":    api.NewQuery().Where(api.Lt("createdAt", nilTime)),
+		"empty sort":  api.NewQuery().OrderByDesc(""),
+		"empty field": api.NewQuery().Fields("href", ""),
+	}
+	for name, q := range tests {
+		if err := q.Validate(); err == nil {
+			t.Errorf("%s: Validate() = nil", name)
+		}
+	}
+	// rendering doesn't panic even if query wasn't validated
+	if s := tests["nil time"].String(); s != "createdAt[lt]=" {
+		t.Errorf("String() with nil time = %q", s)
+	}
+}
+
+func TestQueryRequest(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	product := srv.Add("products", map[string]interface{}{"name": "Lamp"}, nil)
+	for _, site := range []string{"A", "B", "A"} {
+		srv.Add("devices", map[string]interface{}{"custom": map[string]interface{}{"site": site}}, map[string]string{"product": "products/" + product})
+	}
+
+	devices, _, err := client.Devices.ListByProduct(product, api.NewQuery().Where(api.Eq(api.Custom("site"), "A")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 2 {
+		t.Errorf("%d devices of site A, want 2", len(devices))
+	}
+	reqs := srv.Requests()
+	if v := reqs[len(reqs)-1].Query.Get("custom.site"); v != "A" {
+		t.Errorf("custom.site = %q, want A", v)
+	}
+
+	// invalid queries and unsupported options fail before anything is sent
+	sent := len(srv.Requests())
+	if _, _, err := client.Devices.ListByProduct(product, api.NewQuery().Where(api.Eq("", "A"))); err == nil {
+		t.Error("invalid query accepted")
+	}
+	_, _, err = client.Devices.ListByProduct(product, 42)
+	if err == nil || !strings.Contains(err.Error(), "unsupported request option of type int") {
+		t.Errorf("request with int option = %v, want unsupported option", err)
+	}
+	if n := len(srv.Requests()); n != sent {
+		t.Errorf("%d requests with invalid options sent", n-sent)
+	}
+}
@@ -0,0 +1,253 @@
+package api
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Endpoint families with separate rate limit budgets
+const (
+	// Data, events and commands of devices and clusters
+	FamilyResources = "resources"
+	// All other endpoints, e.g. devices, users or products management
+	FamilyManagement = "management"
+)
+
+// RateLimit describes budget of a token bucket
+type RateLimit struct {
+	// Sustained number of requests per second, zero means no limit
+	Rate float64
+	// Maximum number of requests sent at once
+	Burst int
+}
+
+// RateLimiter is a client-side token bucket limiter shared by all services of Client.
+// Read (GET) and write calls have separate budgets within each endpoint family.
+// Budgets are adapted to X-RateLimit-* and Retry-After headers sent by API.
+type RateLimiter struct {
+	mu      sync.Mutex
+	read    RateLimit
+	write   RateLimit
+	limits  map[string][2]RateLimit
+	buckets map[string]*tokenBucket
+}
+
+// NewRateLimiter creates limiter using read and write budgets for every family
+func NewRateLimiter(read, write RateLimit) *RateLimiter {
+	return &RateLimiter{
+		read:    read,
+		write:   write,
+		limits:  make(map[string][2]RateLimit),
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// SetFamilyLimits overrides read and write budgets for family of endpoints
+func (l *RateLimiter) SetFamilyLimits(family string, read, write RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limits[family] = [2]RateLimit{read, write}
+	delete(l.buckets, bucketKey(family, true))
+	delete(l.buckets, bucketKey(family, false))
+}
+
+// Wait blocks until request may be sent or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context, method, path string) error {
+	if l == nil {
+		return nil
+	}
+	return l.bucket(method, path).wait(ctx)
+}
+
+// Update adapts budget of request's bucket to rate limit headers of response
+func (l *RateLimiter) Update(method, path string, resp *http.Response) {
+	if l == nil || resp == nil {
+		return
+	}
+	b := l.bucket(method, path)
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if d, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			b.pause(time.Now().Add(d))
+		}
+	}
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, ok := rateLimitReset(resp.Header.Get("X-RateLimit-Reset"))
+	if !ok {
+		return
+	}
+	b.adapt(remaining, reset)
+}
+
+// bucket returns token bucket for request, creating it if necessary
+func (l *RateLimiter) bucket(method, path string) *tokenBucket {
+	family := endpointFamily(path)
+	read := method == "GET" || method == "HEAD"
+	key := bucketKey(family, read)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if b, ok := l.buckets[key]; ok {
+		return b
+	}
+	limit := l.write
+	if read {
+		limit = l.read
+	}
+	if v, ok := l.limits[family]; ok {
+		limit = v[1]
+		if read {
+			limit = v[0]
+		}
+	}
+	b := newTokenBucket(limit)
+	l.buckets[key] = b
+	return b
+}
+
+// SetRateLimiter sets limiter used for all requests, nil disables limiting
+func (c *Client) SetRateLimiter(l *RateLimiter) {
+	c.rateLimiter = l
+}
+
+func bucketKey(family string, read bool) string {
+	if read {
+		return family + ":read"
+	}
+	return family + ":write"
+}
+
+// endpointFamily classifies request path into one of endpoint families
+func endpointFamily(path string) string {
+	if strings.Contains(path, "/resources") {
+		return FamilyResources
+	}
+	return FamilyManagement
+}
+
+// rateLimitReset parses X-RateLimit-Reset header, given either as unix timestamp
+// or as number of seconds until reset
+func rateLimitReset(v string) (time.Time, bool) {
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < 0 {
+		return time.Time{}, false
+	}
+	// values this large can't be a delay, so they are timestamps
+	if n > 1000000000 {
+		return time.Unix(n, 0), true
+	}
+	return time.Now().Add(time.Duration(n) * time.Second), true
+}
+
+// tokenBucket is a single budget of requests
+type tokenBucket struct {
+	mu     sync.Mutex
+	limit  RateLimit
+	rate   float64
+	tokens float64
+	last   time.Time
+	// until reset rate is lowered to what API allows
+	reset time.Time
+	// no requests are allowed until paused time
+	paused time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{limit: limit, rate: limit.Rate, tokens: burst, last: time.Now()}
+}
+
+// refill adds tokens accumulated since last call, b.mu must be held
+func (b *tokenBucket) refill(now time.Time) {
+	if !b.reset.IsZero() && now.After(b.reset) {
+		b.rate = b.limit.Rate
+		b.reset = time.Time{}
+	}
+	burst := math.Max(float64(b.limit.Burst), 1)
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+// reserve takes a token and returns how long caller must wait before using it
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	var wait time.Duration
+	if now.Before(b.paused) {
+		wait = b.paused.Sub(now)
+	}
+	if b.limit.Rate <= 0 {
+		return wait
+	}
+	b.refill(now)
+	b.tokens--
+	if b.tokens < 0 && b.rate > 0 {
+		if d := time.Duration(-b.tokens / b.rate * float64(time.Second)); d > wait {
+			wait = d
+		}
+	}
+	return wait
+}
+
+func (b *tokenBucket) wait(ctx context.Context) error {
+	d := b.reserve()
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// pause stops sending requests until t
+func (b *tokenBucket) pause(t time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if t.After(b.paused) {
+		b.paused = t
+	}
+}
+
+// adapt lowers budget to remaining number of requests API allows until reset
+func (b *tokenBucket) adapt(remaining int, reset time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if remaining <= 0 {
+		if reset.After(b.paused) {
+			b.paused = reset
+		}
+		return
+	}
+	if b.limit.Rate <= 0 {
+		return
+	}
+	b.refill(now)
+	if float64(remaining) < b.tokens {
+		b.tokens = float64(remaining)
+	}
+	if until := reset.Sub(now).Seconds(); until > 0 {
+		if rate := float64(remaining) / until; rate < b.limit.Rate {
+			b.rate = rate
+			b.reset = reset
+		}
+	}
+}
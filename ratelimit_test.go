@@ -0,0 +1,102 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+)
+
+// waitTook returns how long Wait of l blocked
+func waitTook(t *testing.T, l *api.RateLimiter, method, path string) time.Duration {
+	t.Helper()
+	start := time.Now()
+	if err := l.Wait(context.Background(), method, path); err != nil {
+		t.Fatal(err)
+	}
+	return time.Since(start)
+}
+
+func TestRateLimiterBurst(t *testing.T) {
+	l := api.NewRateLimiter(api.RateLimit{Rate: 10, Burst: 2}, api.RateLimit{})
+	for i := 0; i < 2; i++ {
+		if d := waitTook(t, l, "GET", "devices/1"); d > 20*time.Millisecond {
+			t.Errorf("request %d within burst waited %s", i+1, d)
+		}
+	}
+	if d := waitTook(t, l, "GET", "devices/1"); d < 70*time.Millisecond {
+		t.Errorf("request over burst waited %s, want about 100ms", d)
+	}
+}
+
+func TestRateLimiterBudgets(t *testing.T) {
+	l := api.NewRateLimiter(api.RateLimit{Rate: 0.1, Burst: 1}, api.RateLimit{Rate: 0.1, Burst: 1})
+	l.SetFamilyLimits(api.FamilyResources, api.RateLimit{}, api.RateLimit{Rate: 0.1, Burst: 1})
+
+	// every request uses up the only token of separate bucket
+	for _, r := range []struct{ method, path string }{
+		{"GET", "devices/1"},
+		{"POST", "devices"},
+		{"GET", "devices/1/resources/data"},
+		{"GET", "devices/1/resources/data"},
+		{"POST", "devices/1/resources/data"},
+	} {
+		if d := waitTook(t, l, r.method, r.path); d > 20*time.Millisecond {
+			t.Errorf("%s %s waited %s", r.method, r.path, d)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, "GET", "products/1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait for exhausted budget = %v, want deadline exceeded", err)
+	}
+}
+
+func TestRateLimiterUpdate(t *testing.T) {
+	l := api.NewRateLimiter(api.RateLimit{}, api.RateLimit{})
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"1"}}}
+	l.Update("GET", "devices", resp)
+	if d := waitTook(t, l, "GET", "devices/1"); d < 900*time.Millisecond {
+		t.Errorf("request after Retry-After waited %s, want 1s", d)
+	}
+	if d := waitTook(t, l, "POST", "devices"); d > 20*time.Millisecond {
+		t.Errorf("write waited %s after reads were paused", d)
+	}
+
+	resp = &http.Response{StatusCode: http.StatusOK, Header: http.Header{
+		"X-Ratelimit-Remaining": {"0"},
+		"X-Ratelimit-Reset":     {"1"},
+	}}
+	l.Update("POST", "devices", resp)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, "POST", "devices"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait with no remaining requests = %v, want deadline exceeded", err)
+	}
+}
+
+func TestRateLimiterClient(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	l := api.NewRateLimiter(api.RateLimit{Rate: 20, Burst: 1}, api.RateLimit{})
+	client, err := srv.NewClient(api.WithRateLimiter(l))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := srv.Add("devices", map[string]interface{}{}, nil)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if _, err := client.Devices.GetById(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := time.Since(start); d < 140*time.Millisecond {
+		t.Errorf("4 requests at 20/s took %s, want at least 150ms", d)
+	}
+}
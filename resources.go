@@ -2,10 +2,11 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"time"
+	"net/url"
 
 	"gitlab.com/cloudthing/structures"
 )
@@ -14,23 +15,81 @@ import (
 // https://tenant-name.cloudthing.io/api/v1/model/id/resources
 type ResourcesService interface {
 	GetDataByDeviceID(string, ...interface{}) ([]DataPoint, *ListParams, error)
+	GetDataByDeviceIDContext(context.Context, string, ...interface{}) ([]DataPoint, *ListParams, error)
 	GetEventsByDeviceID(string, ...interface{}) ([]EventPoint, *ListParams, error)
+	GetEventsByDeviceIDContext(context.Context, string, ...interface{}) ([]EventPoint, *ListParams, error)
 	GetCommandsByDeviceID(string, ...interface{}) ([]CommandPoint, *ListParams, error)
+	GetCommandsByDeviceIDContext(context.Context, string, ...interface{}) ([]CommandPoint, *ListParams, error)
 	WriteDataForDeviceID(string, []DataPoint) ([]DataPoint, error)
+	WriteDataForDeviceIDContext(context.Context, string, []DataPoint) ([]DataPoint, error)
 	WriteEventsForDeviceID(string, []EventPoint) ([]EventPoint, error)
+	WriteEventsForDeviceIDContext(context.Context, string, []EventPoint) ([]EventPoint, error)
 	WriteCommandsForDeviceID(string, []CommandPoint) ([]CommandPoint, error)
+	WriteCommandsForDeviceIDContext(context.Context, string, []CommandPoint) ([]CommandPoint, error)
 	GetDataByClusterID(string, ...interface{}) ([]DataPoint, *ListParams, error)
+	GetDataByClusterIDContext(context.Context, string, ...interface{}) ([]DataPoint, *ListParams, error)
 	GetEventsByClusterID(string, ...interface{}) ([]EventPoint, *ListParams, error)
+	GetEventsByClusterIDContext(context.Context, string, ...interface{}) ([]EventPoint, *ListParams, error)
 	GetCommandsByClusterID(string, ...interface{}) ([]CommandPoint, *ListParams, error)
+	GetCommandsByClusterIDContext(context.Context, string, ...interface{}) ([]CommandPoint, *ListParams, error)
 	WriteDataForClusterID(string, []DataPoint) ([]DataPoint, error)
+	WriteDataForClusterIDContext(context.Context, string, []DataPoint) ([]DataPoint, error)
 	WriteEventsForClusterID(string, []EventPoint) ([]EventPoint, error)
+	WriteEventsForClusterIDContext(context.Context, string, []EventPoint) ([]EventPoint, error)
 	WriteCommandsForClusterID(string, []CommandPoint) ([]CommandPoint, error)
+	WriteCommandsForClusterIDContext(context.Context, string, []CommandPoint) ([]CommandPoint, error)
 	GetDataByLink(string, ...interface{}) ([]DataPoint, *ListParams, error)
+	GetDataByLinkContext(context.Context, string, ...interface{}) ([]DataPoint, *ListParams, error)
 	GetEventsByLink(string, ...interface{}) ([]EventPoint, *ListParams, error)
+	GetEventsByLinkContext(context.Context, string, ...interface{}) ([]EventPoint, *ListParams, error)
 	GetCommandsByLink(string, ...interface{}) ([]CommandPoint, *ListParams, error)
+	GetCommandsByLinkContext(context.Context, string, ...interface{}) ([]CommandPoint, *ListParams, error)
 	WriteDataForLink(string, []DataPoint) ([]DataPoint, error)
+	WriteDataForLinkContext(context.Context, string, []DataPoint) ([]DataPoint, error)
 	WriteEventsForLink(string, []EventPoint) ([]EventPoint, error)
+	WriteEventsForLinkContext(context.Context, string, []EventPoint) ([]EventPoint, error)
 	WriteCommandsForLink(string, []CommandPoint) ([]CommandPoint, error)
+	WriteCommandsForLinkContext(context.Context, string, []CommandPoint) ([]CommandPoint, error)
+	StreamDataByDeviceID(context.Context, string, ...interface{}) *Stream[DataPoint]
+	StreamDataByClusterID(context.Context, string, ...interface{}) *Stream[DataPoint]
+	StreamDataByLink(context.Context, string, ...interface{}) *Stream[DataPoint]
+	StreamEventsByDeviceID(context.Context, string, ...interface{}) *Stream[EventPoint]
+	StreamEventsByClusterID(context.Context, string, ...interface{}) *Stream[EventPoint]
+	StreamEventsByLink(context.Context, string, ...interface{}) *Stream[EventPoint]
+	StreamCommandsByDeviceID(context.Context, string, ...interface{}) *Stream[CommandPoint]
+	StreamCommandsByClusterID(context.Context, string, ...interface{}) *Stream[CommandPoint]
+	StreamCommandsByLink(context.Context, string, ...interface{}) *Stream[CommandPoint]
+	GetDataByDeviceKey(string, string, ...interface{}) ([]DataPoint, *ListParams, error)
+	GetDataByDeviceKeyContext(context.Context, string, string, ...interface{}) ([]DataPoint, *ListParams, error)
+	GetEventsByDeviceKey(string, string, ...interface{}) ([]EventPoint, *ListParams, error)
+	GetEventsByDeviceKeyContext(context.Context, string, string, ...interface{}) ([]EventPoint, *ListParams, error)
+	GetCommandsByDeviceKey(string, string, ...interface{}) ([]CommandPoint, *ListParams, error)
+	GetCommandsByDeviceKeyContext(context.Context, string, string, ...interface{}) ([]CommandPoint, *ListParams, error)
+	GetDataByClusterKey(string, string, ...interface{}) ([]DataPoint, *ListParams, error)
+	GetDataByClusterKeyContext(context.Context, string, string, ...interface{}) ([]DataPoint, *ListParams, error)
+	GetEventsByClusterKey(string, string, ...interface{}) ([]EventPoint, *ListParams, error)
+	GetEventsByClusterKeyContext(context.Context, string, string, ...interface{}) ([]EventPoint, *ListParams, error)
+	GetCommandsByClusterKey(string, string, ...interface{}) ([]CommandPoint, *ListParams, error)
+	GetCommandsByClusterKeyContext(context.Context, string, string, ...interface{}) ([]CommandPoint, *ListParams, error)
+	GetDataByDeviceKeys(string, []string, ...interface{}) (map[string][]DataPoint, error)
+	GetDataByDeviceKeysContext(context.Context, string, []string, ...interface{}) (map[string][]DataPoint, error)
+	GetEventsByDeviceKeys(string, []string, ...interface{}) (map[string][]EventPoint, error)
+	GetEventsByDeviceKeysContext(context.Context, string, []string, ...interface{}) (map[string][]EventPoint, error)
+	GetCommandsByDeviceKeys(string, []string, ...interface{}) (map[string][]CommandPoint, error)
+	GetCommandsByDeviceKeysContext(context.Context, string, []string, ...interface{}) (map[string][]CommandPoint, error)
+	GetDataByClusterKeys(string, []string, ...interface{}) (map[string][]DataPoint, error)
+	GetDataByClusterKeysContext(context.Context, string, []string, ...interface{}) (map[string][]DataPoint, error)
+	GetEventsByClusterKeys(string, []string, ...interface{}) (map[string][]EventPoint, error)
+	GetEventsByClusterKeysContext(context.Context, string, []string, ...interface{}) (map[string][]EventPoint, error)
+	GetCommandsByClusterKeys(string, []string, ...interface{}) (map[string][]CommandPoint, error)
+	GetCommandsByClusterKeysContext(context.Context, string, []string, ...interface{}) (map[string][]CommandPoint, error)
+	AggregateDataByDeviceID(string, *Aggregation, ...interface{}) (map[string][]Bucket, error)
+	AggregateDataByDeviceIDContext(context.Context, string, *Aggregation, ...interface{}) (map[string][]Bucket, error)
+	AggregateDataByClusterID(string, *Aggregation, ...interface{}) (map[string][]Bucket, error)
+	AggregateDataByClusterIDContext(context.Context, string, *Aggregation, ...interface{}) (map[string][]Bucket, error)
+	AggregateDataByLink(string, *Aggregation, ...interface{}) (map[string][]Bucket, error)
+	AggregateDataByLinkContext(context.Context, string, *Aggregation, ...interface{}) (map[string][]Bucket, error)
+	Subscribe(context.Context, string, ...ResourceKind) (*Subscription, error)
 }
 
 // ResourcesServiceOp handles communication with Resources related methods of API
@@ -57,10 +116,68 @@ type EventPoint struct {
 // CommandPoint is a single timeseries point with associated time and added payload. Used in commands
 type CommandPoint EventPoint
 
-// TimeParams allows to add 'start' and 'end' query parameters
-type TimeParams struct {
-	Start *time.Time
-	End   *time.Time
+// PointsOrder is order of points in time series
+type PointsOrder int
+
+const (
+	// Order chosen by CloudThing
+	OrderDefault PointsOrder = iota
+	// Oldest points first
+	OrderAscending
+	// Newest points first
+	OrderDescending
+)
+
+// PointsOptions sets order of points returned by resources requests and allows
+// requesting only the latest point. It is passed with filters, e.g.
+//
+//	points, _, err := client.Resources.GetDataByDeviceKey(id, "temperature", &api.PointsOptions{Latest: true})
+type PointsOptions struct {
+	Order PointsOrder
+	// Only the newest point is returned, Order and ListOptions are ignored
+	Latest bool
+}
+
+// latestRequested checks whether args request only the latest point
+func latestRequested(args []interface{}) bool {
+	for _, a := range args {
+		if p, ok := a.(*PointsOptions); ok && p != nil && p.Latest {
+			return true
+		}
+	}
+	return false
+}
+
+// pointsOptions merges PointsOptions and order of TimeParams in args into single
+// options, so order of points is sent once. PointsOptions take precedence.
+func pointsOptions(args []interface{}) *PointsOptions {
+	res := &PointsOptions{}
+	for _, a := range args {
+		if t, ok := a.(*TimeParams); ok && t != nil && t.Order != OrderDefault {
+			res.Order = t.Order
+		}
+	}
+	for _, a := range args {
+		if p, ok := a.(*PointsOptions); ok && p != nil {
+			if p.Order != OrderDefault {
+				res.Order = p.Order
+			}
+			res.Latest = res.Latest || p.Latest
+		}
+	}
+	return res
+}
+
+func (p *PointsOptions) String() string {
+	switch {
+	case p.Latest:
+		return "sort=-time&limit=1"
+	case p.Order == OrderAscending:
+		return "sort=time"
+	case p.Order == OrderDescending:
+		return "sort=-time"
+	}
+	return ""
 }
 
 // DataResponse is a response from CloudThing when calling for data resource
@@ -81,98 +198,172 @@ type CommandsResponse struct {
 	Items []CommandPoint `json:"items"`
 }
 
-func (t *TimeParams) String() string {
-	return fmt.Sprintf("start=%s&end=%s", t.Start.UTC().Format(time.RFC3339), t.End.UTC().Format(time.RFC3339))
-}
-
 // GetDataByDeviceID requests from CloudThing device's data with set filters
 func (s *ResourcesServiceOp) GetDataByDeviceID(deviceID string, filters ...interface{}) ([]DataPoint, *ListParams, error) {
-	endpoint := fmt.Sprintf("/api/v1/devices/%s/resources/data", deviceID)
+	return s.GetDataByDeviceIDContext(context.Background(), deviceID, filters...)
+}
+
+// GetDataByDeviceIDContext is like GetDataByDeviceID but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) GetDataByDeviceIDContext(ctx context.Context, deviceID string, filters ...interface{}) ([]DataPoint, *ListParams, error) {
+	ctx = withOperation(ctx, "Resources", "GetDataByDeviceID")
+	endpoint := fmt.Sprintf("devices/%s/resources/data", deviceID)
 
-	return s.GetDataByLink(endpoint, filters...)
+	return s.GetDataByLinkContext(ctx, endpoint, filters...)
 }
 
 // GetEventsByDeviceID requests from CloudThing device's events with set filters
 func (s *ResourcesServiceOp) GetEventsByDeviceID(deviceID string, filters ...interface{}) ([]EventPoint, *ListParams, error) {
-	endpoint := fmt.Sprintf("/api/v1/devices/%s/resources/events", deviceID)
+	return s.GetEventsByDeviceIDContext(context.Background(), deviceID, filters...)
+}
+
+// GetEventsByDeviceIDContext is like GetEventsByDeviceID but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) GetEventsByDeviceIDContext(ctx context.Context, deviceID string, filters ...interface{}) ([]EventPoint, *ListParams, error) {
+	ctx = withOperation(ctx, "Resources", "GetEventsByDeviceID")
+	endpoint := fmt.Sprintf("devices/%s/resources/events", deviceID)
 
-	return s.GetEventsByLink(endpoint, filters...)
+	return s.GetEventsByLinkContext(ctx, endpoint, filters...)
 }
 
 // GetCommandsByDeviceID requests from CloudThing device's commands with set filters
 func (s *ResourcesServiceOp) GetCommandsByDeviceID(deviceID string, filters ...interface{}) ([]CommandPoint, *ListParams, error) {
-	endpoint := fmt.Sprintf("/api/v1/devices/%s/resources/commands", deviceID)
+	return s.GetCommandsByDeviceIDContext(context.Background(), deviceID, filters...)
+}
+
+// GetCommandsByDeviceIDContext is like GetCommandsByDeviceID but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) GetCommandsByDeviceIDContext(ctx context.Context, deviceID string, filters ...interface{}) ([]CommandPoint, *ListParams, error) {
+	ctx = withOperation(ctx, "Resources", "GetCommandsByDeviceID")
+	endpoint := fmt.Sprintf("devices/%s/resources/commands", deviceID)
 
-	return s.GetCommandsByLink(endpoint, filters...)
+	return s.GetCommandsByLinkContext(ctx, endpoint, filters...)
 }
 
 // WriteDataForDeviceID sends data to CloudThing device's for saving
 func (s *ResourcesServiceOp) WriteDataForDeviceID(deviceID string, points []DataPoint) ([]DataPoint, error) {
-	endpoint := fmt.Sprintf("/api/v1/devices/%s/resources/data", deviceID)
+	return s.WriteDataForDeviceIDContext(context.Background(), deviceID, points)
+}
 
-	return s.WriteDataForLink(endpoint, points)
+// WriteDataForDeviceIDContext is like WriteDataForDeviceID but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) WriteDataForDeviceIDContext(ctx context.Context, deviceID string, points []DataPoint) ([]DataPoint, error) {
+	ctx = withOperation(ctx, "Resources", "WriteDataForDeviceID")
+	endpoint := fmt.Sprintf("devices/%s/resources/data", deviceID)
+
+	return s.WriteDataForLinkContext(ctx, endpoint, points)
 }
 
 // WriteEventsForDeviceID sends events to CloudThing device's for saving
 func (s *ResourcesServiceOp) WriteEventsForDeviceID(deviceID string, points []EventPoint) ([]EventPoint, error) {
-	endpoint := fmt.Sprintf("/api/v1/devices/%s/resources/events", deviceID)
+	return s.WriteEventsForDeviceIDContext(context.Background(), deviceID, points)
+}
 
-	return s.WriteEventsForLink(endpoint, points)
+// WriteEventsForDeviceIDContext is like WriteEventsForDeviceID but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) WriteEventsForDeviceIDContext(ctx context.Context, deviceID string, points []EventPoint) ([]EventPoint, error) {
+	ctx = withOperation(ctx, "Resources", "WriteEventsForDeviceID")
+	endpoint := fmt.Sprintf("devices/%s/resources/events", deviceID)
+
+	return s.WriteEventsForLinkContext(ctx, endpoint, points)
 }
 
 // WriteCommandsForDeviceID sends events to CloudThing device's for saving
 func (s *ResourcesServiceOp) WriteCommandsForDeviceID(deviceID string, points []CommandPoint) ([]CommandPoint, error) {
-	endpoint := fmt.Sprintf("/api/v1/devices/%s/resources/commands", deviceID)
+	return s.WriteCommandsForDeviceIDContext(context.Background(), deviceID, points)
+}
 
-	return s.WriteCommandsForLink(endpoint, points)
+// WriteCommandsForDeviceIDContext is like WriteCommandsForDeviceID but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) WriteCommandsForDeviceIDContext(ctx context.Context, deviceID string, points []CommandPoint) ([]CommandPoint, error) {
+	ctx = withOperation(ctx, "Resources", "WriteCommandsForDeviceID")
+	endpoint := fmt.Sprintf("devices/%s/resources/commands", deviceID)
+
+	return s.WriteCommandsForLinkContext(ctx, endpoint, points)
 }
 
 // GetDataByClusterID requests from CloudThing cluster's data with set filters
 func (s *ResourcesServiceOp) GetDataByClusterID(clusterID string, filters ...interface{}) ([]DataPoint, *ListParams, error) {
-	endpoint := fmt.Sprintf("/api/v1/clusters/%s/resources/data", clusterID)
+	return s.GetDataByClusterIDContext(context.Background(), clusterID, filters...)
+}
 
-	return s.GetDataByLink(endpoint, filters...)
+// GetDataByClusterIDContext is like GetDataByClusterID but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) GetDataByClusterIDContext(ctx context.Context, clusterID string, filters ...interface{}) ([]DataPoint, *ListParams, error) {
+	ctx = withOperation(ctx, "Resources", "GetDataByClusterID")
+	endpoint := fmt.Sprintf("clusters/%s/resources/data", clusterID)
+
+	return s.GetDataByLinkContext(ctx, endpoint, filters...)
 }
 
 // GetEventsByClusterID requests from CloudThing cluster's events with set filters
 func (s *ResourcesServiceOp) GetEventsByClusterID(clusterID string, filters ...interface{}) ([]EventPoint, *ListParams, error) {
-	endpoint := fmt.Sprintf("/api/v1/clusters/%s/resources/events", clusterID)
+	return s.GetEventsByClusterIDContext(context.Background(), clusterID, filters...)
+}
+
+// GetEventsByClusterIDContext is like GetEventsByClusterID but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) GetEventsByClusterIDContext(ctx context.Context, clusterID string, filters ...interface{}) ([]EventPoint, *ListParams, error) {
+	ctx = withOperation(ctx, "Resources", "GetEventsByClusterID")
+	endpoint := fmt.Sprintf("clusters/%s/resources/events", clusterID)
 
-	return s.GetEventsByLink(endpoint, filters...)
+	return s.GetEventsByLinkContext(ctx, endpoint, filters...)
 }
 
 // GetCommandsByClusterID requests from CloudThing cluster's commands with set filters
 func (s *ResourcesServiceOp) GetCommandsByClusterID(clusterID string, filters ...interface{}) ([]CommandPoint, *ListParams, error) {
-	endpoint := fmt.Sprintf("/api/v1/clusters/%s/resources/commands", clusterID)
+	return s.GetCommandsByClusterIDContext(context.Background(), clusterID, filters...)
+}
+
+// GetCommandsByClusterIDContext is like GetCommandsByClusterID but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) GetCommandsByClusterIDContext(ctx context.Context, clusterID string, filters ...interface{}) ([]CommandPoint, *ListParams, error) {
+	ctx = withOperation(ctx, "Resources", "GetCommandsByClusterID")
+	endpoint := fmt.Sprintf("clusters/%s/resources/commands", clusterID)
 
-	return s.GetCommandsByLink(endpoint, filters...)
+	return s.GetCommandsByLinkContext(ctx, endpoint, filters...)
 }
 
 // WriteDataForClusterID sends data to CloudThing cluster's for saving
 func (s *ResourcesServiceOp) WriteDataForClusterID(clusterID string, points []DataPoint) ([]DataPoint, error) {
-	endpoint := fmt.Sprintf("/api/v1/clusters/%s/resources/data", clusterID)
+	return s.WriteDataForClusterIDContext(context.Background(), clusterID, points)
+}
+
+// WriteDataForClusterIDContext is like WriteDataForClusterID but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) WriteDataForClusterIDContext(ctx context.Context, clusterID string, points []DataPoint) ([]DataPoint, error) {
+	ctx = withOperation(ctx, "Resources", "WriteDataForClusterID")
+	endpoint := fmt.Sprintf("clusters/%s/resources/data", clusterID)
 
-	return s.WriteDataForLink(endpoint, points)
+	return s.WriteDataForLinkContext(ctx, endpoint, points)
 }
 
 // WriteEventsForClusterID sends events to CloudThing cluster's for saving
 func (s *ResourcesServiceOp) WriteEventsForClusterID(clusterID string, points []EventPoint) ([]EventPoint, error) {
-	endpoint := fmt.Sprintf("/api/v1/clusters/%s/resources/events", clusterID)
+	return s.WriteEventsForClusterIDContext(context.Background(), clusterID, points)
+}
+
+// WriteEventsForClusterIDContext is like WriteEventsForClusterID but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) WriteEventsForClusterIDContext(ctx context.Context, clusterID string, points []EventPoint) ([]EventPoint, error) {
+	ctx = withOperation(ctx, "Resources", "WriteEventsForClusterID")
+	endpoint := fmt.Sprintf("clusters/%s/resources/events", clusterID)
 
-	return s.WriteEventsForLink(endpoint, points)
+	return s.WriteEventsForLinkContext(ctx, endpoint, points)
 }
 
 // WriteCommandsForClusterID sends events to CloudThing cluster's for saving
 func (s *ResourcesServiceOp) WriteCommandsForClusterID(clusterID string, points []CommandPoint) ([]CommandPoint, error) {
-	endpoint := fmt.Sprintf("/api/v1/clusters/%s/resources/commands", clusterID)
+	return s.WriteCommandsForClusterIDContext(context.Background(), clusterID, points)
+}
+
+// WriteCommandsForClusterIDContext is like WriteCommandsForClusterID but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) WriteCommandsForClusterIDContext(ctx context.Context, clusterID string, points []CommandPoint) ([]CommandPoint, error) {
+	ctx = withOperation(ctx, "Resources", "WriteCommandsForClusterID")
+	endpoint := fmt.Sprintf("clusters/%s/resources/commands", clusterID)
 
-	return s.WriteCommandsForLink(endpoint, points)
+	return s.WriteCommandsForLinkContext(ctx, endpoint, points)
 }
 
 // GetDataByLink requests from CloudThing data with set filters
 func (s *ResourcesServiceOp) GetDataByLink(link string, filters ...interface{}) ([]DataPoint, *ListParams, error) {
+	return s.GetDataByLinkContext(context.Background(), link, filters...)
+}
+
+// GetDataByLinkContext is like GetDataByLink but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) GetDataByLinkContext(ctx context.Context, link string, filters ...interface{}) ([]DataPoint, *ListParams, error) {
+	ctx = withOperation(ctx, "Resources", "GetDataByLink")
 	obj := &DataResponse{}
-	err := s.getResourcesByEndpoint(obj, link, filters...)
+	err := s.getResourcesByEndpoint(ctx, obj, link, filters...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -181,8 +372,14 @@ func (s *ResourcesServiceOp) GetDataByLink(link string, filters ...interface{})
 
 // GetEventsByLink requests from CloudThing events with set filters
 func (s *ResourcesServiceOp) GetEventsByLink(link string, filters ...interface{}) ([]EventPoint, *ListParams, error) {
+	return s.GetEventsByLinkContext(context.Background(), link, filters...)
+}
+
+// GetEventsByLinkContext is like GetEventsByLink but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) GetEventsByLinkContext(ctx context.Context, link string, filters ...interface{}) ([]EventPoint, *ListParams, error) {
+	ctx = withOperation(ctx, "Resources", "GetEventsByLink")
 	obj := &EventsResponse{}
-	err := s.getResourcesByEndpoint(obj, link, filters...)
+	err := s.getResourcesByEndpoint(ctx, obj, link, filters...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -191,8 +388,14 @@ func (s *ResourcesServiceOp) GetEventsByLink(link string, filters ...interface{}
 
 // GetCommandsByLink requests from CloudThing commands with set filters
 func (s *ResourcesServiceOp) GetCommandsByLink(link string, filters ...interface{}) ([]CommandPoint, *ListParams, error) {
+	return s.GetCommandsByLinkContext(context.Background(), link, filters...)
+}
+
+// GetCommandsByLinkContext is like GetCommandsByLink but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) GetCommandsByLinkContext(ctx context.Context, link string, filters ...interface{}) ([]CommandPoint, *ListParams, error) {
+	ctx = withOperation(ctx, "Resources", "GetCommandsByLink")
 	obj := &CommandsResponse{}
-	err := s.getResourcesByEndpoint(obj, link, filters...)
+	err := s.getResourcesByEndpoint(ctx, obj, link, filters...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -201,8 +404,14 @@ func (s *ResourcesServiceOp) GetCommandsByLink(link string, filters ...interface
 
 // WriteDataForLink sends data to CloudThing for saving
 func (s *ResourcesServiceOp) WriteDataForLink(link string, points []DataPoint) ([]DataPoint, error) {
+	return s.WriteDataForLinkContext(context.Background(), link, points)
+}
+
+// WriteDataForLinkContext is like WriteDataForLink but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) WriteDataForLinkContext(ctx context.Context, link string, points []DataPoint) ([]DataPoint, error) {
+	ctx = withOperation(ctx, "Resources", "WriteDataForLink")
 	obj := make([]DataPoint, 0)
-	err := s.writeResourcesByEndpoint(obj, points, link)
+	err := s.writeResourcesByEndpoint(ctx, &obj, points, link)
 	if err != nil {
 		return nil, err
 	}
@@ -211,8 +420,14 @@ func (s *ResourcesServiceOp) WriteDataForLink(link string, points []DataPoint) (
 
 // WriteEventsForLink sends events to CloudThing for saving
 func (s *ResourcesServiceOp) WriteEventsForLink(link string, points []EventPoint) ([]EventPoint, error) {
+	return s.WriteEventsForLinkContext(context.Background(), link, points)
+}
+
+// WriteEventsForLinkContext is like WriteEventsForLink but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) WriteEventsForLinkContext(ctx context.Context, link string, points []EventPoint) ([]EventPoint, error) {
+	ctx = withOperation(ctx, "Resources", "WriteEventsForLink")
 	obj := make([]EventPoint, 0)
-	err := s.writeResourcesByEndpoint(obj, points, link)
+	err := s.writeResourcesByEndpoint(ctx, &obj, points, link)
 	if err != nil {
 		return nil, err
 	}
@@ -221,23 +436,296 @@ func (s *ResourcesServiceOp) WriteEventsForLink(link string, points []EventPoint
 
 // WriteCommandsForLink sends events to CloudThing for saving
 func (s *ResourcesServiceOp) WriteCommandsForLink(link string, points []CommandPoint) ([]CommandPoint, error) {
+	return s.WriteCommandsForLinkContext(context.Background(), link, points)
+}
+
+// WriteCommandsForLinkContext is like WriteCommandsForLink but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) WriteCommandsForLinkContext(ctx context.Context, link string, points []CommandPoint) ([]CommandPoint, error) {
+	ctx = withOperation(ctx, "Resources", "WriteCommandsForLink")
 	obj := make([]CommandPoint, 0)
-	err := s.writeResourcesByEndpoint(obj, points, link)
+	err := s.writeResourcesByEndpoint(ctx, &obj, points, link)
 	if err != nil {
 		return nil, err
 	}
 	return obj, nil
 }
 
-func (s *ResourcesServiceOp) getResourcesByEndpoint(responseBody interface{}, endpoint string, filters ...interface{}) error {
-	resp, err := s.client.request("GET", endpoint, nil, filters...)
+// StreamDataByDeviceID is like GetDataByDeviceID but decodes points one at a time, following all pages
+func (s *ResourcesServiceOp) StreamDataByDeviceID(ctx context.Context, deviceID string, filters ...interface{}) *Stream[DataPoint] {
+	ctx = withOperation(ctx, "Resources", "StreamDataByDeviceID")
+	endpoint := fmt.Sprintf("devices/%s/resources/data", deviceID)
+
+	return s.StreamDataByLink(ctx, endpoint, filters...)
+}
+
+// StreamDataByClusterID is like GetDataByClusterID but decodes points one at a time, following all pages
+func (s *ResourcesServiceOp) StreamDataByClusterID(ctx context.Context, clusterID string, filters ...interface{}) *Stream[DataPoint] {
+	ctx = withOperation(ctx, "Resources", "StreamDataByClusterID")
+	endpoint := fmt.Sprintf("clusters/%s/resources/data", clusterID)
+
+	return s.StreamDataByLink(ctx, endpoint, filters...)
+}
+
+// StreamDataByLink is like GetDataByLink but decodes points one at a time, following all pages
+func (s *ResourcesServiceOp) StreamDataByLink(ctx context.Context, link string, filters ...interface{}) *Stream[DataPoint] {
+	ctx = withOperation(ctx, "Resources", "StreamDataByLink")
+	return newStream[DataPoint](ctx, s.client, link, filters...)
+}
+
+// StreamEventsByDeviceID is like GetEventsByDeviceID but decodes points one at a time, following all pages
+func (s *ResourcesServiceOp) StreamEventsByDeviceID(ctx context.Context, deviceID string, filters ...interface{}) *Stream[EventPoint] {
+	ctx = withOperation(ctx, "Resources", "StreamEventsByDeviceID")
+	endpoint := fmt.Sprintf("devices/%s/resources/events", deviceID)
+
+	return s.StreamEventsByLink(ctx, endpoint, filters...)
+}
+
+// StreamEventsByClusterID is like GetEventsByClusterID but decodes points one at a time, following all pages
+func (s *ResourcesServiceOp) StreamEventsByClusterID(ctx context.Context, clusterID string, filters ...interface{}) *Stream[EventPoint] {
+	ctx = withOperation(ctx, "Resources", "StreamEventsByClusterID")
+	endpoint := fmt.Sprintf("clusters/%s/resources/events", clusterID)
+
+	return s.StreamEventsByLink(ctx, endpoint, filters...)
+}
+
+// StreamEventsByLink is like GetEventsByLink but decodes points one at a time, following all pages
+func (s *ResourcesServiceOp) StreamEventsByLink(ctx context.Context, link string, filters ...interface{}) *Stream[EventPoint] {
+	ctx = withOperation(ctx, "Resources", "StreamEventsByLink")
+	return newStream[EventPoint](ctx, s.client, link, filters...)
+}
+
+// StreamCommandsByDeviceID is like GetCommandsByDeviceID but decodes points one at a time, following all pages
+func (s *ResourcesServiceOp) StreamCommandsByDeviceID(ctx context.Context, deviceID string, filters ...interface{}) *Stream[CommandPoint] {
+	ctx = withOperation(ctx, "Resources", "StreamCommandsByDeviceID")
+	endpoint := fmt.Sprintf("devices/%s/resources/commands", deviceID)
+
+	return s.StreamCommandsByLink(ctx, endpoint, filters...)
+}
+
+// StreamCommandsByClusterID is like GetCommandsByClusterID but decodes points one at a time, following all pages
+func (s *ResourcesServiceOp) StreamCommandsByClusterID(ctx context.Context, clusterID string, filters ...interface{}) *Stream[CommandPoint] {
+	ctx = withOperation(ctx, "Resources", "StreamCommandsByClusterID")
+	endpoint := fmt.Sprintf("clusters/%s/resources/commands", clusterID)
+
+	return s.StreamCommandsByLink(ctx, endpoint, filters...)
+}
+
+// StreamCommandsByLink is like GetCommandsByLink but decodes points one at a time, following all pages
+func (s *ResourcesServiceOp) StreamCommandsByLink(ctx context.Context, link string, filters ...interface{}) *Stream[CommandPoint] {
+	ctx = withOperation(ctx, "Resources", "StreamCommandsByLink")
+	return newStream[CommandPoint](ctx, s.client, link, filters...)
+}
+
+// GetDataByDeviceKey requests from CloudThing device's data of a single key with set filters
+func (s *ResourcesServiceOp) GetDataByDeviceKey(deviceID, key string, filters ...interface{}) ([]DataPoint, *ListParams, error) {
+	return s.GetDataByDeviceKeyContext(context.Background(), deviceID, key, filters...)
+}
+
+// GetDataByDeviceKeyContext is like GetDataByDeviceKey but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) GetDataByDeviceKeyContext(ctx context.Context, deviceID, key string, filters ...interface{}) ([]DataPoint, *ListParams, error) {
+	ctx = withOperation(ctx, "Resources", "GetDataByDeviceKey")
+	endpoint := fmt.Sprintf("devices/%s/resources/data/%s", deviceID, url.PathEscape(key))
+
+	return s.GetDataByLinkContext(ctx, endpoint, filters...)
+}
+
+// GetEventsByDeviceKey requests from CloudThing device's events of a single key with set filters
+func (s *ResourcesServiceOp) GetEventsByDeviceKey(deviceID, key string, filters ...interface{}) ([]EventPoint, *ListParams, error) {
+	return s.GetEventsByDeviceKeyContext(context.Background(), deviceID, key, filters...)
+}
+
+// GetEventsByDeviceKeyContext is like GetEventsByDeviceKey but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) GetEventsByDeviceKeyContext(ctx context.Context, deviceID, key string, filters ...interface{}) ([]EventPoint, *ListParams, error) {
+	ctx = withOperation(ctx, "Resources", "GetEventsByDeviceKey")
+	endpoint := fmt.Sprintf("devices/%s/resources/events/%s", deviceID, url.PathEscape(key))
+
+	return s.GetEventsByLinkContext(ctx, endpoint, filters...)
+}
+
+// GetCommandsByDeviceKey requests from CloudThing device's commands of a single key with set filters
+func (s *ResourcesServiceOp) GetCommandsByDeviceKey(deviceID, key string, filters ...interface{}) ([]CommandPoint, *ListParams, error) {
+	return s.GetCommandsByDeviceKeyContext(context.Background(), deviceID, key, filters...)
+}
+
+// GetCommandsByDeviceKeyContext is like GetCommandsByDeviceKey but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) GetCommandsByDeviceKeyContext(ctx context.Context, deviceID, key string, filters ...interface{}) ([]CommandPoint, *ListParams, error) {
+	ctx = withOperation(ctx, "Resources", "GetCommandsByDeviceKey")
+	endpoint := fmt.Sprintf("devices/%s/resources/commands/%s", deviceID, url.PathEscape(key))
+
+	return s.GetCommandsByLinkContext(ctx, endpoint, filters...)
+}
+
+// GetDataByClusterKey requests from CloudThing cluster's data of a single key with set filters
+func (s *ResourcesServiceOp) GetDataByClusterKey(clusterID, key string, filters ...interface{}) ([]DataPoint, *ListParams, error) {
+	return s.GetDataByClusterKeyContext(context.Background(), clusterID, key, filters...)
+}
+
+// GetDataByClusterKeyContext is like GetDataByClusterKey but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) GetDataByClusterKeyContext(ctx context.Context, clusterID, key string, filters ...interface{}) ([]DataPoint, *ListParams, error) {
+	ctx = withOperation(ctx, "Resources", "GetDataByClusterKey")
+	endpoint := fmt.Sprintf("clusters/%s/resources/data/%s", clusterID, url.PathEscape(key))
+
+	return s.GetDataByLinkContext(ctx, endpoint, filters...)
+}
+
+// GetEventsByClusterKey requests from CloudThing cluster's events of a single key with set filters
+func (s *ResourcesServiceOp) GetEventsByClusterKey(clusterID, key string, filters ...interface{}) ([]EventPoint, *ListParams, error) {
+	return s.GetEventsByClusterKeyContext(context.Background(), clusterID, key, filters...)
+}
+
+// GetEventsByClusterKeyContext is like GetEventsByClusterKey but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) GetEventsByClusterKeyContext(ctx context.Context, clusterID, key string, filters ...interface{}) ([]EventPoint, *ListParams, error) {
+	ctx = withOperation(ctx, "Resources", "GetEventsByClusterKey")
+	endpoint := fmt.Sprintf("clusters/%s/resources/events/%s", clusterID, url.PathEscape(key))
+
+	return s.GetEventsByLinkContext(ctx, endpoint, filters...)
+}
+
+// GetCommandsByClusterKey requests from CloudThing cluster's commands of a single key with set filters
+func (s *ResourcesServiceOp) GetCommandsByClusterKey(clusterID, key string, filters ...interface{}) ([]CommandPoint, *ListParams, error) {
+	return s.GetCommandsByClusterKeyContext(context.Background(), clusterID, key, filters...)
+}
+
+// GetCommandsByClusterKeyContext is like GetCommandsByClusterKey but uses ctx for the underlying request.
+func (s *ResourcesServiceOp) GetCommandsByClusterKeyContext(ctx context.Context, clusterID, key string, filters ...interface{}) ([]CommandPoint, *ListParams, error) {
+	ctx = withOperation(ctx, "Resources", "GetCommandsByClusterKey")
+	endpoint := fmt.Sprintf("clusters/%s/resources/commands/%s", clusterID, url.PathEscape(key))
+
+	return s.GetCommandsByLinkContext(ctx, endpoint, filters...)
+}
+
+// GetDataByDeviceKeys requests from CloudThing device's data of keys with set filters, grouped by key.
+// Every key is requested separately, taking one request per key and page, duplicate
+// keys are requested once.
+func (s *ResourcesServiceOp) GetDataByDeviceKeys(deviceID string, keys []string, filters ...interface{}) (map[string][]DataPoint, error) {
+	return s.GetDataByDeviceKeysContext(context.Background(), deviceID, keys, filters...)
+}
+
+// GetDataByDeviceKeysContext is like GetDataByDeviceKeys but uses ctx for the underlying requests.
+func (s *ResourcesServiceOp) GetDataByDeviceKeysContext(ctx context.Context, deviceID string, keys []string, filters ...interface{}) (map[string][]DataPoint, error) {
+	ctx = withOperation(ctx, "Resources", "GetDataByDeviceKeys")
+	link := func(key string) string {
+		return fmt.Sprintf("devices/%s/resources/data/%s", deviceID, url.PathEscape(key))
+	}
+
+	return getByKeys(ctx, link, keys, s.GetDataByLinkContext, filters...)
+}
+
+// GetEventsByDeviceKeys requests from CloudThing device's events of keys with set filters, grouped by key.
+// Every key is requested separately, taking one request per key and page, duplicate
+// keys are requested once.
+func (s *ResourcesServiceOp) GetEventsByDeviceKeys(deviceID string, keys []string, filters ...interface{}) (map[string][]EventPoint, error) {
+	return s.GetEventsByDeviceKeysContext(context.Background(), deviceID, keys, filters...)
+}
+
+// GetEventsByDeviceKeysContext is like GetEventsByDeviceKeys but uses ctx for the underlying requests.
+func (s *ResourcesServiceOp) GetEventsByDeviceKeysContext(ctx context.Context, deviceID string, keys []string, filters ...interface{}) (map[string][]EventPoint, error) {
+	ctx = withOperation(ctx, "Resources", "GetEventsByDeviceKeys")
+	link := func(key string) string {
+		return fmt.Sprintf("devices/%s/resources/events/%s", deviceID, url.PathEscape(key))
+	}
+
+	return getByKeys(ctx, link, keys, s.GetEventsByLinkContext, filters...)
+}
+
+// GetCommandsByDeviceKeys requests from CloudThing device's commands of keys with set filters, grouped by key.
+// Every key is requested separately, taking one request per key and page, duplicate
+// keys are requested once.
+func (s *ResourcesServiceOp) GetCommandsByDeviceKeys(deviceID string, keys []string, filters ...interface{}) (map[string][]CommandPoint, error) {
+	return s.GetCommandsByDeviceKeysContext(context.Background(), deviceID, keys, filters...)
+}
+
+// GetCommandsByDeviceKeysContext is like GetCommandsByDeviceKeys but uses ctx for the underlying requests.
+func (s *ResourcesServiceOp) GetCommandsByDeviceKeysContext(ctx context.Context, deviceID string, keys []string, filters ...interface{}) (map[string][]CommandPoint, error) {
+	ctx = withOperation(ctx, "Resources", "GetCommandsByDeviceKeys")
+	link := func(key string) string {
+		return fmt.Sprintf("devices/%s/resources/commands/%s", deviceID, url.PathEscape(key))
+	}
+
+	return getByKeys(ctx, link, keys, s.GetCommandsByLinkContext, filters...)
+}
+
+// GetDataByClusterKeys requests from CloudThing cluster's data of keys with set filters, grouped by key.
+// Every key is requested separately, taking one request per key and page, duplicate
+// keys are requested once.
+func (s *ResourcesServiceOp) GetDataByClusterKeys(clusterID string, keys []string, filters ...interface{}) (map[string][]DataPoint, error) {
+	return s.GetDataByClusterKeysContext(context.Background(), clusterID, keys, filters...)
+}
+
+// GetDataByClusterKeysContext is like GetDataByClusterKeys but uses ctx for the underlying requests.
+func (s *ResourcesServiceOp) GetDataByClusterKeysContext(ctx context.Context, clusterID string, keys []string, filters ...interface{}) (map[string][]DataPoint, error) {
+	ctx = withOperation(ctx, "Resources", "GetDataByClusterKeys")
+	link := func(key string) string {
+		return fmt.Sprintf("clusters/%s/resources/data/%s", clusterID, url.PathEscape(key))
+	}
+
+	return getByKeys(ctx, link, keys, s.GetDataByLinkContext, filters...)
+}
+
+// GetEventsByClusterKeys requests from CloudThing cluster's events of keys with set filters, grouped by key.
+// Every key is requested separately, taking one request per key and page, duplicate
+// keys are requested once.
+func (s *ResourcesServiceOp) GetEventsByClusterKeys(clusterID string, keys []string, filters ...interface{}) (map[string][]EventPoint, error) {
+	return s.GetEventsByClusterKeysContext(context.Background(), clusterID, keys, filters...)
+}
+
+// GetEventsByClusterKeysContext is like GetEventsByClusterKeys but uses ctx for the underlying requests.
+func (s *ResourcesServiceOp) GetEventsByClusterKeysContext(ctx context.Context, clusterID string, keys []string, filters ...interface{}) (map[string][]EventPoint, error) {
+	ctx = withOperation(ctx, "Resources", "GetEventsByClusterKeys")
+	link := func(key string) string {
+		return fmt.Sprintf("clusters/%s/resources/events/%s", clusterID, url.PathEscape(key))
+	}
+
+	return getByKeys(ctx, link, keys, s.GetEventsByLinkContext, filters...)
+}
+
+// GetCommandsByClusterKeys requests from CloudThing cluster's commands of keys with set filters, grouped by key.
+// Every key is requested separately, taking one request per key and page, duplicate
+// keys are requested once.
+func (s *ResourcesServiceOp) GetCommandsByClusterKeys(clusterID string, keys []string, filters ...interface{}) (map[string][]CommandPoint, error) {
+	return s.GetCommandsByClusterKeysContext(context.Background(), clusterID, keys, filters...)
+}
+
+// GetCommandsByClusterKeysContext is like GetCommandsByClusterKeys but uses ctx for the underlying requests.
+func (s *ResourcesServiceOp) GetCommandsByClusterKeysContext(ctx context.Context, clusterID string, keys []string, filters ...interface{}) (map[string][]CommandPoint, error) {
+	ctx = withOperation(ctx, "Resources", "GetCommandsByClusterKeys")
+	link := func(key string) string {
+		return fmt.Sprintf("clusters/%s/resources/commands/%s", clusterID, url.PathEscape(key))
+	}
+
+	return getByKeys(ctx, link, keys, s.GetCommandsByLinkContext, filters...)
+}
+
+// getByKeys requests points of every key by its link and groups them by key.
+// Keys are requested sequentially, with one request per key and page.
+func getByKeys[T any](ctx context.Context, link func(string) string, keys []string, fetch pageFunc[T], filters ...interface{}) (map[string][]T, error) {
+	res := make(map[string][]T, len(keys))
+	for _, key := range keys {
+		if _, ok := res[key]; ok {
+			continue
+		}
+		points := make([]T, 0)
+		it := newIterator(ctx, link(key), fetch, filters...)
+		for it.Next() {
+			points = append(points, it.Value())
+		}
+		if err := it.Err(); err != nil {
+			return nil, err
+		}
+		res[key] = points
+	}
+	return res, nil
+}
+
+func (s *ResourcesServiceOp) getResourcesByEndpoint(ctx context.Context, responseBody interface{}, endpoint string, filters ...interface{}) error {
+	resp, err := s.client.request(ctx, "GET", endpoint, nil, filters...)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return newApiError(resp, "non-ok status returned")
 	}
 
 	dec := json.NewDecoder(resp.Body)
@@ -248,22 +736,25 @@ func (s *ResourcesServiceOp) getResourcesByEndpoint(responseBody interface{}, en
 	return nil
 }
 
-func (s *ResourcesServiceOp) writeResourcesByEndpoint(responseBody interface{}, data interface{}, endpoint string) error {
+func (s *ResourcesServiceOp) writeResourcesByEndpoint(ctx context.Context, responseBody interface{}, data interface{}, endpoint string) error {
+	if err := s.client.validateWrite(ctx, endpoint, data); err != nil {
+		return err
+	}
 	enc, err := json.Marshal(data)
 	if err != nil {
 		return err
 	}
 	buf := bytes.NewBuffer(enc)
-	resp, err := s.client.request("POST", endpoint, buf)
+	resp, err := s.client.request(ctx, "POST", endpoint, buf)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return newApiError(resp, "non-ok status returned")
 	}
 	dec := json.NewDecoder(resp.Body)
-	err = dec.Decode(&responseBody)
+	err = dec.Decode(responseBody)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,159 @@
+package api_test
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+)
+
+// keyedClient returns client and ID of device and cluster with data, events and commands
+// of keys "t" (two points), "a/b" and "c"
+func keyedClient(t *testing.T, srv *apitest.Server) (*api.Client, string, string) {
+	t.Helper()
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	device := srv.Add("devices", map[string]interface{}{}, nil)
+	cluster := srv.Add("clusters", map[string]interface{}{"name": "Hall"}, nil)
+	at := time.Now().Add(-time.Minute)
+	keys := []string{"t", "t", "a/b", "c"}
+	for _, link := range []string{"devices/" + device + "/resources/", "clusters/" + cluster + "/resources/"} {
+		var data []api.DataPoint
+		var events []api.EventPoint
+		var commands []api.CommandPoint
+		for i, k := range keys {
+			ts := api.FormatPointTime(at.Add(time.Duration(i)*time.Second), api.PrecisionMillisecond)
+			data = append(data, api.DataPoint{Key: k, Value: float64(i), Time: ts})
+			events = append(events, api.EventPoint{Key: k, Time: ts})
+			commands = append(commands, api.CommandPoint{Key: k, Time: ts})
+		}
+		if _, err := client.Resources.WriteDataForLink(link+"data", data); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.Resources.WriteEventsForLink(link+"events", events); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.Resources.WriteCommandsForLink(link+"commands", commands); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return client, device, cluster
+}
+
+func TestGetByKey(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, device, cluster := keyedClient(t, srv)
+	r := client.Resources
+
+	tests := []struct {
+		path string
+		get  func(string) (int, error)
+	}{
+		{"devices/" + device + "/resources/data/a%2Fb", func(key string) (int, error) {
+			points, _, err := r.GetDataByDeviceKey(device, key)
+			return len(points), err
+		}},
+		{"devices/" + device + "/resources/events/a%2Fb", func(key string) (int, error) {
+			points, _, err := r.GetEventsByDeviceKey(device, key)
+			return len(points), err
+		}},
+		{"devices/" + device + "/resources/commands/a%2Fb", func(key string) (int, error) {
+			points, _, err := r.GetCommandsByDeviceKey(device, key)
+			return len(points), err
+		}},
+		{"clusters/" + cluster + "/resources/data/a%2Fb", func(key string) (int, error) {
+			points, _, err := r.GetDataByClusterKey(cluster, key)
+			return len(points), err
+		}},
+		{"clusters/" + cluster + "/resources/events/a%2Fb", func(key string) (int, error) {
+			points, _, err := r.GetEventsByClusterKey(cluster, key)
+			return len(points), err
+		}},
+		{"clusters/" + cluster + "/resources/commands/a%2Fb", func(key string) (int, error) {
+			points, _, err := r.GetCommandsByClusterKey(cluster, key)
+			return len(points), err
+		}},
+	}
+	for _, tt := range tests {
+		n, err := tt.get("a/b")
+		if err != nil {
+			t.Errorf("%s: %v", tt.path, err)
+			continue
+		}
+		if n != 1 {
+			t.Errorf("%s: got %d points, want 1", tt.path, n)
+		}
+		if countRequests(srv, "GET", tt.path) != 1 {
+			t.Errorf("%s wasn't requested with escaped key", tt.path)
+		}
+	}
+}
+
+func TestGetByKeys(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, device, cluster := keyedClient(t, srv)
+	r := client.Resources
+	keys := []string{"t", "a/b", "t", "missing"}
+	// every page holds a single point
+	page := &api.ListOptions{Limit: 1}
+
+	tests := []struct {
+		link string
+		get  func() (map[string]int, error)
+	}{
+		{"devices/" + device + "/resources/data/", func() (map[string]int, error) {
+			res, err := r.GetDataByDeviceKeys(device, keys, page)
+			return counts(res), err
+		}},
+		{"devices/" + device + "/resources/events/", func() (map[string]int, error) {
+			res, err := r.GetEventsByDeviceKeys(device, keys, page)
+			return counts(res), err
+		}},
+		{"devices/" + device + "/resources/commands/", func() (map[string]int, error) {
+			res, err := r.GetCommandsByDeviceKeys(device, keys, page)
+			return counts(res), err
+		}},
+		{"clusters/" + cluster + "/resources/data/", func() (map[string]int, error) {
+			res, err := r.GetDataByClusterKeys(cluster, keys, page)
+			return counts(res), err
+		}},
+		{"clusters/" + cluster + "/resources/events/", func() (map[string]int, error) {
+			res, err := r.GetEventsByClusterKeys(cluster, keys, page)
+			return counts(res), err
+		}},
+		{"clusters/" + cluster + "/resources/commands/", func() (map[string]int, error) {
+			res, err := r.GetCommandsByClusterKeys(cluster, keys, page)
+			return counts(res), err
+		}},
+	}
+	for _, tt := range tests {
+		res, err := tt.get()
+		if err != nil {
+			t.Errorf("%s: %v", tt.link, err)
+			continue
+		}
+		if len(res) != 3 || res["t"] != 2 || res["a/b"] != 1 || res["missing"] != 0 {
+			t.Errorf("%s: got points of keys %v, want 2 of t, 1 of a/b and none of missing", tt.link, res)
+		}
+		// one request per key and page, duplicate key is requested once
+		for key, want := range map[string]int{"t": 2, "a%2Fb": 1, "missing": 1} {
+			if n := countRequests(srv, "GET", tt.link+key); n != want {
+				t.Errorf("%s%s requested %d times, want %d", tt.link, key, n, want)
+			}
+		}
+	}
+}
+
+// counts returns number of points of every key in res
+func counts[T any](res map[string][]T) map[string]int {
+	n := make(map[string]int, len(res))
+	for k, points := range res {
+		n[k] = len(points)
+	}
+	return n
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how Client retries failed requests. Requests are retried
+// on transport errors and on one of RetryStatuses. By default only idempotent
+// methods are retried, other calls need context returned by WithRetry.
+type RetryPolicy struct {
+	// Maximum number of attempts including the first one, values below 2 disable retries
+	MaxAttempts int
+	// Backoff before the first retry, doubled for every next attempt
+	MinBackoff time.Duration
+	// Upper limit of backoff
+	MaxBackoff time.Duration
+	// Status codes which are considered transient
+	RetryStatuses []int
+	// Methods retried without WithRetry
+	Methods []string
+}
+
+// DefaultRetryPolicy returns policy retrying idempotent requests up to 3 times
+// on 429, 502, 503 and 504 statuses.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:   3,
+		MinBackoff:    200 * time.Millisecond,
+		MaxBackoff:    5 * time.Second,
+		RetryStatuses: []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		Methods:       []string{"GET", "DELETE"},
+	}
+}
+
+type retryKey struct{}
+
+// WithRetry returns context marking calls made with it as safe to retry
+// regardless of their method, e.g. POSTs which may be sent again.
+func WithRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryKey{}, true)
+}
+
+// SetRetryPolicy sets policy used for retrying failed requests, nil disables retries
+func (c *Client) SetRetryPolicy(p *RetryPolicy) {
+	c.retryPolicy = p
+}
+
+// retryable checks whether request may be retried at all
+func (p *RetryPolicy) retryable(ctx context.Context, method string) bool {
+	if p == nil || p.MaxAttempts < 2 {
+		return false
+	}
+	if v, ok := ctx.Value(retryKey{}).(bool); ok && v {
+		return true
+	}
+	for _, m := range p.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldRetry checks whether result of an attempt is transient failure
+func (p *RetryPolicy) shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	for _, s := range p.RetryStatuses {
+		if s == resp.StatusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns time to wait before next attempt. Retry-After header of response
+// takes precedence, otherwise exponential backoff with jitter is used.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+	d := p.MinBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	// half of backoff is fixed, the other half is random
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter parses Retry-After header given either in seconds or as HTTP date
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// send sends request retrying it according to client's retry policy
+func (c *Client) send(ctx context.Context, method, u string, payload []byte, token *Token) (*http.Response, error) {
+	p := c.retryPolicy
+	if !p.retryable(ctx, method) {
+		return c.do(ctx, method, u, payload, token)
+	}
+	for attempt := 1; ; attempt++ {
+		resp, err := c.do(ctx, method, u, payload, token)
+		if attempt >= p.MaxAttempts || ctx.Err() != nil || !p.shouldRetry(resp, err) {
+			return resp, err
+		}
+		wait := p.backoff(attempt, resp)
+		if err != nil {
+			c.log(ctx, LevelWarn, "request failed, retrying", "method", method, "url", u, "attempt", attempt, "wait", wait, "error", err)
+		} else {
+			c.log(ctx, LevelWarn, "request failed, retrying", "method", method, "url", u, "attempt", attempt, "wait", wait, "status", resp.StatusCode)
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
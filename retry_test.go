@@ -0,0 +1,129 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+)
+
+func fastRetries() *api.RetryPolicy {
+	p := api.DefaultRetryPolicy()
+	p.MinBackoff = time.Millisecond
+	p.MaxBackoff = 10 * time.Millisecond
+	return p
+}
+
+func countRequests(srv *apitest.Server, method, path string) int {
+	var n int
+	for _, r := range srv.Requests() {
+		if r.Method == method && r.Path == path {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRetryIdempotentRequests(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClient(api.WithRetryPolicy(fastRetries()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := srv.Add("devices", map[string]interface{}{}, nil)
+
+	srv.Fail("GET", "devices/"+id, http.StatusServiceUnavailable, 2, nil)
+	if _, err := client.Devices.GetById(id); err != nil {
+		t.Fatalf("request failing twice: %v", err)
+	}
+	if n := countRequests(srv, "GET", "devices/"+id); n != 3 {
+		t.Errorf("%d attempts, want 3", n)
+	}
+
+	srv.Fail("GET", "devices/"+id, http.StatusBadGateway, 3, nil)
+	_, err = client.Devices.GetById(id)
+	if apiErr, ok := api.AsApiError(err); !ok || apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("request failing on every attempt = %v, want 502", err)
+	}
+	if n := countRequests(srv, "GET", "devices/"+id); n != 6 {
+		t.Errorf("%d attempts, want 3 more", n-3)
+	}
+
+	srv.Fail("GET", "devices/"+id, http.StatusInternalServerError, 1, nil)
+	if _, err := client.Devices.GetById(id); err == nil {
+		t.Error("request failing with status not retried succeeded")
+	}
+}
+
+func TestRetryOtherMethodsNeedWithRetry(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClient(api.WithRetryPolicy(fastRetries()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := srv.Add("devices", map[string]interface{}{}, nil)
+	path := "devices/" + id + "/resources/data"
+	points := []api.DataPoint{{Key: "t", Value: 1.0}}
+
+	srv.Fail("POST", path, http.StatusServiceUnavailable, 1, nil)
+	if _, err := client.Resources.WriteDataForDeviceID(id, points); err == nil {
+		t.Error("POST was retried without WithRetry")
+	}
+	srv.Fail("POST", path, http.StatusServiceUnavailable, 1, nil)
+	if _, err := client.Resources.WriteDataForDeviceIDContext(api.WithRetry(context.Background()), id, points); err != nil {
+		t.Errorf("POST with WithRetry: %v", err)
+	}
+	if n := countRequests(srv, "POST", path); n != 3 {
+		t.Errorf("%d POSTs, want 3", n)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClient(api.WithRetryPolicy(fastRetries()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := srv.Add("devices", map[string]interface{}{}, nil)
+
+	srv.Fail("GET", "devices/"+id, http.StatusTooManyRequests, 1, http.Header{"Retry-After": {"1"}})
+	start := time.Now()
+	if _, err := client.Devices.GetById(id); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d < time.Second {
+		t.Errorf("retried after %s, want Retry-After of 1s", d)
+	}
+}
+
+func TestRetryStopsWithContext(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	p := api.DefaultRetryPolicy()
+	p.MinBackoff = time.Minute
+	p.MaxBackoff = time.Minute
+	client, err := srv.NewClient(api.WithRetryPolicy(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := srv.Add("devices", map[string]interface{}{}, nil)
+
+	srv.Fail("GET", "devices/"+id, http.StatusServiceUnavailable, 1, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = client.Devices.GetByIdContext(ctx, id)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want deadline exceeded", err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("backoff wasn't interrupted, request took %s", d)
+	}
+}
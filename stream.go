@@ -0,0 +1,283 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+)
+
+// Stream decodes points of resource time series one at a time, following Next links
+// of pages transparently. Only a single point is held in memory regardless of size
+// of pages, so it is suited for long time ranges.
+//
+//	s := client.Resources.StreamDataByClusterID(ctx, clusterId, api.TimeRange(start, end))
+//	defer s.Close()
+//	for s.Next() {
+//		point := s.Value()
+//	}
+//	if err := s.Err(); err != nil {
+//	}
+type Stream[T any] struct {
+	ctx    context.Context
+	client *Client
+	args   []interface{}
+	// link to page which is requested next, empty if there are no more pages
+	link string
+	// whether the first page was already requested
+	started bool
+	// whether only the first page is requested, e.g. for the latest point
+	single bool
+
+	body    io.ReadCloser
+	dec     *json.Decoder
+	inItems bool
+	// number of points decoded from current page
+	count  int
+	params ListParams
+
+	// iterator used instead of decoder by streams created with NewStreamFromPages
+	pages *Iterator[T]
+
+	current T
+	err     error
+}
+
+func newStream[T any](ctx context.Context, client *Client, link string, args ...interface{}) *Stream[T] {
+	s := &Stream[T]{ctx: ctx, client: client, link: link, single: latestRequested(args)}
+	for _, a := range resolveTimeParams(args) {
+		if _, ok := a.(prefetchOption); ok {
+			continue
+		}
+		s.args = append(s.args, a)
+	}
+	return s
+}
+
+// NewStreamFromPages creates stream reading already decoded pages retrieved with fetch.
+// It allows implementing Stream* methods of services outside of this package.
+func NewStreamFromPages[T any](ctx context.Context, link string, fetch func(context.Context, string, ...interface{}) ([]T, *ListParams, error), args ...interface{}) *Stream[T] {
+	return &Stream[T]{ctx: ctx, pages: newIterator(ctx, link, fetch, args...)}
+}
+
+// Next advances stream to the next point. It returns false when there are no more
+// points or an error occured.
+func (s *Stream[T]) Next() bool {
+	if s.pages != nil {
+		if !s.pages.Next() {
+			s.err = s.pages.Err()
+			return false
+		}
+		s.current = s.pages.Value()
+		return true
+	}
+	for s.err == nil {
+		if s.dec == nil {
+			if s.link == "" {
+				return false
+			}
+			if err := s.open(); err != nil {
+				s.fail(err)
+				return false
+			}
+		}
+		if s.inItems {
+			if s.dec.More() {
+				var v T
+				if err := s.dec.Decode(&v); err != nil {
+					s.fail(err)
+					return false
+				}
+				s.current = v
+				s.count++
+				return true
+			}
+			// closing bracket of items
+			if _, err := s.dec.Token(); err != nil {
+				s.fail(err)
+				return false
+			}
+			s.inItems = false
+		}
+		if err := s.advance(); err != nil {
+			s.fail(err)
+		}
+	}
+	return false
+}
+
+// open requests page by link and reads beginning of response
+func (s *Stream[T]) open() error {
+	args := s.args
+	if s.started {
+		// Next links already contain query of the first request
+		args = followArgs(s.args)
+	}
+	s.started = true
+
+	resp, err := s.client.request(s.ctx, "GET", s.link, nil, args...)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return newApiError(resp, "non-ok status returned")
+	}
+	s.body = resp.Body
+	s.dec = json.NewDecoder(resp.Body)
+	s.count = 0
+	s.params = ListParams{}
+	s.link = ""
+
+	if err := expectDelim(s.dec, '{'); err != nil {
+		return err
+	}
+	return nil
+}
+
+// advance reads fields of page until items or end of page
+func (s *Stream[T]) advance() error {
+	for s.dec.More() {
+		tok, err := s.dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("unexpected %v in resources page", tok)
+		}
+		var field interface{}
+		switch key {
+		case "items":
+			tok, err := s.dec.Token()
+			if err != nil {
+				return err
+			}
+			if tok == nil {
+				continue
+			}
+			if d, ok := tok.(json.Delim); !ok || d != '[' {
+				return fmt.Errorf("unexpected %v instead of items array", tok)
+			}
+			s.inItems = true
+			return nil
+		case "href":
+			field = &s.params.Href
+		case "size":
+			field = &s.params.Size
+		case "limit":
+			field = &s.params.Limit
+		case "page":
+			field = &s.params.Page
+		case "prev":
+			field = &s.params.Prev
+		case "next":
+			field = &s.params.Next
+		default:
+			field = &json.RawMessage{}
+		}
+		if err := s.dec.Decode(field); err != nil {
+			return err
+		}
+	}
+
+	// end of page
+	if err := expectDelim(s.dec, '}'); err != nil {
+		return err
+	}
+	s.closeBody()
+	if s.count > 0 && s.params.Next != nil && !s.single {
+		s.link = s.params.Next.Href
+	}
+	return nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("unexpected %v in resources page, expected %v", tok, delim)
+	}
+	return nil
+}
+
+func (s *Stream[T]) fail(err error) {
+	s.err = err
+	s.closeBody()
+}
+
+func (s *Stream[T]) closeBody() {
+	if s.body != nil {
+		s.body.Close()
+	}
+	s.body = nil
+	s.dec = nil
+	s.inItems = false
+}
+
+// Value returns current point
+func (s *Stream[T]) Value() T {
+	return s.current
+}
+
+// Err returns error which stopped the stream, if any
+func (s *Stream[T]) Err() error {
+	return s.err
+}
+
+// ListParams returns pagination params of current page. Params following items
+// in response are available only after all points of the page were read.
+func (s *Stream[T]) ListParams() *ListParams {
+	if s.pages != nil {
+		return s.pages.ListParams()
+	}
+	p := s.params
+	return &p
+}
+
+// Close stops the stream and releases connection of current page. It is safe
+// to call Close multiple times and after all points were read.
+func (s *Stream[T]) Close() error {
+	if s.pages != nil {
+		s.pages.link = ""
+		s.pages.items = nil
+		s.pages.pending = nil
+		return nil
+	}
+	s.link = ""
+	s.closeBody()
+	return nil
+}
+
+// ForEach calls fn for every remaining point. Stream is closed when fn returns
+// an error, which is then returned.
+func (s *Stream[T]) ForEach(fn func(T) error) error {
+	defer s.Close()
+	for s.Next() {
+		if err := fn(s.Value()); err != nil {
+			return err
+		}
+	}
+	return s.err
+}
+
+// All returns iterator usable with range-over-func. Error stopping the stream is
+// yielded as the last pair. Stream is closed when the loop ends.
+func (s *Stream[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		defer s.Close()
+		for s.Next() {
+			if !yield(s.Value(), nil) {
+				return
+			}
+		}
+		if s.err != nil {
+			var zero T
+			yield(zero, s.err)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package api_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+)
+
+// rawPages serves bodies of pages of data of device "d" by page query parameter,
+// "1" when absent, passing other requests to apitest
+type rawPages struct {
+	mu       sync.Mutex
+	bodies   map[string]string
+	status   map[string]int
+	served   []string
+	queries  []url.Values
+	open     int
+	released int
+}
+
+type trackedBody struct {
+	io.Reader
+	pages *rawPages
+	once  sync.Once
+}
+
+func (b *trackedBody) Close() error {
+	b.once.Do(func() {
+		b.pages.mu.Lock()
+		b.pages.released++
+		b.pages.mu.Unlock()
+	})
+	return nil
+}
+
+func (p *rawPages) middleware(next http.RoundTripper) http.RoundTripper {
+	return api.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/devices/d/resources/data") {
+			return next.RoundTrip(req)
+		}
+		page := req.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.served = append(p.served, page)
+		p.queries = append(p.queries, req.URL.Query())
+		p.open++
+		status := p.status[page]
+		if status == 0 {
+			status = http.StatusOK
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       &trackedBody{Reader: strings.NewReader(p.bodies[page]), pages: p},
+			Request:    req,
+		}, nil
+	})
+}
+
+// unreleased returns number of bodies which weren't closed
+func (p *rawPages) unreleased() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.open - p.released
+}
+
+func (p *rawPages) pages() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.served...)
+}
+
+func (p *rawPages) query(i int) url.Values {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.queries[i]
+}
+
+func streamClient(t *testing.T, p *rawPages) (*apitest.Server, *api.Client) {
+	t.Helper()
+	srv := apitest.NewServer()
+	t.Cleanup(srv.Close)
+	client, err := srv.NewClient(api.WithMiddleware(p.middleware))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, client
+}
+
+func nextPage(srv *apitest.Server, page string) string {
+	return `{"href":"` + srv.URL + `/api/v1/devices/d/resources/data?page=` + page + `"}`
+}
+
+func values(t *testing.T, s *api.Stream[api.DataPoint]) []float64 {
+	t.Helper()
+	var res []float64
+	for s.Next() {
+		v, err := s.Value().FloatValue()
+		if err != nil {
+			t.Fatal(err)
+		}
+		res = append(res, v)
+	}
+	return res
+}
+
+func TestStreamFollowsPages(t *testing.T) {
+	p := &rawPages{bodies: map[string]string{}}
+	srv, client := streamClient(t, p)
+	// next follows items on the first page and precedes them on the second,
+	// empty items end the stream even with next link
+	p.bodies["1"] = `{"href":"x","items":[{"key":"t","value":1},{"key":"t","value":2}],"size":3,"next":` + nextPage(srv, "2") + `,"extra":{"a":[1]}}`
+	p.bodies["2"] = `{"page":2,"next":` + nextPage(srv, "3") + `,"items":[{"key":"t","value":3}],"limit":2}`
+	p.bodies["3"] = `{"page":3,"items":null,"next":` + nextPage(srv, "4") + `}`
+
+	s := client.Resources.StreamDataByDeviceID(context.Background(), "d")
+	defer s.Close()
+	got := values(t, s)
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("streamed %v, want 1, 2 and 3", got)
+	}
+	if served := p.pages(); len(served) != 3 {
+		t.Errorf("served pages %v, want 1 to 3", served)
+	}
+	if params := s.ListParams(); params.Page != 3 {
+		t.Errorf("params of last page = %+v", params)
+	}
+	if n := p.unreleased(); n != 0 {
+		t.Errorf("%d bodies not closed", n)
+	}
+}
+
+func TestStreamCloseReleasesBody(t *testing.T) {
+	p := &rawPages{bodies: map[string]string{}}
+	srv, client := streamClient(t, p)
+	p.bodies["1"] = `{"items":[{"key":"t","value":1},{"key":"t","value":2}],"next":` + nextPage(srv, "2") + `}`
+
+	s := client.Resources.StreamDataByDeviceID(context.Background(), "d")
+	if !s.Next() {
+		t.Fatal(s.Err())
+	}
+	if n := p.unreleased(); n != 1 {
+		t.Fatalf("%d bodies open while reading page, want 1", n)
+	}
+	s.Close()
+	s.Close()
+	if n := p.unreleased(); n != 0 {
+		t.Errorf("%d bodies not closed by Close", n)
+	}
+	if s.Next() {
+		t.Error("Next after Close succeeded")
+	}
+	if served := p.pages(); len(served) != 1 {
+		t.Errorf("served pages %v after Close, want only 1", served)
+	}
+}
+
+func TestStreamPageError(t *testing.T) {
+	p := &rawPages{bodies: map[string]string{}, status: map[string]int{"2": http.StatusNotFound}}
+	srv, client := streamClient(t, p)
+	p.bodies["1"] = `{"items":[{"key":"t","value":1}],"next":` + nextPage(srv, "2") + `}`
+	p.bodies["2"] = `{"code":"not_found","message":"Page expired"}`
+
+	s := client.Resources.StreamDataByDeviceID(context.Background(), "d")
+	if got := values(t, s); len(got) != 1 {
+		t.Errorf("streamed %v before error, want 1", got)
+	}
+	apiErr, ok := api.AsApiError(s.Err())
+	if !ok || apiErr.StatusCode != http.StatusNotFound || apiErr.Message != "Page expired" {
+		t.Errorf("Err() = %v, want 404 of page", s.Err())
+	}
+	if n := p.unreleased(); n != 0 {
+		t.Errorf("%d bodies not closed", n)
+	}
+
+	// malformed pages stop the stream too
+	p.bodies["1"] = `{"items":{"key":"t"}}`
+	s = client.Resources.StreamDataByDeviceID(context.Background(), "d")
+	if s.Next() || s.Err() == nil {
+		t.Error("page with items object accepted")
+	}
+}
+
+func TestStreamLatest(t *testing.T) {
+	p := &rawPages{bodies: map[string]string{}}
+	srv, client := streamClient(t, p)
+	p.bodies["1"] = `{"items":[{"key":"t","value":5}],"next":` + nextPage(srv, "2") + `}`
+
+	s := client.Resources.StreamDataByDeviceID(context.Background(), "d", &api.PointsOptions{Latest: true})
+	defer s.Close()
+	if got := values(t, s); len(got) != 1 || got[0] != 5 {
+		t.Errorf("streamed %v, want latest 5", got)
+	}
+	if served := p.pages(); len(served) != 1 {
+		t.Errorf("served pages %v, want only the first", served)
+	}
+	if q := p.query(0); q.Get("sort") != "-time" || q.Get("limit") != "1" {
+		t.Errorf("latest point requested with %s", q.Encode())
+	}
+}
@@ -0,0 +1,611 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResourceKind is a kind of resources of device or cluster
+type ResourceKind string
+
+const (
+	ResourceData     ResourceKind = "data"
+	ResourceEvents   ResourceKind = "events"
+	ResourceCommands ResourceKind = "commands"
+)
+
+// Names of transports used by Subscription
+const (
+	TransportSSE     = "sse"
+	TransportPolling = "polling"
+)
+
+// Update is a single point delivered by Subscription, only field matching Kind is set
+type Update struct {
+	Kind    ResourceKind
+	Data    *DataPoint
+	Event   *EventPoint
+	Command *CommandPoint
+}
+
+// identity returns time of point, false if it has none, and ID used for de-duplication.
+// Points are identified by kind, time and key; points without time by their content.
+func (u Update) identity() (time.Time, bool, string) {
+	var ts, key string
+	var point interface{}
+	switch {
+	case u.Data != nil:
+		ts, key, point = u.Data.Time, u.Data.Key, u.Data
+	case u.Event != nil:
+		ts, key, point = u.Event.Time, u.Event.Key, u.Event
+	case u.Command != nil:
+		ts, key, point = u.Command.Time, u.Command.Key, u.Command
+	}
+	t, err := ParsePointTime(ts)
+	if err != nil {
+		b, _ := json.Marshal(point)
+		return time.Time{}, false, fmt.Sprintf("%s||%s", u.Kind, b)
+	}
+	return t, true, fmt.Sprintf("%s|%s|%s", u.Kind, t.UTC().Format(time.RFC3339Nano), key)
+}
+
+// SubscriptionOptions configures subscriptions of Client, zero values are replaced by defaults
+type SubscriptionOptions struct {
+	// Capacity of channel of updates, 64 by default
+	Buffer int
+	// Bounds of interval of polling, which grows while there are no new points,
+	// 1s and 30s by default
+	MinPollInterval time.Duration
+	MaxPollInterval time.Duration
+	// Number of points requested by single poll, 100 by default
+	PollLimit int
+	// Maximum delay between reconnections, 30s by default
+	MaxBackoff time.Duration
+	// Whether polling is used right away, without trying the stream
+	DisableStreaming bool
+}
+
+func (o SubscriptionOptions) withDefaults() SubscriptionOptions {
+	if o.Buffer <= 0 {
+		o.Buffer = 64
+	}
+	if o.MinPollInterval <= 0 {
+		o.MinPollInterval = time.Second
+	}
+	if o.MaxPollInterval < o.MinPollInterval {
+		o.MaxPollInterval = 30 * time.Second
+		if o.MaxPollInterval < o.MinPollInterval {
+			o.MaxPollInterval = o.MinPollInterval
+		}
+	}
+	if o.PollLimit <= 0 {
+		o.PollLimit = 100
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = 30 * time.Second
+	}
+	return o
+}
+
+// WithSubscriptionOptions configures subscriptions created with Resources.Subscribe
+func WithSubscriptionOptions(opts SubscriptionOptions) Option {
+	return func(o *clientOptions) error {
+		o.subscriptions = opts
+		return nil
+	}
+}
+
+// Number of identities of delivered points remembered for de-duplication
+const subscriptionSeen = 4096
+
+// Initial delay between reconnections
+const subscriptionBackoff = 500 * time.Millisecond
+
+// Subscription delivers points written to resources of device or cluster since it was
+// created. Points are streamed with Server-Sent Events; when the server doesn't support
+// streaming, resources are polled instead. Broken connections are resumed from time of
+// the last delivered point and points delivered again are dropped.
+//
+//	sub, err := client.Resources.Subscribe(ctx, device.ResourcesLink(), api.ResourceData)
+//	defer sub.Close()
+//	for u := range sub.Updates() {
+//		fmt.Println(u.Data.Key, u.Data.Value)
+//	}
+//	if err := sub.Err(); err != nil {
+//	}
+type Subscription struct {
+	client *Client
+	link   string
+	kinds  []ResourceKind
+	opts   SubscriptionOptions
+
+	updates chan Update
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	// time of the latest delivered point of every kind
+	cursors map[ResourceKind]time.Time
+	// identities of recently delivered points, ring buffer of order is used for eviction
+	seen  map[string]bool
+	order []string
+	next  int
+
+	mu        sync.Mutex
+	transport string
+	err       error
+}
+
+// Subscribe starts delivering points of kinds, all if none, written to resources link,
+// e.g. Device.ResourcesLink() or "clusters/ID/resources". Subscription ends when ctx is
+// done, Close is called or an error which can't be recovered from occurs.
+func (s *ResourcesServiceOp) Subscribe(ctx context.Context, link string, kinds ...ResourceKind) (*Subscription, error) {
+	ctx = withOperation(ctx, "Resources", "Subscribe")
+	if len(kinds) == 0 {
+		kinds = []ResourceKind{ResourceData, ResourceEvents, ResourceCommands}
+	}
+	for _, k := range kinds {
+		switch k {
+		case ResourceData, ResourceEvents, ResourceCommands:
+		default:
+			return nil, fmt.Errorf("unknown resource kind %q", k)
+		}
+	}
+
+	opts := s.client.subscriptions.withDefaults()
+	ctx, cancel := context.WithCancel(ctx)
+	sub := &Subscription{
+		client:    s.client,
+		link:      strings.TrimSuffix(link, "/"),
+		kinds:     kinds,
+		opts:      opts,
+		updates:   make(chan Update, opts.Buffer),
+		cancel:    cancel,
+		done:      make(chan struct{}),
+		cursors:   make(map[ResourceKind]time.Time, len(kinds)),
+		seen:      make(map[string]bool),
+		order:     make([]string, subscriptionSeen),
+		transport: TransportSSE,
+	}
+	now := time.Now()
+	for _, k := range kinds {
+		sub.cursors[k] = now
+	}
+	if opts.DisableStreaming {
+		sub.transport = TransportPolling
+	}
+	go sub.run(ctx)
+	return sub, nil
+}
+
+// NewSubscriptionFromChannel returns subscription delivering updates received from
+// source until it is closed, e.g. for fakes of ResourcesService
+func NewSubscriptionFromChannel(source <-chan Update) *Subscription {
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := &Subscription{
+		updates: make(chan Update),
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	go func() {
+		defer close(sub.done)
+		defer close(sub.updates)
+		for {
+			var u Update
+			select {
+			case v, ok := <-source:
+				if !ok {
+					return
+				}
+				u = v
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case sub.updates <- u:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return sub
+}
+
+// Updates returns channel of points, it is closed when subscription ends
+func (s *Subscription) Updates() <-chan Update {
+	return s.updates
+}
+
+// Err returns error which ended subscription, nil if it was closed or its context is done
+func (s *Subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Transport returns name of transport currently used, TransportSSE or TransportPolling
+func (s *Subscription) Transport() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.transport
+}
+
+// Close ends subscription and waits until its connection is released
+func (s *Subscription) Close() error {
+	s.cancel()
+	<-s.done
+	return nil
+}
+
+func (s *Subscription) run(ctx context.Context) {
+	defer close(s.done)
+	defer close(s.updates)
+
+	var err error
+	if s.Transport() == TransportSSE {
+		err = s.stream(ctx)
+		if errors.Is(err, errStreamUnsupported) {
+			s.client.log(ctx, LevelInfo, "streaming not supported, polling resources", "link", s.link)
+			s.mu.Lock()
+			s.transport = TransportPolling
+			s.mu.Unlock()
+			err = s.poll(ctx)
+		}
+	} else {
+		err = s.poll(ctx)
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+}
+
+var errStreamUnsupported = errors.New("streaming not supported")
+
+// stream receives points with Server-Sent Events, reconnecting until ctx is done
+func (s *Subscription) stream(ctx context.Context) error {
+	backoff := subscriptionBackoff
+	for {
+		delivered, err := s.connect(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Is(err, errStreamUnsupported) || (err != nil && !subscriptionRetryable(err)) {
+			return err
+		}
+		if delivered {
+			backoff = subscriptionBackoff
+		}
+		s.client.log(ctx, LevelWarn, "subscription stream interrupted, reconnecting", "link", s.link, "error", err, "delay", backoff)
+		if !sleepContext(ctx, backoff) {
+			return ctx.Err()
+		}
+		backoff = nextDelay(backoff, s.opts.MaxBackoff)
+	}
+}
+
+// connect reads single stream until it ends, reporting whether any point was delivered
+func (s *Subscription) connect(ctx context.Context) (bool, error) {
+	since := s.since()
+	kinds := make([]string, len(s.kinds))
+	for i, k := range s.kinds {
+		kinds[i] = string(k)
+	}
+	endpoint := fmt.Sprintf("%s/stream?kinds=%s&since=%s", s.link, url.QueryEscape(strings.Join(kinds, ",")),
+		url.QueryEscape(since.UTC().Format(time.RFC3339Nano)))
+	header := http.Header{}
+	header.Set("Accept", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Last-Event-ID", since.UTC().Format(time.RFC3339Nano))
+
+	resp, err := s.client.request(withStream(withHeader(ctx, header)), "GET", endpoint, nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotAcceptable, http.StatusNotImplemented:
+		return false, errStreamUnsupported
+	default:
+		return false, newApiError(resp, "non-ok status returned")
+	}
+	if mt, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mt != "text/event-stream" {
+		return false, errStreamUnsupported
+	}
+
+	delivered := false
+	r := bufio.NewReader(resp.Body)
+	for {
+		event, data, err := readEvent(r)
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return delivered, err
+		}
+		kind := ResourceKind(event)
+		if _, ok := s.cursors[kind]; !ok || data == "" {
+			continue
+		}
+		u, err := decodeUpdate(kind, []byte(data))
+		if err != nil {
+			return delivered, err
+		}
+		if !s.deliver(ctx, u) {
+			return delivered, ctx.Err()
+		}
+		delivered = true
+	}
+}
+
+// readEvent reads single Server-Sent Event, comments and ids are skipped as resuming
+// relies on times of points
+func readEvent(r *bufio.Reader) (event, data string, err error) {
+	var lines []string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return "", "", err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if event == "" && len(lines) == 0 {
+				continue
+			}
+			return event, strings.Join(lines, "\n"), nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			lines = append(lines, value)
+		}
+	}
+}
+
+// decodeUpdate decodes point of kind
+func decodeUpdate(kind ResourceKind, data []byte) (Update, error) {
+	u := Update{Kind: kind}
+	var target interface{}
+	switch kind {
+	case ResourceData:
+		u.Data = &DataPoint{}
+		target = u.Data
+	case ResourceEvents:
+		u.Event = &EventPoint{}
+		target = u.Event
+	case ResourceCommands:
+		u.Command = &CommandPoint{}
+		target = u.Command
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return u, fmt.Errorf("decoding %s point: %w", kind, err)
+	}
+	return u, nil
+}
+
+// poll requests new points periodically, polling more often while there are new points
+func (s *Subscription) poll(ctx context.Context) error {
+	interval := s.opts.MinPollInterval
+	backoff := subscriptionBackoff
+	for {
+		delivered, more, err := s.pollOnce(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		delay := interval
+		switch {
+		case err != nil:
+			if !subscriptionRetryable(err) {
+				return err
+			}
+			s.client.log(ctx, LevelWarn, "polling resources failed, retrying", "link", s.link, "error", err, "delay", backoff)
+			delay = backoff
+			backoff = nextDelay(backoff, s.opts.MaxBackoff)
+		case more:
+			continue
+		case delivered:
+			interval, backoff = s.opts.MinPollInterval, subscriptionBackoff
+			delay = interval
+		default:
+			backoff = subscriptionBackoff
+			interval = nextDelay(interval, s.opts.MaxPollInterval)
+		}
+		if !sleepContext(ctx, delay) {
+			return ctx.Err()
+		}
+	}
+}
+
+// pollOnce requests points of every kind since its cursor. More is reported when
+// a page was full and the cursor advanced, so the next one should be requested right away.
+func (s *Subscription) pollOnce(ctx context.Context) (delivered, more bool, err error) {
+	for _, kind := range s.kinds {
+		fresh, full, err := s.pollKind(ctx, kind)
+		delivered = delivered || fresh > 0
+		if err != nil {
+			return delivered, false, err
+		}
+		more = more || full
+	}
+	return delivered, more, nil
+}
+
+// pollKind requests points of kind since its cursor. Pages in which all points share
+// time of the cursor don't advance it, so the following ones are requested until the
+// cursor advances or the points run out, even if more than PollLimit points share time.
+func (s *Subscription) pollKind(ctx context.Context, kind ResourceKind) (fresh int, more bool, err error) {
+	cursor := s.cursors[kind]
+	for page := 1; ; page++ {
+		updates, err := s.fetch(ctx, kind, cursor, page)
+		if err != nil {
+			return fresh, false, err
+		}
+		for _, u := range updates {
+			if !s.isNew(u) {
+				continue
+			}
+			if !s.deliver(ctx, u) {
+				return fresh, false, ctx.Err()
+			}
+			fresh++
+		}
+		if len(updates) < s.opts.PollLimit {
+			return fresh, false, nil
+		}
+		if s.cursors[kind].After(cursor) {
+			return fresh, true, nil
+		}
+	}
+}
+
+// fetch requests page of points of kind since cursor, oldest first
+func (s *Subscription) fetch(ctx context.Context, kind ResourceKind, cursor time.Time, page int) ([]Update, error) {
+	link := s.link + "/" + string(kind)
+	filters := []interface{}{
+		&TimeParams{Start: &cursor, Order: OrderAscending},
+		&ListOptions{Limit: s.opts.PollLimit, Page: page},
+	}
+	var updates []Update
+	switch kind {
+	case ResourceData:
+		points, _, err := s.client.conv.resources.GetDataByLinkContext(ctx, link, filters...)
+		if err != nil {
+			return nil, err
+		}
+		for i := range points {
+			updates = append(updates, Update{Kind: kind, Data: &points[i]})
+		}
+	case ResourceEvents:
+		points, _, err := s.client.conv.resources.GetEventsByLinkContext(ctx, link, filters...)
+		if err != nil {
+			return nil, err
+		}
+		for i := range points {
+			updates = append(updates, Update{Kind: kind, Event: &points[i]})
+		}
+	case ResourceCommands:
+		points, _, err := s.client.conv.resources.GetCommandsByLinkContext(ctx, link, filters...)
+		if err != nil {
+			return nil, err
+		}
+		for i := range points {
+			updates = append(updates, Update{Kind: kind, Command: &points[i]})
+		}
+	}
+	return updates, nil
+}
+
+// since returns time from which stream is resumed, the earliest cursor of all kinds
+func (s *Subscription) since() time.Time {
+	var since time.Time
+	for _, t := range s.cursors {
+		if since.IsZero() || t.Before(since) {
+			since = t
+		}
+	}
+	return since
+}
+
+// isNew checks whether point wasn't delivered yet
+func (s *Subscription) isNew(u Update) bool {
+	_, _, id := u.identity()
+	return !s.seen[id]
+}
+
+// deliver sends new point to channel and advances cursor of its kind, points
+// delivered before are dropped. It returns false if ctx is done first.
+func (s *Subscription) deliver(ctx context.Context, u Update) bool {
+	t, timed, id := u.identity()
+	if s.seen[id] {
+		return true
+	}
+	if old := s.order[s.next]; old != "" {
+		delete(s.seen, old)
+	}
+	s.order[s.next] = id
+	s.next = (s.next + 1) % len(s.order)
+	s.seen[id] = true
+
+	select {
+	case s.updates <- u:
+	case <-ctx.Done():
+		return false
+	}
+	if timed && t.After(s.cursors[u.Kind]) {
+		s.cursors[u.Kind] = t
+	}
+	return true
+}
+
+// subscriptionRetryable checks whether subscription may recover from err
+func subscriptionRetryable(err error) bool {
+	var apiErr ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusRequestTimeout || apiErr.StatusCode == http.StatusTooManyRequests ||
+			apiErr.StatusCode >= 500
+	}
+	return true
+}
+
+// nextDelay doubles d up to max
+func nextDelay(d, max time.Duration) time.Duration {
+	if d *= 2; d > max {
+		return max
+	}
+	return d
+}
+
+type headerKey struct{}
+
+// withHeader returns context whose requests are sent with header, replacing default values
+func withHeader(ctx context.Context, header http.Header) context.Context {
+	return context.WithValue(ctx, headerKey{}, header)
+}
+
+func headerFromContext(ctx context.Context) http.Header {
+	h, _ := ctx.Value(headerKey{}).(http.Header)
+	return h
+}
+
+type streamKey struct{}
+
+// withStream returns context marking its requests as long-lived streams, which
+// aren't limited by timeout of Client
+func withStream(ctx context.Context) context.Context {
+	return context.WithValue(ctx, streamKey{}, true)
+}
+
+func isStream(ctx context.Context) bool {
+	stream, _ := ctx.Value(streamKey{}).(bool)
+	return stream
+}
+
+// sleepContext waits for d, it returns false if ctx is done first
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,208 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+)
+
+// receive reads n updates of sub, failing if they don't arrive in time
+func receive(t *testing.T, sub *api.Subscription, n int) []api.Update {
+	t.Helper()
+	var updates []api.Update
+	timeout := time.After(5 * time.Second)
+	for len(updates) < n {
+		select {
+		case u, ok := <-sub.Updates():
+			if !ok {
+				t.Fatalf("subscription ended after %d of %d updates: %v", len(updates), n, sub.Err())
+			}
+			updates = append(updates, u)
+		case <-timeout:
+			t.Fatalf("received %d of %d updates", len(updates), n)
+		}
+	}
+	return updates
+}
+
+// expectNothing fails if sub delivers update within d
+func expectNothing(t *testing.T, sub *api.Subscription, d time.Duration) {
+	t.Helper()
+	select {
+	case u, ok := <-sub.Updates():
+		if ok {
+			t.Errorf("unexpected update %+v", u.Data)
+		}
+	case <-time.After(d):
+	}
+}
+
+// points returns data points of keys, all at time at
+func points(at time.Time, keys ...string) []api.DataPoint {
+	var res []api.DataPoint
+	for _, k := range keys {
+		res = append(res, api.DataPoint{Key: k, Value: 1.0, Time: at.UTC().Format(time.RFC3339Nano)})
+	}
+	return res
+}
+
+func subscribeDevice(t *testing.T, srv *apitest.Server, opts ...api.Option) (*api.Client, string, *api.Subscription) {
+	t.Helper()
+	client, err := srv.NewClient(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := srv.Add("devices", map[string]interface{}{}, nil)
+	sub, err := client.Resources.Subscribe(context.Background(), "devices/"+id+"/resources", api.ResourceData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sub.Close() })
+	// stream is connected before points are written
+	time.Sleep(50 * time.Millisecond)
+	return client, id, sub
+}
+
+func TestSubscribeStream(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	// streams outlive client's timeout of requests
+	client, id, sub := subscribeDevice(t, srv, api.WithTimeout(200*time.Millisecond))
+
+	time.Sleep(300 * time.Millisecond)
+	at := time.Now().Add(time.Second)
+	if _, err := client.Resources.WriteDataForDeviceID(id, points(at, "a", "b", "c")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Resources.WriteDataForDeviceID(id, points(at.Add(time.Second), "a")); err != nil {
+		t.Fatal(err)
+	}
+	updates := receive(t, sub, 4)
+	if sub.Transport() != api.TransportSSE {
+		t.Errorf("transport = %s, want sse", sub.Transport())
+	}
+	for _, u := range updates {
+		if u.Kind != api.ResourceData || u.Data == nil {
+			t.Errorf("update = %+v, want data point", u)
+		}
+	}
+	expectNothing(t, sub, 100*time.Millisecond)
+
+	var streams int
+	for _, r := range srv.Requests() {
+		if r.Path == "devices/"+id+"/resources/stream" {
+			streams++
+		}
+	}
+	if streams != 1 {
+		t.Errorf("stream connected %d times, want once", streams)
+	}
+}
+
+func TestSubscribeResumesStream(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, id, sub := subscribeDevice(t, srv)
+
+	at := time.Now().Add(time.Second)
+	if _, err := client.Resources.WriteDataForDeviceID(id, points(at, "a", "b")); err != nil {
+		t.Fatal(err)
+	}
+	receive(t, sub, 2)
+
+	srv.DropStreams()
+	if _, err := client.Resources.WriteDataForDeviceID(id, points(at, "c")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Resources.WriteDataForDeviceID(id, points(at.Add(time.Second), "a")); err != nil {
+		t.Fatal(err)
+	}
+	// points since the last delivered one are sent again, but only new ones are delivered
+	updates := receive(t, sub, 2)
+	if updates[0].Data.Key != "c" || updates[1].Data.Key != "a" {
+		t.Errorf("updates after reconnecting are %s and %s, want c and a", updates[0].Data.Key, updates[1].Data.Key)
+	}
+	expectNothing(t, sub, 100*time.Millisecond)
+}
+
+func TestSubscribePollsEqualTimes(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	srv.DisableStreaming = true
+	client, id, sub := subscribeDevice(t, srv, api.WithSubscriptionOptions(api.SubscriptionOptions{
+		MinPollInterval: 10 * time.Millisecond,
+		PollLimit:       2,
+	}))
+
+	at := time.Now().Add(time.Second)
+	if _, err := client.Resources.WriteDataForDeviceID(id, points(at, "a", "b", "c", "d", "e")); err != nil {
+		t.Fatal(err)
+	}
+	receive(t, sub, 5)
+	if _, err := client.Resources.WriteDataForDeviceID(id, points(at.Add(time.Second), "a")); err != nil {
+		t.Fatal(err)
+	}
+	updates := receive(t, sub, 1)
+	if updates[0].Data.Key != "a" {
+		t.Errorf("update after equal times is %s, want later a", updates[0].Data.Key)
+	}
+	if sub.Transport() != api.TransportPolling {
+		t.Errorf("transport = %s, want polling", sub.Transport())
+	}
+	expectNothing(t, sub, 100*time.Millisecond)
+}
+
+func TestSubscribeMissingResource(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub, err := client.Resources.Subscribe(context.Background(), "devices/missing/resources")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	select {
+	case _, ok := <-sub.Updates():
+		if ok {
+			t.Fatal("update of missing device")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription of missing device didn't end")
+	}
+	if apiErr, ok := api.AsApiError(sub.Err()); !ok || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Err() = %v, want 404", sub.Err())
+	}
+}
+
+func TestSubscriptionFromChannelClose(t *testing.T) {
+	source := make(chan api.Update)
+	sub := api.NewSubscriptionFromChannel(source)
+
+	go func() { source <- api.Update{Kind: api.ResourceData, Data: &api.DataPoint{Key: "a"}} }()
+	if u := receive(t, sub, 1); u[0].Data.Key != "a" {
+		t.Errorf("update = %+v, want a", u[0].Data)
+	}
+
+	// source stays open and idle
+	closed := make(chan struct{})
+	go func() {
+		sub.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close blocked on idle source")
+	}
+	if _, ok := <-sub.Updates(); ok {
+		t.Error("updates not closed by Close")
+	}
+}
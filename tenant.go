@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -14,9 +15,9 @@ import (
 
 type TenantService interface {
 	Get() (*Tenant, error)
+	GetContext(context.Context) (*Tenant, error)
 	UpdateByLink(string, *TenantRequestUpdate) (*Tenant, error)
-
-	get(*TenantResponse) (*Tenant, error)
+	UpdateByLinkContext(context.Context, string, *TenantRequestUpdate) (*Tenant, error)
 }
 
 // TenantServiceOp handles communication with Tenant related methods of API
@@ -104,14 +105,20 @@ func (t *Tenant) Save() error {
 
 // Get retrieves current tenant
 func (s *TenantServiceOp) Get() (*Tenant, error) {
+	return s.GetContext(context.Background())
+}
+
+// GetContext is like Get but uses ctx for the underlying request.
+func (s *TenantServiceOp) GetContext(ctx context.Context) (*Tenant, error) {
+	ctx = withOperation(ctx, "Tenant", "Get")
 	endpoint := "tenants/"
-	if s.client.tenantId == "" {
+	if s.client.currentTenantId(ctx) == "" {
 		endpoint = fmt.Sprintf("%s%s", endpoint, "current")
 	} else {
-		endpoint = fmt.Sprintf("%s%s", endpoint, s.client.tenantId)
+		endpoint = fmt.Sprintf("%s%s", endpoint, s.client.currentTenantId(ctx))
 	}
 
-	resp, err := s.client.request("GET", endpoint, nil)
+	resp, err := s.client.request(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -121,16 +128,16 @@ func (s *TenantServiceOp) Get() (*Tenant, error) {
 	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnauthorized {
 		// this is probably due to redirect
 		endpoint = resp.Request.URL.String()
-		resp, err = s.client.request("GET", endpoint, nil)
+		resp, err = s.client.request(ctx, "GET", endpoint, nil)
 		if err != nil {
 			return nil, err
 		}
 		defer resp.Body.Close()
 		if resp.StatusCode != http.StatusOK {
-			return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+			return nil, newApiError(resp, "non-ok status returned")
 		}
 	} else if resp.StatusCode != http.StatusOK {
-		return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, newApiError(resp, "non-ok status returned")
 	}
 	tenant := &TenantResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -138,15 +145,69 @@ func (s *TenantServiceOp) Get() (*Tenant, error) {
 	return s.get(tenant)
 }
 
-func (s *TenantServiceOp) get(t *TenantResponse) (*Tenant, error) {
+func (s *TenantServiceOp) get(r *TenantResponse) (*Tenant, error) {
 	obj := &Tenant{}
-	copier.Copy(obj, t)
+	copier.Copy(obj, r)
+	if v, ok := r.Directories["href"]; ok {
+		obj.directories = v.(string)
+	}
+	if v, ok := r.Applications["href"]; ok {
+		obj.applications = v.(string)
+	}
+	if v, ok := r.Products["href"]; ok {
+		obj.products = v.(string)
+	}
+	if len(r.Directories) > 1 {
+		bytes, err := json.Marshal(r.Directories)
+		if err != nil {
+			return nil, err
+		}
+		ten := &DirectoriesResponse{}
+		json.Unmarshal(bytes, ten)
+		t, _, err := s.client.conv.directories.getCollection(ten)
+		if err != nil {
+			return nil, err
+		}
+		obj.Directories = t
+	}
+	if len(r.Applications) > 1 {
+		bytes, err := json.Marshal(r.Applications)
+		if err != nil {
+			return nil, err
+		}
+		ten := &ApplicationsResponse{}
+		json.Unmarshal(bytes, ten)
+		t, _, err := s.client.conv.applications.getCollection(ten)
+		if err != nil {
+			return nil, err
+		}
+		obj.Applications = t
+	}
+	if len(r.Products) > 1 {
+		bytes, err := json.Marshal(r.Products)
+		if err != nil {
+			return nil, err
+		}
+		ten := &ProductsResponse{}
+		json.Unmarshal(bytes, ten)
+		t, _, err := s.client.conv.products.getCollection(ten)
+		if err != nil {
+			return nil, err
+		}
+		obj.Products = t
+	}
 	obj.service = s
 	return obj, nil
 }
 
 // Update updates tenant
 func (s *TenantServiceOp) UpdateByLink(endpoint string, t *TenantRequestUpdate) (*Tenant, error) {
+	return s.UpdateByLinkContext(context.Background(), endpoint, t)
+}
+
+// UpdateByLinkContext is like UpdateByLink but uses ctx for the underlying request.
+func (s *TenantServiceOp) UpdateByLinkContext(ctx context.Context, endpoint string, t *TenantRequestUpdate) (*Tenant, error) {
+	ctx = withOperation(ctx, "Tenant", "UpdateByLink")
 	enc, err := json.Marshal(t)
 	if err != nil {
 		return nil, err
@@ -154,7 +215,7 @@ func (s *TenantServiceOp) UpdateByLink(endpoint string, t *TenantRequestUpdate)
 
 	buf := bytes.NewBuffer(enc)
 
-	resp, err := s.client.request("POST", endpoint, buf)
+	resp, err := s.client.request(ctx, "POST", endpoint, buf)
 	if err != nil {
 		return nil, err
 	}
@@ -162,7 +223,7 @@ func (s *TenantServiceOp) UpdateByLink(endpoint string, t *TenantRequestUpdate)
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, newApiError(resp, "non-ok status returned")
 	}
 	tenant := &Tenant{}
 	dec := json.NewDecoder(resp.Body)
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrInvalidTimeRange is returned for TimeParams which can't match any point
+var ErrInvalidTimeRange = errors.New("invalid time range")
+
+// TimeParams limits points of resources requests to a time range. Both bounds are
+// optional and inclusive unless excluded. The range may be relative to time of
+// request, e.g. the last 15 minutes:
+//
+//	points, _, err := client.Resources.GetDataByDeviceID(id, &api.TimeParams{Last: 15 * time.Minute})
+//
+// Relative ranges are resolved once per call, so all pages of Iterate* and Stream*
+// methods share the same range.
+type TimeParams struct {
+	Start *time.Time
+	End   *time.Time
+	// Length of range ending at End, or at time of request if End is nil.
+	// It can't be combined with Start.
+	Last time.Duration
+
+	// Whether points exactly at Start or End are excluded
+	ExcludeStart bool
+	ExcludeEnd   bool
+
+	// Time zone in which bounds are sent, UTC if nil
+	Location *time.Location
+	// Order of points, unless set by PointsOptions passed with the same request
+	Order PointsOrder
+}
+
+// TimeRange returns params of range [start, end]
+func TimeRange(start, end time.Time) *TimeParams {
+	return &TimeParams{Start: &start, End: &end}
+}
+
+// Since returns params of range starting at t
+func Since(t time.Time) *TimeParams {
+	return &TimeParams{Start: &t}
+}
+
+// Until returns params of range ending at t
+func Until(t time.Time) *TimeParams {
+	return &TimeParams{End: &t}
+}
+
+// Last returns params of range of length d ending at time of request
+func Last(d time.Duration) *TimeParams {
+	return &TimeParams{Last: d}
+}
+
+// Validate checks whether range may contain any point
+func (t *TimeParams) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if t.Last < 0 {
+		return fmt.Errorf("%w: negative length %s", ErrInvalidTimeRange, t.Last)
+	}
+	if t.Last > 0 && t.Start != nil {
+		return fmt.Errorf("%w: both start and relative length set", ErrInvalidTimeRange)
+	}
+	if t.Start == nil || t.End == nil {
+		return nil
+	}
+	switch c := t.Start.Compare(*t.End); {
+	case c > 0:
+		return fmt.Errorf("%w: start %s is after end %s", ErrInvalidTimeRange, t.Start.Format(time.RFC3339Nano), t.End.Format(time.RFC3339Nano))
+	case c == 0 && (t.ExcludeStart || t.ExcludeEnd):
+		return fmt.Errorf("%w: start equals end and one of them is excluded", ErrInvalidTimeRange)
+	}
+	return nil
+}
+
+// Bounds returns absolute bounds of range, relative range is resolved against now.
+// Nil is returned for open bounds.
+func (t *TimeParams) Bounds(now time.Time) (start, end *time.Time) {
+	if t == nil {
+		return nil, nil
+	}
+	start, end = t.Start, t.End
+	if t.Last > 0 {
+		e := now
+		if end != nil {
+			e = *end
+		}
+		s := e.Add(-t.Last)
+		start, end = &s, &e
+	}
+	return start, end
+}
+
+// resolve returns copy of params with relative range replaced by absolute bounds
+func (t *TimeParams) resolve(now time.Time) *TimeParams {
+	if t == nil || t.Last == 0 {
+		return t
+	}
+	r := *t
+	r.Start, r.End = t.Bounds(now)
+	r.Last = 0
+	return &r
+}
+
+// resolveTimeParams replaces relative TimeParams in args with absolute ones
+func resolveTimeParams(args []interface{}) []interface{} {
+	now := time.Now()
+	res := make([]interface{}, len(args))
+	for i, a := range args {
+		if t, ok := a.(*TimeParams); ok {
+			a = t.resolve(now)
+		}
+		res[i] = a
+	}
+	return res
+}
+
+// String formats params as query. Inclusive bounds are sent as start and end,
+// excluded ones as filters of time, e.g. time[gt]. Order is sent by requests
+// along with PointsOptions, so it isn't included.
+func (t *TimeParams) String() string {
+	if t == nil {
+		return ""
+	}
+	start, end := t.Bounds(time.Now())
+	loc := t.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	format := func(v time.Time) string {
+		return url.QueryEscape(v.In(loc).Format(time.RFC3339Nano))
+	}
+
+	var params []string
+	if start != nil {
+		if t.ExcludeStart {
+			params = append(params, "time[gt]="+format(*start))
+		} else {
+			params = append(params, "start="+format(*start))
+		}
+	}
+	if end != nil {
+		if t.ExcludeEnd {
+			params = append(params, "time[lt]="+format(*end))
+		} else {
+			params = append(params, "end="+format(*end))
+		}
+	}
+	if loc != time.UTC {
+		params = append(params, "tz="+url.QueryEscape(loc.String()))
+	}
+	return strings.Join(params, "&")
+}
@@ -0,0 +1,170 @@
+package api_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+)
+
+// timedDevice adds device with data "t" of values 0 to 4 written a minute apart since start
+func timedDevice(t *testing.T, srv *apitest.Server) (*api.Client, string, time.Time) {
+	t.Helper()
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := srv.Add("devices", map[string]interface{}{}, nil)
+	start := time.Now().UTC().Truncate(time.Minute).Add(-10 * time.Minute)
+	var data []api.DataPoint
+	for i := 0; i < 5; i++ {
+		data = append(data, api.NewDataPoint("t", float64(i), start.Add(time.Duration(i)*time.Minute)))
+	}
+	if _, err := client.Resources.WriteDataForDeviceID(id, data); err != nil {
+		t.Fatal(err)
+	}
+	return client, id, start
+}
+
+func TestTimeParamsBounds(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, id, start := timedDevice(t, srv)
+	at := func(i int) *time.Time {
+		v := start.Add(time.Duration(i) * time.Minute)
+		return &v
+	}
+
+	tests := []struct {
+		name   string
+		params *api.TimeParams
+		query  map[string]string
+		values []float64
+	}{
+		{"open end", api.Since(*at(3)), map[string]string{"start": at(3).Format(time.RFC3339Nano)}, []float64{3, 4}},
+		{"open start", api.Until(*at(1)), map[string]string{"end": at(1).Format(time.RFC3339Nano)}, []float64{0, 1}},
+		{"excluded", &api.TimeParams{Start: at(1), End: at(3), ExcludeStart: true, ExcludeEnd: true},
+			map[string]string{"time[gt]": at(1).Format(time.RFC3339Nano), "time[lt]": at(3).Format(time.RFC3339Nano)}, []float64{2}},
+		{"relative to end", &api.TimeParams{End: at(4), Last: 2 * time.Minute},
+			map[string]string{"start": at(2).Format(time.RFC3339Nano), "end": at(4).Format(time.RFC3339Nano)}, []float64{2, 3, 4}},
+	}
+	for _, tt := range tests {
+		points, _, err := client.Resources.GetDataByDeviceID(id, tt.params, &api.PointsOptions{Order: api.OrderAscending})
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		reqs := srv.Requests()
+		query := reqs[len(reqs)-1].Query
+		for k, v := range tt.query {
+			if query.Get(k) != v {
+				t.Errorf("%s: query %s = %q, want %q", tt.name, k, query.Get(k), v)
+			}
+		}
+		for _, k := range []string{"start", "end", "time[gt]", "time[lt]"} {
+			if _, ok := tt.query[k]; !ok && query.Has(k) {
+				t.Errorf("%s: unexpected %s in query", tt.name, k)
+			}
+		}
+		var values []float64
+		for _, p := range points {
+			v, _ := p.FloatValue()
+			values = append(values, v)
+		}
+		if len(values) != len(tt.values) {
+			t.Errorf("%s: got values %v, want %v", tt.name, values, tt.values)
+			continue
+		}
+		for i := range values {
+			if values[i] != tt.values[i] {
+				t.Errorf("%s: got values %v, want %v", tt.name, values, tt.values)
+				break
+			}
+		}
+	}
+
+	// relative range ends at time of request
+	before := time.Now()
+	if _, _, err := client.Resources.GetDataByDeviceID(id, api.Last(15*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.Requests()
+	query := reqs[len(reqs)-1].Query
+	from, _ := time.Parse(time.RFC3339Nano, query.Get("start"))
+	to, _ := time.Parse(time.RFC3339Nano, query.Get("end"))
+	if to.Sub(from) != 15*time.Minute || to.Before(before) || to.After(time.Now()) {
+		t.Errorf("relative range sent as [%s, %s]", query.Get("start"), query.Get("end"))
+	}
+
+	loc := time.FixedZone("CET", 3600)
+	if _, _, err := client.Resources.GetDataByDeviceID(id, &api.TimeParams{Start: at(0), Location: loc}); err != nil {
+		t.Fatal(err)
+	}
+	reqs = srv.Requests()
+	query = reqs[len(reqs)-1].Query
+	if query.Get("start") != at(0).In(loc).Format(time.RFC3339Nano) || query.Get("tz") != "CET" {
+		t.Errorf("start %s in zone %s, want CET", query.Get("start"), query.Get("tz"))
+	}
+}
+
+func TestTimeParamsOrder(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, id, start := timedDevice(t, srv)
+	rng := &api.TimeParams{Start: &start, Order: api.OrderAscending}
+
+	tests := []struct {
+		name  string
+		args  []interface{}
+		sort  string
+		first float64
+	}{
+		{"time params", []interface{}{rng}, "time", 0},
+		{"points options", []interface{}{rng, &api.PointsOptions{Order: api.OrderDescending}}, "-time", 4},
+		{"latest", []interface{}{rng, &api.PointsOptions{Latest: true}}, "-time", 4},
+	}
+	for _, tt := range tests {
+		points, _, err := client.Resources.GetDataByDeviceID(id, tt.args...)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		reqs := srv.Requests()
+		if sort := reqs[len(reqs)-1].Query["sort"]; len(sort) != 1 || sort[0] != tt.sort {
+			t.Errorf("%s: sort = %v, want single %s", tt.name, sort, tt.sort)
+		}
+		if v, _ := points[0].FloatValue(); v != tt.first {
+			t.Errorf("%s: first point is %v, want %v", tt.name, v, tt.first)
+		}
+	}
+}
+
+func TestTimeParamsValidate(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, id, start := timedDevice(t, srv)
+	end := start.Add(-time.Minute)
+
+	tests := map[string]*api.TimeParams{
+		"start after end":    api.TimeRange(start, end),
+		"negative last":      api.Last(-time.Minute),
+		"last with start":    {Start: &start, Last: time.Minute},
+		"excluded equal end": {Start: &start, End: &start, ExcludeEnd: true},
+	}
+	sent := len(srv.Requests())
+	for name, params := range tests {
+		if err := params.Validate(); !errors.Is(err, api.ErrInvalidTimeRange) {
+			t.Errorf("%s: Validate() = %v, want ErrInvalidTimeRange", name, err)
+		}
+		if _, _, err := client.Resources.GetDataByDeviceID(id, params); !errors.Is(err, api.ErrInvalidTimeRange) {
+			t.Errorf("%s: request = %v, want ErrInvalidTimeRange", name, err)
+		}
+	}
+	if n := len(srv.Requests()) - sent; n != 0 {
+		t.Errorf("%d requests with invalid ranges sent", n)
+	}
+	if err := api.TimeRange(start, start).Validate(); err != nil {
+		t.Errorf("range of single instant = %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -13,19 +14,29 @@ import (
 // https://tenant-name.cloudthing.io/api/v1/usergroups
 type UsergroupsService interface {
 	GetById(string, ...interface{}) (*Usergroup, error)
+	GetByIdContext(context.Context, string, ...interface{}) (*Usergroup, error)
 	GetByLink(string, ...interface{}) (*Usergroup, error)
+	GetByLinkContext(context.Context, string, ...interface{}) (*Usergroup, error)
 	ListByLink(string, ...interface{}) ([]Usergroup, *ListParams, error)
+	ListByLinkContext(context.Context, string, ...interface{}) ([]Usergroup, *ListParams, error)
+	IterateByLink(context.Context, string, ...interface{}) *Iterator[Usergroup]
 	ListByDirectory(string, ...interface{}) ([]Usergroup, *ListParams, error)
+	ListByDirectoryContext(context.Context, string, ...interface{}) ([]Usergroup, *ListParams, error)
+	IterateByDirectory(context.Context, string, ...interface{}) *Iterator[Usergroup]
 	CreateByLink(string, *UsergroupRequestCreate) (*Usergroup, error)
+	CreateByLinkContext(context.Context, string, *UsergroupRequestCreate) (*Usergroup, error)
 	CreateByDirectory(string, *UsergroupRequestCreate) (*Usergroup, error)
+	CreateByDirectoryContext(context.Context, string, *UsergroupRequestCreate) (*Usergroup, error)
 	UpdateById(string, *UsergroupRequestUpdate) (*Usergroup, error)
+	UpdateByIdContext(context.Context, string, *UsergroupRequestUpdate) (*Usergroup, error)
 	UpdateByLink(string, *UsergroupRequestUpdate) (*Usergroup, error)
+	UpdateByLinkContext(context.Context, string, *UsergroupRequestUpdate) (*Usergroup, error)
 	Delete(*Usergroup) error
+	DeleteContext(context.Context, *Usergroup) error
 	DeleteByLink(string) error
+	DeleteByLinkContext(context.Context, string) error
 	DeleteById(string) error
-
-	get(*UsergroupResponse) (*Usergroup, error)
-	getCollection(*UsergroupsResponse) ([]Usergroup, *ListParams, error)
+	DeleteByIdContext(context.Context, string) error
 }
 
 // UsergroupsServiceOp handles communication with Usergroups related methods of API
@@ -154,15 +165,27 @@ func (t *Usergroup) Delete() error {
 
 // GetById retrieves apikey by its ID
 func (s *UsergroupsServiceOp) GetById(id string, args ...interface{}) (*Usergroup, error) {
+	return s.GetByIdContext(context.Background(), id, args...)
+}
+
+// GetByIdContext is like GetById but uses ctx for the underlying request.
+func (s *UsergroupsServiceOp) GetByIdContext(ctx context.Context, id string, args ...interface{}) (*Usergroup, error) {
+	ctx = withOperation(ctx, "Usergroups", "GetById")
 	endpoint := "usergroups/"
 	endpoint = fmt.Sprintf("%s%s", endpoint, id)
 
-	return s.GetByLink(endpoint, args...)
+	return s.GetByLinkContext(ctx, endpoint, args...)
 }
 
 // GetById retrieves apikey by its full link
 func (s *UsergroupsServiceOp) GetByLink(endpoint string, args ...interface{}) (*Usergroup, error) {
-	resp, err := s.client.request("GET", endpoint, nil, args...)
+	return s.GetByLinkContext(context.Background(), endpoint, args...)
+}
+
+// GetByLinkContext is like GetByLink but uses ctx for the underlying request.
+func (s *UsergroupsServiceOp) GetByLinkContext(ctx context.Context, endpoint string, args ...interface{}) (*Usergroup, error) {
+	ctx = withOperation(ctx, "Usergroups", "GetByLink")
+	resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -170,7 +193,7 @@ func (s *UsergroupsServiceOp) GetByLink(endpoint string, args ...interface{}) (*
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &UsergroupResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -202,7 +225,7 @@ func (s *UsergroupsServiceOp) get(r *UsergroupResponse) (*Usergroup, error) {
 		}
 		ten := &TenantResponse{}
 		json.Unmarshal(bytes, ten)
-		t, err := s.client.Tenant.get(ten)
+		t, err := s.client.conv.tenant.get(ten)
 		if err != nil {
 			return nil, err
 		}
@@ -215,7 +238,7 @@ func (s *UsergroupsServiceOp) get(r *UsergroupResponse) (*Usergroup, error) {
 		}
 		ten := &UsersResponse{}
 		json.Unmarshal(bytes, ten)
-		t, _, err := s.client.Users.getCollection(ten)
+		t, _, err := s.client.conv.users.getCollection(ten)
 		if err != nil {
 			return nil, err
 		}
@@ -228,12 +251,25 @@ func (s *UsergroupsServiceOp) get(r *UsergroupResponse) (*Usergroup, error) {
 		}
 		ten := &DirectoryResponse{}
 		json.Unmarshal(bytes, ten)
-		t, err := s.client.Directories.get(ten)
+		t, err := s.client.conv.directories.get(ten)
 		if err != nil {
 			return nil, err
 		}
 		obj.Directory = t
 	}
+	if len(r.Memberships) > 1 {
+		bytes, err := json.Marshal(r.Memberships)
+		if err != nil {
+			return nil, err
+		}
+		ten := &MembershipsResponse{}
+		json.Unmarshal(bytes, ten)
+		t, _, err := s.client.conv.memberships.getCollection(ten)
+		if err != nil {
+			return nil, err
+		}
+		obj.Memberships = t
+	}
 	obj.service = s
 	return obj, nil
 }
@@ -262,13 +298,32 @@ func (s *UsergroupsServiceOp) getCollection(r *UsergroupsResponse) ([]Usergroup,
 
 // GetById retrieves collection of usergroups of current tenant
 func (s *UsergroupsServiceOp) ListByDirectory(id string, args ...interface{}) ([]Usergroup, *ListParams, error) {
+	return s.ListByDirectoryContext(context.Background(), id, args...)
+}
+
+// ListByDirectoryContext is like ListByDirectory but uses ctx for the underlying request.
+func (s *UsergroupsServiceOp) ListByDirectoryContext(ctx context.Context, id string, args ...interface{}) ([]Usergroup, *ListParams, error) {
+	ctx = withOperation(ctx, "Usergroups", "ListByDirectory")
+	endpoint := fmt.Sprintf("directories/%s/usergroups", id)
+	return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// IterateByDirectory is like ListByDirectory but returns iterator walking over all pages
+func (s *UsergroupsServiceOp) IterateByDirectory(ctx context.Context, id string, args ...interface{}) *Iterator[Usergroup] {
+	ctx = withOperation(ctx, "Usergroups", "IterateByDirectory")
 	endpoint := fmt.Sprintf("directories/%s/usergroups", id)
-	return s.ListByLink(endpoint, args...)
+	return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of usergroups by link
 func (s *UsergroupsServiceOp) ListByLink(endpoint string, args ...interface{}) ([]Usergroup, *ListParams, error) {
-	resp, err := s.client.request("GET", endpoint, nil, args...)
+	return s.ListByLinkContext(context.Background(), endpoint, args...)
+}
+
+// ListByLinkContext is like ListByLink but uses ctx for the underlying request.
+func (s *UsergroupsServiceOp) ListByLinkContext(ctx context.Context, endpoint string, args ...interface{}) ([]Usergroup, *ListParams, error) {
+	ctx = withOperation(ctx, "Usergroups", "ListByLink")
+	resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -276,7 +331,7 @@ func (s *UsergroupsServiceOp) ListByLink(endpoint string, args ...interface{}) (
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &UsergroupsResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -285,14 +340,32 @@ func (s *UsergroupsServiceOp) ListByLink(endpoint string, args ...interface{}) (
 	return s.getCollection(obj)
 }
 
+// IterateByLink is like ListByLink but returns iterator walking over all pages
+func (s *UsergroupsServiceOp) IterateByLink(ctx context.Context, endpoint string, args ...interface{}) *Iterator[Usergroup] {
+	ctx = withOperation(ctx, "Usergroups", "IterateByLink")
+	return newIterator(ctx, endpoint, s.ListByLinkContext, args...)
+}
+
 // GetById updates apikey with specified ID
 func (s *UsergroupsServiceOp) UpdateById(id string, t *UsergroupRequestUpdate) (*Usergroup, error) {
+	return s.UpdateByIdContext(context.Background(), id, t)
+}
+
+// UpdateByIdContext is like UpdateById but uses ctx for the underlying request.
+func (s *UsergroupsServiceOp) UpdateByIdContext(ctx context.Context, id string, t *UsergroupRequestUpdate) (*Usergroup, error) {
+	ctx = withOperation(ctx, "Usergroups", "UpdateById")
 	endpoint := fmt.Sprintf("usergroups/%s", id)
-	return s.UpdateByLink(endpoint, t)
+	return s.UpdateByLinkContext(ctx, endpoint, t)
 }
 
 // GetById updates apikey specified by link
 func (s *UsergroupsServiceOp) UpdateByLink(endpoint string, t *UsergroupRequestUpdate) (*Usergroup, error) {
+	return s.UpdateByLinkContext(context.Background(), endpoint, t)
+}
+
+// UpdateByLinkContext is like UpdateByLink but uses ctx for the underlying request.
+func (s *UsergroupsServiceOp) UpdateByLinkContext(ctx context.Context, endpoint string, t *UsergroupRequestUpdate) (*Usergroup, error) {
+	ctx = withOperation(ctx, "Usergroups", "UpdateByLink")
 	enc, err := json.Marshal(t)
 	if err != nil {
 		return nil, err
@@ -300,7 +373,7 @@ func (s *UsergroupsServiceOp) UpdateByLink(endpoint string, t *UsergroupRequestU
 
 	buf := bytes.NewBuffer(enc)
 
-	resp, err := s.client.request("POST", endpoint, buf)
+	resp, err := s.client.request(ctx, "POST", endpoint, buf)
 	if err != nil {
 		return nil, err
 	}
@@ -308,7 +381,7 @@ func (s *UsergroupsServiceOp) UpdateByLink(endpoint string, t *UsergroupRequestU
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &UsergroupResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -317,12 +390,24 @@ func (s *UsergroupsServiceOp) UpdateByLink(endpoint string, t *UsergroupRequestU
 }
 
 func (s *UsergroupsServiceOp) CreateByDirectory(id string, dir *UsergroupRequestCreate) (*Usergroup, error) {
+	return s.CreateByDirectoryContext(context.Background(), id, dir)
+}
+
+// CreateByDirectoryContext is like CreateByDirectory but uses ctx for the underlying request.
+func (s *UsergroupsServiceOp) CreateByDirectoryContext(ctx context.Context, id string, dir *UsergroupRequestCreate) (*Usergroup, error) {
+	ctx = withOperation(ctx, "Usergroups", "CreateByDirectory")
 	endpoint := fmt.Sprintf("directories/%s/usergroups", id)
-	return s.CreateByLink(endpoint, dir)
+	return s.CreateByLinkContext(ctx, endpoint, dir)
 }
 
 // Create creates new apikey within tenant
 func (s *UsergroupsServiceOp) CreateByLink(endpoint string, dir *UsergroupRequestCreate) (*Usergroup, error) {
+	return s.CreateByLinkContext(context.Background(), endpoint, dir)
+}
+
+// CreateByLinkContext is like CreateByLink but uses ctx for the underlying request.
+func (s *UsergroupsServiceOp) CreateByLinkContext(ctx context.Context, endpoint string, dir *UsergroupRequestCreate) (*Usergroup, error) {
+	ctx = withOperation(ctx, "Usergroups", "CreateByLink")
 	enc, err := json.Marshal(dir)
 	if err != nil {
 		return nil, err
@@ -330,7 +415,7 @@ func (s *UsergroupsServiceOp) CreateByLink(endpoint string, dir *UsergroupReques
 
 	buf := bytes.NewBuffer(enc)
 
-	resp, err := s.client.request("POST", endpoint, buf)
+	resp, err := s.client.request(ctx, "POST", endpoint, buf)
 	if err != nil {
 		return nil, err
 	}
@@ -338,7 +423,7 @@ func (s *UsergroupsServiceOp) CreateByLink(endpoint string, dir *UsergroupReques
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &UsergroupResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -348,18 +433,36 @@ func (s *UsergroupsServiceOp) CreateByLink(endpoint string, dir *UsergroupReques
 
 // Delete removes apikey
 func (s *UsergroupsServiceOp) Delete(t *Usergroup) error {
-	return s.DeleteByLink(t.Href)
+	return s.DeleteContext(context.Background(), t)
+}
+
+// DeleteContext is like Delete but uses ctx for the underlying request.
+func (s *UsergroupsServiceOp) DeleteContext(ctx context.Context, t *Usergroup) error {
+	ctx = withOperation(ctx, "Usergroups", "Delete")
+	return s.DeleteByLinkContext(ctx, t.Href)
 }
 
 // Delete removes apikey by ID
 func (s *UsergroupsServiceOp) DeleteById(id string) error {
+	return s.DeleteByIdContext(context.Background(), id)
+}
+
+// DeleteByIdContext is like DeleteById but uses ctx for the underlying request.
+func (s *UsergroupsServiceOp) DeleteByIdContext(ctx context.Context, id string) error {
+	ctx = withOperation(ctx, "Usergroups", "DeleteById")
 	endpoint := fmt.Sprintf("usergroups/%s", id)
-	return s.DeleteByLink(endpoint)
+	return s.DeleteByLinkContext(ctx, endpoint)
 }
 
 // Delete removes apikey by link
 func (s *UsergroupsServiceOp) DeleteByLink(endpoint string) error {
-	resp, err := s.client.request("DELETE", endpoint, nil)
+	return s.DeleteByLinkContext(context.Background(), endpoint)
+}
+
+// DeleteByLinkContext is like DeleteByLink but uses ctx for the underlying request.
+func (s *UsergroupsServiceOp) DeleteByLinkContext(ctx context.Context, endpoint string) error {
+	ctx = withOperation(ctx, "Usergroups", "DeleteByLink")
+	resp, err := s.client.request(ctx, "DELETE", endpoint, nil)
 	if err != nil {
 		return err
 	}
@@ -367,7 +470,7 @@ func (s *UsergroupsServiceOp) DeleteByLink(endpoint string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent {
-		return ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return newApiError(resp, "non-ok status returned")
 	}
 	return nil
 }
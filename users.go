@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -14,21 +15,34 @@ import (
 // https://tenant-name.cloudthing.io/api/v1/users
 type UsersService interface {
 	GetCurrent(...interface{}) (*User, error)
+	GetCurrentContext(context.Context, ...interface{}) (*User, error)
 	GetById(string, ...interface{}) (*User, error)
+	GetByIdContext(context.Context, string, ...interface{}) (*User, error)
 	GetByLink(string, ...interface{}) (*User, error)
+	GetByLinkContext(context.Context, string, ...interface{}) (*User, error)
 	ListByLink(string, ...interface{}) ([]User, *ListParams, error)
+	ListByLinkContext(context.Context, string, ...interface{}) ([]User, *ListParams, error)
+	IterateByLink(context.Context, string, ...interface{}) *Iterator[User]
 	ListByDirectory(string, ...interface{}) ([]User, *ListParams, error)
+	ListByDirectoryContext(context.Context, string, ...interface{}) ([]User, *ListParams, error)
+	IterateByDirectory(context.Context, string, ...interface{}) *Iterator[User]
 	ListByUsergroup(string, ...interface{}) ([]User, *ListParams, error)
+	ListByUsergroupContext(context.Context, string, ...interface{}) ([]User, *ListParams, error)
+	IterateByUsergroup(context.Context, string, ...interface{}) *Iterator[User]
 	CreateByLink(string, *UserRequestCreate) (*User, error)
+	CreateByLinkContext(context.Context, string, *UserRequestCreate) (*User, error)
 	CreateByDirectory(string, *UserRequestCreate) (*User, error)
+	CreateByDirectoryContext(context.Context, string, *UserRequestCreate) (*User, error)
 	UpdateById(string, *UserRequestUpdate) (*User, error)
+	UpdateByIdContext(context.Context, string, *UserRequestUpdate) (*User, error)
 	UpdateByLink(string, *UserRequestUpdate) (*User, error)
+	UpdateByLinkContext(context.Context, string, *UserRequestUpdate) (*User, error)
 	Delete(*User) error
+	DeleteContext(context.Context, *User) error
 	DeleteByLink(string) error
+	DeleteByLinkContext(context.Context, string) error
 	DeleteById(string) error
-
-	get(*UserResponse) (*User, error)
-	getCollection(*UsersResponse) ([]User, *ListParams, error)
+	DeleteByIdContext(context.Context, string) error
 }
 
 // UsersServiceOp handles communication with Users related methods of API
@@ -62,11 +76,11 @@ type User struct {
 
 	Memberships []Membership `json:"memberships,omitempty"`
 
-	tenant       string `json:"tenant,omitempty"`
-	directory    string `json:"directory,omitempty"`
-	applications string `json:"applications,omitempty"`
-	usergroups   string `json:"usergroups,omitempty"`
-	memberships  string `json:"memberships,omitempty"`
+	tenant       string
+	directory    string
+	applications string
+	usergroups   string
+	memberships  string
 
 	// service for communication, internal use only
 	service *UsersServiceOp
@@ -193,9 +207,15 @@ func (t *User) Delete() error {
 
 // Get retrieves current user
 func (s *UsersServiceOp) GetCurrent(args ...interface{}) (*User, error) {
+	return s.GetCurrentContext(context.Background(), args...)
+}
+
+// GetCurrentContext is like GetCurrent but uses ctx for the underlying request.
+func (s *UsersServiceOp) GetCurrentContext(ctx context.Context, args ...interface{}) (*User, error) {
+	ctx = withOperation(ctx, "Users", "GetCurrent")
 	endpoint := "users/current"
 
-	resp, err := s.client.request("GET", endpoint, nil, args...)
+	resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -205,16 +225,16 @@ func (s *UsersServiceOp) GetCurrent(args ...interface{}) (*User, error) {
 	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnauthorized {
 		// this is probably due to redirect
 		endpoint = resp.Request.URL.String()
-		resp, err = s.client.request("GET", endpoint, nil, args...)
+		resp, err = s.client.request(ctx, "GET", endpoint, nil, args...)
 		if err != nil {
 			return nil, err
 		}
 		defer resp.Body.Close()
 		if resp.StatusCode != http.StatusOK {
-			return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+			return nil, newApiError(resp, "non-ok status returned")
 		}
 	} else if resp.StatusCode != http.StatusOK {
-		return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, newApiError(resp, "non-ok status returned")
 	}
 	user := &UserResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -224,15 +244,27 @@ func (s *UsersServiceOp) GetCurrent(args ...interface{}) (*User, error) {
 
 // GetById retrieves apikey by its ID
 func (s *UsersServiceOp) GetById(id string, args ...interface{}) (*User, error) {
+	return s.GetByIdContext(context.Background(), id, args...)
+}
+
+// GetByIdContext is like GetById but uses ctx for the underlying request.
+func (s *UsersServiceOp) GetByIdContext(ctx context.Context, id string, args ...interface{}) (*User, error) {
+	ctx = withOperation(ctx, "Users", "GetById")
 	endpoint := "users/"
 	endpoint = fmt.Sprintf("%s%s", endpoint, id)
 
-	return s.GetByLink(endpoint, args...)
+	return s.GetByLinkContext(ctx, endpoint, args...)
 }
 
 // GetById retrieves apikey by its full link
 func (s *UsersServiceOp) GetByLink(endpoint string, args ...interface{}) (*User, error) {
-	resp, err := s.client.request("GET", endpoint, nil, args...)
+	return s.GetByLinkContext(context.Background(), endpoint, args...)
+}
+
+// GetByLinkContext is like GetByLink but uses ctx for the underlying request.
+func (s *UsersServiceOp) GetByLinkContext(ctx context.Context, endpoint string, args ...interface{}) (*User, error) {
+	ctx = withOperation(ctx, "Users", "GetByLink")
+	resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -240,7 +272,7 @@ func (s *UsersServiceOp) GetByLink(endpoint string, args ...interface{}) (*User,
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &UserResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -275,7 +307,7 @@ func (s *UsersServiceOp) get(r *UserResponse) (*User, error) {
 		}
 		ten := &TenantResponse{}
 		json.Unmarshal(bytes, ten)
-		t, err := s.client.Tenant.get(ten)
+		t, err := s.client.conv.tenant.get(ten)
 		if err != nil {
 			return nil, err
 		}
@@ -288,7 +320,7 @@ func (s *UsersServiceOp) get(r *UserResponse) (*User, error) {
 		}
 		ten := &ApplicationsResponse{}
 		json.Unmarshal(bytes, ten)
-		t, _, err := s.client.Applications.getCollection(ten)
+		t, _, err := s.client.conv.applications.getCollection(ten)
 		if err != nil {
 			return nil, err
 		}
@@ -301,7 +333,7 @@ func (s *UsersServiceOp) get(r *UserResponse) (*User, error) {
 		}
 		ten := &DirectoryResponse{}
 		json.Unmarshal(bytes, ten)
-		t, err := s.client.Directories.get(ten)
+		t, err := s.client.conv.directories.get(ten)
 		if err != nil {
 			return nil, err
 		}
@@ -314,12 +346,25 @@ func (s *UsersServiceOp) get(r *UserResponse) (*User, error) {
 		}
 		ugs := &UsergroupsResponse{}
 		json.Unmarshal(bytes, ugs)
-		u, _, err := s.client.Usergroups.getCollection(ugs)
+		u, _, err := s.client.conv.usergroups.getCollection(ugs)
 		if err != nil {
 			return nil, err
 		}
 		obj.Usergroups = u
 	}
+	if len(r.Memberships) > 1 {
+		bytes, err := json.Marshal(r.Memberships)
+		if err != nil {
+			return nil, err
+		}
+		ten := &MembershipsResponse{}
+		json.Unmarshal(bytes, ten)
+		t, _, err := s.client.conv.memberships.getCollection(ten)
+		if err != nil {
+			return nil, err
+		}
+		obj.Memberships = t
+	}
 	obj.service = s
 	return obj, nil
 }
@@ -348,19 +393,51 @@ func (s *UsersServiceOp) getCollection(r *UsersResponse) ([]User, *ListParams, e
 
 // GetById retrieves collection of users of current tenant
 func (s *UsersServiceOp) ListByDirectory(id string, args ...interface{}) ([]User, *ListParams, error) {
+	return s.ListByDirectoryContext(context.Background(), id, args...)
+}
+
+// ListByDirectoryContext is like ListByDirectory but uses ctx for the underlying request.
+func (s *UsersServiceOp) ListByDirectoryContext(ctx context.Context, id string, args ...interface{}) ([]User, *ListParams, error) {
+	ctx = withOperation(ctx, "Users", "ListByDirectory")
 	endpoint := fmt.Sprintf("directories/%s/users", id)
-	return s.ListByLink(endpoint, args...)
+	return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// IterateByDirectory is like ListByDirectory but returns iterator walking over all pages
+func (s *UsersServiceOp) IterateByDirectory(ctx context.Context, id string, args ...interface{}) *Iterator[User] {
+	ctx = withOperation(ctx, "Users", "IterateByDirectory")
+	endpoint := fmt.Sprintf("directories/%s/users", id)
+	return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // ListByUsergroup retrieves collection of users of current usergroup
 func (s *UsersServiceOp) ListByUsergroup(id string, args ...interface{}) ([]User, *ListParams, error) {
+	return s.ListByUsergroupContext(context.Background(), id, args...)
+}
+
+// ListByUsergroupContext is like ListByUsergroup but uses ctx for the underlying request.
+func (s *UsersServiceOp) ListByUsergroupContext(ctx context.Context, id string, args ...interface{}) ([]User, *ListParams, error) {
+	ctx = withOperation(ctx, "Users", "ListByUsergroup")
 	endpoint := fmt.Sprintf("usergroups/%s/users", id)
-	return s.ListByLink(endpoint, args...)
+	return s.ListByLinkContext(ctx, endpoint, args...)
+}
+
+// IterateByUsergroup is like ListByUsergroup but returns iterator walking over all pages
+func (s *UsersServiceOp) IterateByUsergroup(ctx context.Context, id string, args ...interface{}) *Iterator[User] {
+	ctx = withOperation(ctx, "Users", "IterateByUsergroup")
+	endpoint := fmt.Sprintf("usergroups/%s/users", id)
+	return s.IterateByLink(ctx, endpoint, args...)
 }
 
 // GetById retrieves collection of users by link
 func (s *UsersServiceOp) ListByLink(endpoint string, args ...interface{}) ([]User, *ListParams, error) {
-	resp, err := s.client.request("GET", endpoint, nil, args...)
+	return s.ListByLinkContext(context.Background(), endpoint, args...)
+}
+
+// ListByLinkContext is like ListByLink but uses ctx for the underlying request.
+func (s *UsersServiceOp) ListByLinkContext(ctx context.Context, endpoint string, args ...interface{}) ([]User, *ListParams, error) {
+	ctx = withOperation(ctx, "Users", "ListByLink")
+	resp, err := s.client.request(ctx, "GET", endpoint, nil, args...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -368,7 +445,7 @@ func (s *UsersServiceOp) ListByLink(endpoint string, args ...interface{}) ([]Use
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &UsersResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -377,14 +454,32 @@ func (s *UsersServiceOp) ListByLink(endpoint string, args ...interface{}) ([]Use
 	return s.getCollection(obj)
 }
 
+// IterateByLink is like ListByLink but returns iterator walking over all pages
+func (s *UsersServiceOp) IterateByLink(ctx context.Context, endpoint string, args ...interface{}) *Iterator[User] {
+	ctx = withOperation(ctx, "Users", "IterateByLink")
+	return newIterator(ctx, endpoint, s.ListByLinkContext, args...)
+}
+
 // GetById updates apikey with specified ID
 func (s *UsersServiceOp) UpdateById(id string, t *UserRequestUpdate) (*User, error) {
+	return s.UpdateByIdContext(context.Background(), id, t)
+}
+
+// UpdateByIdContext is like UpdateById but uses ctx for the underlying request.
+func (s *UsersServiceOp) UpdateByIdContext(ctx context.Context, id string, t *UserRequestUpdate) (*User, error) {
+	ctx = withOperation(ctx, "Users", "UpdateById")
 	endpoint := fmt.Sprintf("users/%s", id)
-	return s.UpdateByLink(endpoint, t)
+	return s.UpdateByLinkContext(ctx, endpoint, t)
 }
 
 // GetById updates apikey specified by link
 func (s *UsersServiceOp) UpdateByLink(endpoint string, t *UserRequestUpdate) (*User, error) {
+	return s.UpdateByLinkContext(context.Background(), endpoint, t)
+}
+
+// UpdateByLinkContext is like UpdateByLink but uses ctx for the underlying request.
+func (s *UsersServiceOp) UpdateByLinkContext(ctx context.Context, endpoint string, t *UserRequestUpdate) (*User, error) {
+	ctx = withOperation(ctx, "Users", "UpdateByLink")
 	enc, err := json.Marshal(t)
 	if err != nil {
 		return nil, err
@@ -392,7 +487,7 @@ func (s *UsersServiceOp) UpdateByLink(endpoint string, t *UserRequestUpdate) (*U
 
 	buf := bytes.NewBuffer(enc)
 
-	resp, err := s.client.request("POST", endpoint, buf)
+	resp, err := s.client.request(ctx, "POST", endpoint, buf)
 	if err != nil {
 		return nil, err
 	}
@@ -400,7 +495,7 @@ func (s *UsersServiceOp) UpdateByLink(endpoint string, t *UserRequestUpdate) (*U
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &UserResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -409,12 +504,24 @@ func (s *UsersServiceOp) UpdateByLink(endpoint string, t *UserRequestUpdate) (*U
 }
 
 func (s *UsersServiceOp) CreateByDirectory(id string, dir *UserRequestCreate) (*User, error) {
+	return s.CreateByDirectoryContext(context.Background(), id, dir)
+}
+
+// CreateByDirectoryContext is like CreateByDirectory but uses ctx for the underlying request.
+func (s *UsersServiceOp) CreateByDirectoryContext(ctx context.Context, id string, dir *UserRequestCreate) (*User, error) {
+	ctx = withOperation(ctx, "Users", "CreateByDirectory")
 	endpoint := fmt.Sprintf("directories/%s/users", id)
-	return s.CreateByLink(endpoint, dir)
+	return s.CreateByLinkContext(ctx, endpoint, dir)
 }
 
 // Create creates new apikey within tenant
 func (s *UsersServiceOp) CreateByLink(endpoint string, dir *UserRequestCreate) (*User, error) {
+	return s.CreateByLinkContext(context.Background(), endpoint, dir)
+}
+
+// CreateByLinkContext is like CreateByLink but uses ctx for the underlying request.
+func (s *UsersServiceOp) CreateByLinkContext(ctx context.Context, endpoint string, dir *UserRequestCreate) (*User, error) {
+	ctx = withOperation(ctx, "Users", "CreateByLink")
 	enc, err := json.Marshal(dir)
 	if err != nil {
 		return nil, err
@@ -422,7 +529,7 @@ func (s *UsersServiceOp) CreateByLink(endpoint string, dir *UserRequestCreate) (
 
 	buf := bytes.NewBuffer(enc)
 
-	resp, err := s.client.request("POST", endpoint, buf)
+	resp, err := s.client.request(ctx, "POST", endpoint, buf)
 	if err != nil {
 		return nil, err
 	}
@@ -430,7 +537,7 @@ func (s *UsersServiceOp) CreateByLink(endpoint string, dir *UserRequestCreate) (
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return nil, ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return nil, newApiError(resp, "non-ok status returned")
 	}
 	obj := &UserResponse{}
 	dec := json.NewDecoder(resp.Body)
@@ -440,18 +547,36 @@ func (s *UsersServiceOp) CreateByLink(endpoint string, dir *UserRequestCreate) (
 
 // Delete removes apikey
 func (s *UsersServiceOp) Delete(t *User) error {
-	return s.DeleteByLink(t.Href)
+	return s.DeleteContext(context.Background(), t)
+}
+
+// DeleteContext is like Delete but uses ctx for the underlying request.
+func (s *UsersServiceOp) DeleteContext(ctx context.Context, t *User) error {
+	ctx = withOperation(ctx, "Users", "Delete")
+	return s.DeleteByLinkContext(ctx, t.Href)
 }
 
 // Delete removes apikey by ID
 func (s *UsersServiceOp) DeleteById(id string) error {
+	return s.DeleteByIdContext(context.Background(), id)
+}
+
+// DeleteByIdContext is like DeleteById but uses ctx for the underlying request.
+func (s *UsersServiceOp) DeleteByIdContext(ctx context.Context, id string) error {
+	ctx = withOperation(ctx, "Users", "DeleteById")
 	endpoint := fmt.Sprintf("users/%s", id)
-	return s.DeleteByLink(endpoint)
+	return s.DeleteByLinkContext(ctx, endpoint)
 }
 
 // Delete removes apikey by link
 func (s *UsersServiceOp) DeleteByLink(endpoint string) error {
-	resp, err := s.client.request("DELETE", endpoint, nil)
+	return s.DeleteByLinkContext(context.Background(), endpoint)
+}
+
+// DeleteByLinkContext is like DeleteByLink but uses ctx for the underlying request.
+func (s *UsersServiceOp) DeleteByLinkContext(ctx context.Context, endpoint string) error {
+	ctx = withOperation(ctx, "Users", "DeleteByLink")
+	resp, err := s.client.request(ctx, "DELETE", endpoint, nil)
 	if err != nil {
 		return err
 	}
@@ -459,7 +584,7 @@ func (s *UsersServiceOp) DeleteByLink(endpoint string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent {
-		return ApiError{StatusCode: resp.StatusCode, Message: "non-ok status returned"}
+		return newApiError(resp, "non-ok status returned")
 	}
 	return nil
 }
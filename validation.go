@@ -0,0 +1,283 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SchemaError is returned by writes of points which don't match resources declared
+// by product of device. Nothing is sent when it is returned. It matches ErrValidation.
+type SchemaError struct {
+	// ID of device the points were written to
+	DeviceId string
+	// Link to product of device
+	Product string
+	// Problems of particular points, e.g. field "data[2].key"
+	Errors []FieldError
+}
+
+func (e *SchemaError) Error() string {
+	fields := make([]string, len(e.Errors))
+	for i, f := range e.Errors {
+		fields[i] = fmt.Sprintf("%s: %s", f.Field, f.Message)
+	}
+	return fmt.Sprintf("points don't match resources of product %s of device %s (%s)", e.Product, e.DeviceId, strings.Join(fields, ", "))
+}
+
+// Is allows to match SchemaError with ErrValidation
+func (e *SchemaError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Codes of FieldError in SchemaError
+const (
+	SchemaUndeclaredKey     = "undeclared_key"
+	SchemaUndeclaredPayload = "undeclared_payload"
+	SchemaInvalidPayload    = "invalid_payload"
+)
+
+// productSchema is cached definition of resources of a product
+type productSchema struct {
+	product   string
+	resources *ProductResources
+	expires   time.Time
+}
+
+// schemaCache holds products of devices used for validation of writes
+type schemaCache struct {
+	// Time after which definitions are loaded again, zero for never
+	ttl time.Duration
+
+	mu      sync.Mutex
+	devices map[string]*productSchema
+}
+
+func newSchemaCache(ttl time.Duration) *schemaCache {
+	return &schemaCache{ttl: ttl, devices: make(map[string]*productSchema)}
+}
+
+// WithSchemaValidation enables validation of points written to devices against
+// resources declared by their products, see Client.SetSchemaValidation
+func WithSchemaValidation(ttl time.Duration) Option {
+	return func(o *clientOptions) error {
+		o.schemaValidation = true
+		o.schemaTTL = ttl
+		return nil
+	}
+}
+
+// SetSchemaValidation switches validation of points written to devices. When enabled,
+// product of device is loaded with the first write and cached for ttl (forever if zero).
+// Keys of data and events and payloads of commands which the product doesn't declare
+// are rejected with SchemaError. Products without declared resources aren't validated.
+// Points written to clusters aren't validated either.
+func (c *Client) SetSchemaValidation(enabled bool, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !enabled {
+		c.schemas = nil
+		return
+	}
+	c.schemas = newSchemaCache(ttl)
+}
+
+// InvalidateSchemas removes cached products, e.g. after resources of a product were changed
+func (c *Client) InvalidateSchemas() {
+	c.mu.Lock()
+	schemas := c.schemas
+	c.mu.Unlock()
+	if schemas == nil {
+		return
+	}
+	schemas.mu.Lock()
+	schemas.devices = make(map[string]*productSchema)
+	schemas.mu.Unlock()
+}
+
+// schemaOf returns product link and resources of device, loading device with product if not cached
+func (c *Client) schemaOf(ctx context.Context, schemas *schemaCache, deviceId string) (*productSchema, error) {
+	schemas.mu.Lock()
+	s, ok := schemas.devices[deviceId]
+	schemas.mu.Unlock()
+	if ok && (s.expires.IsZero() || time.Now().Before(s.expires)) {
+		return s, nil
+	}
+
+	ctx = newOperation(ctx, "Devices", "GetById")
+	device, err := c.conv.devices.GetByIdContext(ctx, deviceId, Expand("product"))
+	if err != nil {
+		return nil, err
+	}
+	s = &productSchema{}
+	_, s.product = device.ProductLink()
+	if device.Product != nil {
+		s.resources = device.Product.Resources
+	}
+	if schemas.ttl > 0 {
+		s.expires = time.Now().Add(schemas.ttl)
+	}
+	schemas.mu.Lock()
+	schemas.devices[deviceId] = s
+	schemas.mu.Unlock()
+	return s, nil
+}
+
+// parseResourceLink extracts ID of device, kind of resources and key from link,
+// e.g. "devices/ID/resources/data/KEY". Empty device ID is returned for other links.
+func parseResourceLink(link string) (deviceId, kind, key string) {
+	if u, err := url.Parse(link); err == nil {
+		link = u.Path
+	}
+	segments := strings.Split(strings.Trim(link, "/"), "/")
+	for i := len(segments) - 2; i >= 2; i-- {
+		if segments[i] != "resources" || segments[i-2] != "devices" {
+			continue
+		}
+		deviceId, kind = segments[i-1], segments[i+1]
+		if i+2 < len(segments) {
+			key = segments[i+2]
+		}
+		return
+	}
+	return "", "", ""
+}
+
+// validateWrite checks points written to link against product of device, if enabled
+func (c *Client) validateWrite(ctx context.Context, link string, points interface{}) error {
+	c.mu.Lock()
+	schemas := c.schemas
+	c.mu.Unlock()
+	if schemas == nil {
+		return nil
+	}
+	deviceId, _, linkKey := parseResourceLink(link)
+	if deviceId == "" {
+		return nil
+	}
+	schema, err := c.schemaOf(ctx, schemas, deviceId)
+	if err != nil {
+		return err
+	}
+	if schema.resources == nil {
+		return nil
+	}
+
+	var errs []FieldError
+	keyOf := func(key string) string {
+		if linkKey != "" {
+			return linkKey
+		}
+		return key
+	}
+	switch p := points.(type) {
+	case []DataPoint:
+		for i, point := range p {
+			errs = append(errs, checkKey(fmt.Sprintf("data[%d]", i), keyOf(point.Key), schema.resources.Data)...)
+		}
+	case []EventPoint:
+		for i, point := range p {
+			errs = append(errs, checkKey(fmt.Sprintf("events[%d]", i), keyOf(point.Key), schema.resources.Events)...)
+		}
+	case []CommandPoint:
+		for i, point := range p {
+			errs = append(errs, checkCommand(fmt.Sprintf("commands[%d]", i), keyOf(point.Key), point.Payload, schema.resources.Commands)...)
+		}
+	}
+	if len(errs) > 0 {
+		return &SchemaError{DeviceId: deviceId, Product: schema.product, Errors: errs}
+	}
+	return nil
+}
+
+// checkKey checks that key is declared among resources
+func checkKey(field, key string, declared []ProductSimpleResource) []FieldError {
+	for _, r := range declared {
+		if r.Id == key {
+			return nil
+		}
+	}
+	return []FieldError{{
+		Field:   field + ".key",
+		Code:    SchemaUndeclaredKey,
+		Message: fmt.Sprintf("key %q is not declared by product", key),
+	}}
+}
+
+// checkCommand checks that command is declared and its payload is an object
+// containing only declared payloads in their serializations
+func checkCommand(field, key string, payload interface{}, declared []ProductCommandResource) []FieldError {
+	var command *ProductCommandResource
+	for i := range declared {
+		if declared[i].Id == key {
+			command = &declared[i]
+			break
+		}
+	}
+	if command == nil {
+		return []FieldError{{
+			Field:   field + ".key",
+			Code:    SchemaUndeclaredKey,
+			Message: fmt.Sprintf("command %q is not declared by product", key),
+		}}
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return []FieldError{{Field: field + ".payload", Code: SchemaInvalidPayload, Message: err.Error()}}
+	}
+	if string(b) == "null" {
+		return nil
+	}
+	if len(command.Payloads) == 0 {
+		return []FieldError{{
+			Field:   field + ".payload",
+			Code:    SchemaUndeclaredPayload,
+			Message: fmt.Sprintf("command %q declares no payloads", key),
+		}}
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return []FieldError{{
+			Field:   field + ".payload",
+			Code:    SchemaInvalidPayload,
+			Message: fmt.Sprintf("payload of command %q has to be an object of declared payloads", key),
+		}}
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var errs []FieldError
+	for _, name := range names {
+		p, ok := command.Payload(name)
+		if !ok {
+			errs = append(errs, FieldError{
+				Field:   fmt.Sprintf("%s.payload.%s", field, name),
+				Code:    SchemaUndeclaredPayload,
+				Message: fmt.Sprintf("payload %q is not declared by command %q", name, key),
+			})
+			continue
+		}
+		// serializations without codec can't be checked
+		codec, err := PayloadCodecFor(p.Serialization)
+		if err != nil {
+			continue
+		}
+		var v interface{}
+		if err := codec.Decode(fields[name], &v); err != nil {
+			errs = append(errs, FieldError{
+				Field:   fmt.Sprintf("%s.payload.%s", field, name),
+				Code:    SchemaInvalidPayload,
+				Message: fmt.Sprintf("payload %q isn't valid %s: %v", name, p.Serialization, err),
+			})
+		}
+	}
+	return errs
+}
@@ -0,0 +1,128 @@
+package api_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	api "github.com/cloudthing-io/go-client-api"
+	"github.com/cloudthing-io/go-client-api/apitest"
+)
+
+// schemaDevice adds device of product declaring data "t", event "alarm" and
+// command "set" with base64 payload "blob"
+func schemaDevice(srv *apitest.Server) string {
+	product := srv.Add("products", map[string]interface{}{
+		"name": "Lamp",
+		"resources": map[string]interface{}{
+			"data":   []map[string]interface{}{{"id": "t"}},
+			"events": []map[string]interface{}{{"id": "alarm"}},
+			"commands": []map[string]interface{}{{
+				"id":       "set",
+				"payloads": []map[string]interface{}{{"name": "blob", "serialization": api.SerializationBase64}},
+			}},
+		},
+	}, nil)
+	return srv.Add("devices", map[string]interface{}{}, map[string]string{"product": "products/" + product})
+}
+
+// schemaErrors returns codes of fields of SchemaError err
+func schemaErrors(t *testing.T, err error) map[string]string {
+	t.Helper()
+	var schemaErr *api.SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("error = %v, want SchemaError", err)
+	}
+	if !errors.Is(err, api.ErrValidation) {
+		t.Errorf("SchemaError doesn't match ErrValidation")
+	}
+	fields := make(map[string]string)
+	for _, f := range schemaErr.Errors {
+		fields[f.Field] = f.Code
+	}
+	return fields
+}
+
+func TestSchemaValidation(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClient(api.WithSchemaValidation(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := schemaDevice(srv)
+	link := "devices/" + id + "/resources/"
+
+	_, err = client.Resources.WriteDataForDeviceID(id, []api.DataPoint{{Key: "t", Value: 1.0}, {Key: "humidity", Value: 2.0}})
+	if f := schemaErrors(t, err); len(f) != 1 || f["data[1].key"] != api.SchemaUndeclaredKey {
+		t.Errorf("errors of data = %v, want undeclared data[1].key", f)
+	}
+	_, err = client.Resources.WriteEventsForDeviceID(id, []api.EventPoint{{Key: "fire"}})
+	if f := schemaErrors(t, err); len(f) != 1 || f["events[0].key"] != api.SchemaUndeclaredKey {
+		t.Errorf("errors of events = %v, want undeclared events[0].key", f)
+	}
+	_, err = client.Resources.WriteCommandsForDeviceID(id, []api.CommandPoint{
+		{Key: "reboot"},
+		{Key: "set", Payload: map[string]interface{}{"blob": "!!!", "color": "red"}},
+	})
+	f := schemaErrors(t, err)
+	if len(f) != 3 || f["commands[0].key"] != api.SchemaUndeclaredKey ||
+		f["commands[1].payload.blob"] != api.SchemaInvalidPayload ||
+		f["commands[1].payload.color"] != api.SchemaUndeclaredPayload {
+		t.Errorf("errors of commands = %v, want undeclared command, invalid blob and undeclared color", f)
+	}
+	if n := countRequests(srv, "POST", link+"data") + countRequests(srv, "POST", link+"events") + countRequests(srv, "POST", link+"commands"); n != 0 {
+		t.Errorf("%d invalid writes reached server", n)
+	}
+
+	// valid points are written, key in link takes precedence over keys of points
+	if _, err := client.Resources.WriteDataForDeviceID(id, []api.DataPoint{{Key: "t", Value: 1.0}}); err != nil {
+		t.Error(err)
+	}
+	if _, err := client.Resources.WriteEventsForLink(link+"events/alarm", []api.EventPoint{{}}); err != nil {
+		t.Error(err)
+	}
+	if _, err := client.Resources.WriteCommandsForDeviceID(id, []api.CommandPoint{{Key: "set", Payload: map[string]interface{}{"blob": "AQI="}}}); err != nil {
+		t.Error(err)
+	}
+	if n := countRequests(srv, "GET", "devices/"+id); n != 1 {
+		t.Errorf("device loaded %d times, want once as ttl is infinite", n)
+	}
+}
+
+func TestSchemaCache(t *testing.T) {
+	srv := apitest.NewServer()
+	defer srv.Close()
+	client, err := srv.NewClient(api.WithSchemaValidation(50 * time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := schemaDevice(srv)
+	write := func() {
+		t.Helper()
+		if _, err := client.Resources.WriteDataForDeviceID(id, []api.DataPoint{{Key: "t", Value: 1.0}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write()
+	write()
+	if n := countRequests(srv, "GET", "devices/"+id); n != 1 {
+		t.Errorf("device loaded %d times within ttl, want once", n)
+	}
+	time.Sleep(100 * time.Millisecond)
+	write()
+	if n := countRequests(srv, "GET", "devices/"+id); n != 2 {
+		t.Errorf("device loaded %d times after ttl, want twice", n)
+	}
+	client.InvalidateSchemas()
+	write()
+	if n := countRequests(srv, "GET", "devices/"+id); n != 3 {
+		t.Errorf("device loaded %d times after InvalidateSchemas, want 3 times", n)
+	}
+
+	client.SetSchemaValidation(false, 0)
+	if _, err := client.Resources.WriteDataForDeviceID(id, []api.DataPoint{{Key: "humidity", Value: 1.0}}); err != nil {
+		t.Errorf("write with validation disabled = %v", err)
+	}
+}